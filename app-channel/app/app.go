@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math/big"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -26,14 +28,28 @@ import (
 	"perun.network/go-perun/channel"
 )
 
+// DefaultMoveTimeout is the default time a player has for making a move.
+const DefaultMoveTimeout = 30 * time.Second
+
 // TicTacToeApp is a channel app.
+//
+// Every move sets a deadline by which the opponent has to make the next move.
+// If the opponent misses the deadline, the waiting player may claim a forfeit.
+// The claim pays out the forfeited rounds but does not finalize the state, so
+// the next actor can still answer it with a move, which reverts the payout.
+// Neither the app nor the contract can read the time, so the deadline itself
+// is only checked by the clients. On-chain, the claim is a progress of the
+// channel, after which the next actor has one challenge duration to answer
+// before the channel can be concluded with the claimed state.
 type TicTacToeApp struct {
-	ID channel.AppID
+	ID          channel.AppID
+	MoveTimeout time.Duration // The time a player has for making a move.
 }
 
 func NewTicTacToeApp(addr *ethwallet.Address) *TicTacToeApp {
 	return &TicTacToeApp{
-		ID: &ethchannel.AppID{addr},
+		ID:          &ethchannel.AppID{Address: addr},
+		MoveTimeout: DefaultMoveTimeout,
 	}
 }
 
@@ -46,44 +62,76 @@ func (a *TicTacToeApp) NewData() channel.Data {
 	return &TicTacToeAppData{}
 }
 
-func (a *TicTacToeApp) InitData(firstActor channel.Index) *TicTacToeAppData {
-	return &TicTacToeAppData{
-		NextActor: uint8(firstActor),
+// InitData creates the initial data for a match on the given board. In each
+// round, roundStake is at stake per asset and the loser pays according to
+// payout.
+func (a *TicTacToeApp) InitData(firstActor channel.Index, board BoardConfig, match MatchConfig, roundStake []channel.Bal, payout Payout) *TicTacToeAppData {
+	d := &TicTacToeAppData{
+		NextActor:   uint8(firstActor),
+		BoardConfig: board,
+		MatchConfig: match,
+		FirstActor:  uint8(firstActor),
+		RoundStake:  roundStake,
+		Payout:      payout,
+		Grid:        make([]FieldValue, board.NumFields()),
 	}
+	d.setDeadline(time.Now().Add(a.MoveTimeout))
+	return d
 }
 
 // DecodeData decodes the channel data.
 func (a *TicTacToeApp) DecodeData(r io.Reader) (channel.Data, error) {
-	d := TicTacToeAppData{}
-
-	var err error
-	d.NextActor, err = readUInt8(r)
-	if err != nil {
-		return nil, errors.WithMessage(err, "reading actor")
-	}
-
-	grid, err := readUInt8Array(r, len(d.Grid))
-	if err != nil {
-		return nil, errors.WithMessage(err, "reading grid")
-	}
-	copy(d.Grid[:], makeFieldValueArray(grid))
-	return &d, nil
+	return decodeTicTacToeAppData(r)
 }
 
 // ValidInit checks that the initial state is valid.
 func (a *TicTacToeApp) ValidInit(p *channel.Params, s *channel.State) error {
-	if len(p.Parts) != numParts {
-		return fmt.Errorf("invalid number of participants: expected %d, got %d", numParts, len(p.Parts))
-	}
-
 	appData, ok := s.Data.(*TicTacToeAppData)
 	if !ok {
 		return fmt.Errorf("invalid data type: %T", s.Data)
 	}
 
-	zero := TicTacToeAppData{}
-	if appData.Grid != zero.Grid {
-		return fmt.Errorf("invalid starting grid: %v", appData.Grid)
+	if len(p.Parts) != appData.Payout.NumParts() {
+		return fmt.Errorf("invalid number of participants: expected %d, got %d", appData.Payout.NumParts(), len(p.Parts))
+	}
+
+	if err := appData.BoardConfig.Validate(); err != nil {
+		return errors.WithMessage(err, "invalid board")
+	}
+
+	if len(appData.Grid) != appData.NumFields() {
+		return fmt.Errorf("invalid grid size: expected %d, got %d", appData.NumFields(), len(appData.Grid))
+	}
+	for _, v := range appData.Grid {
+		if v != notSet {
+			return fmt.Errorf("invalid starting grid: %v", appData.Grid)
+		}
+	}
+
+	if err := appData.MatchConfig.Validate(); err != nil {
+		return errors.WithMessage(err, "invalid match")
+	}
+
+	if appData.Round != 0 || appData.Wins != [numParts]uint8{} || appData.LastMove != 0 ||
+		appData.FirstActor != appData.NextActor || appData.Forfeit {
+		return fmt.Errorf("invalid starting match state")
+	}
+
+	// Each player must be able to pay the round stake in every round.
+	if len(appData.RoundStake) != len(s.Assets) {
+		return fmt.Errorf("invalid number of round stakes: expected %d, got %d", len(s.Assets), len(appData.RoundStake))
+	}
+	for i, stake := range appData.RoundStake {
+		required := new(big.Int).Mul(stake, big.NewInt(int64(appData.Rounds)))
+		for j, bal := range s.Balances[i][:numParts] {
+			if bal.Cmp(required) < 0 {
+				return fmt.Errorf("insufficient balance of participant %d for asset %d: %v < %v", j, i, bal, required)
+			}
+		}
+	}
+
+	if err := appData.Payout.Validate(appData.RoundStake); err != nil {
+		return errors.WithMessage(err, "invalid payout")
 	}
 
 	if s.IsFinal {
@@ -114,48 +162,54 @@ func (a *TicTacToeApp) ValidTransition(params *channel.Params, from, to *channel
 	}
 
 	// Check actor.
+	if len(params.Parts) != fromData.Payout.NumParts() {
+		return fmt.Errorf("invalid number of participants: %d", len(params.Parts))
+	}
+	if idx >= numParts {
+		return fmt.Errorf("the house must not act")
+	}
 	if fromData.NextActor != uint8safe(uint16(idx)) {
-		return fmt.Errorf("invalid actor: expected %v, got %v", fromData.NextActor, idx)
+		// The waiting player may only claim a forfeit.
+		return validForfeit(from, to, idx)
 	}
+	claimer := channel.Index(calcNextActor(fromData.NextActor))
 
-	// Check next actor.
-	if len(params.Parts) != numParts {
-		panic("invalid number of participants")
+	// Check move.
+	if toData.LastMove == 0 || int(toData.LastMove) > len(fromData.Grid) {
+		return fmt.Errorf("invalid move: %d", toData.LastMove)
 	}
-	expectedToNextActor := calcNextActor(fromData.NextActor)
-	if toData.NextActor != expectedToNextActor {
-		return fmt.Errorf("invalid next actor: expected %v, got %v", expectedToNextActor, toData.NextActor)
+	field := int(toData.LastMove) - 1
+	if v := fromData.Grid[field]; v != notSet {
+		return fmt.Errorf("cannot overwrite field %d", field)
 	}
 
-	// Check grid.
-	changed := false
-	for i, v := range toData.Grid {
-		if v > maxFieldValue {
-			return fmt.Errorf("invalid grid value at index %d: %d", i, v)
-		}
-		vFrom := fromData.Grid[i]
-		if v != vFrom {
-			if vFrom != notSet {
-				return fmt.Errorf("cannot overwrite field %d", i)
-			}
-			if changed {
-				return fmt.Errorf("cannot change two fields")
-			}
-			changed = true
-		}
+	// Check deadline.
+	if toData.Deadline < fromData.Deadline {
+		return fmt.Errorf("deadline must not decrease: from %v, got %v", fromData.Deadline, toData.Deadline)
 	}
-	if !changed {
-		return fmt.Errorf("cannot skip turn")
+
+	// Check data by applying the move. This also advances the match if the
+	// move ends the round and answers a claimed forfeit.
+	expectedData := fromData.Clone().(*TicTacToeAppData) //nolint:forcetypeassert
+	expectedData.Forfeit = false
+	_, winner, matchOver := expectedData.play(field, idx)
+	expectedData.Deadline = toData.Deadline
+	if !expectedData.Equal(toData) {
+		return fmt.Errorf("invalid data: expected %v, got %v", expectedData, toData)
 	}
 
 	// Check final and allocation.
-	isFinal, winner := toData.CheckFinal()
-	if to.IsFinal != isFinal {
-		return fmt.Errorf("final flag: expected %v, got %v", isFinal, to.IsFinal)
+	if to.IsFinal != matchOver {
+		return fmt.Errorf("final flag: expected %v, got %v", matchOver, to.IsFinal)
 	}
 	expectedAllocation := from.Allocation.Clone()
+	if fromData.Forfeit {
+		if expectedAllocation.Balances, err = fromData.revertForfeitBalances(from.Allocation.Balances, claimer); err != nil {
+			return errors.WithMessage(err, "answering forfeit")
+		}
+	}
 	if winner != nil {
-		expectedAllocation.Balances = computeFinalBalances(from.Allocation.Balances, *winner)
+		expectedAllocation.Balances = fromData.computeRoundBalances(expectedAllocation.Balances, *winner)
 	}
 	if err := expectedAllocation.Equal(&to.Allocation); err != nil {
 		return errors.WithMessagef(err, "wrong allocation: expected %v, got %v", expectedAllocation, to.Allocation)
@@ -169,14 +223,83 @@ func (a *TicTacToeApp) Set(s *channel.State, x, y int, actorIdx channel.Index) e
 		return fmt.Errorf("invalid data type: %T", d)
 	}
 
-	d.Set(x, y, actorIdx)
-	log.Println("\n" + d.String())
+	if !d.onBoard(x, y) {
+		return fmt.Errorf("invalid field: (%d, %d)", x, y)
+	}
 
-	if isFinal, winner := d.CheckFinal(); isFinal {
-		s.IsFinal = true
-		if winner != nil {
-			s.Balances = computeFinalBalances(s.Balances, *winner)
+	// A move answers a claimed forfeit.
+	if d.Forfeit {
+		bals, err := d.revertForfeitBalances(s.Balances, channel.Index(calcNextActor(d.NextActor)))
+		if err != nil {
+			return err
 		}
+		s.Balances = bals
+		d.Forfeit = false
+	}
+
+	roundOver, winner, matchOver := d.play(y*int(d.Cols)+x, actorIdx)
+	d.setDeadline(time.Now().Add(a.MoveTimeout))
+	log.Println("\n" + d.String())
+
+	if roundOver && winner != nil {
+		s.Balances = d.computeRoundBalances(s.Balances, *winner)
+	}
+	s.IsFinal = matchOver
+	return nil
+}
+
+// Forfeit claims a forfeit for the waiting player because the next actor
+// missed the deadline. The next actor loses the current round and every
+// further round the match would last if the waiting player won them all. The
+// state does not become final: the next actor may still answer with a move.
+func (a *TicTacToeApp) Forfeit(s *channel.State, actorIdx channel.Index) error {
+	d, ok := s.Data.(*TicTacToeAppData)
+	if !ok {
+		return fmt.Errorf("invalid data type: %T", d)
+	}
+	if actorIdx >= numParts {
+		return fmt.Errorf("the house cannot claim a forfeit")
+	}
+	if d.NextActor == uint8safe(uint16(actorIdx)) {
+		return fmt.Errorf("cannot forfeit own turn")
+	}
+	if d.Forfeit {
+		return fmt.Errorf("forfeit already claimed")
+	}
+
+	bals, err := d.computeForfeitBalances(s.Balances, actorIdx)
+	if err != nil {
+		return err
+	}
+	d.Forfeit = true
+	s.Balances = bals
+	return nil
+}
+
+// validForfeit checks that the transition is a forfeit claimed by the waiting
+// player idx: only the forfeit flag is set, the state is not final and the
+// waiting player wins the forfeited rounds.
+func validForfeit(from, to *channel.State, idx channel.Index) error {
+	fromData := from.Data.(*TicTacToeAppData) //nolint:forcetypeassert // Checked by caller.
+	toData := to.Data.(*TicTacToeAppData)     //nolint:forcetypeassert // Checked by caller.
+	if fromData.Forfeit {
+		return fmt.Errorf("invalid actor: expected %v, got %v, forfeit already claimed", fromData.NextActor, idx)
+	}
+	expectedData := fromData.Clone().(*TicTacToeAppData) //nolint:forcetypeassert
+	expectedData.Forfeit = true
+	if !expectedData.Equal(toData) {
+		return fmt.Errorf("invalid actor: expected %v, got %v", fromData.NextActor, idx)
+	}
+	if to.IsFinal {
+		return fmt.Errorf("forfeit: must not be final")
+	}
+	expectedAllocation := from.Allocation.Clone()
+	var err error
+	if expectedAllocation.Balances, err = fromData.computeForfeitBalances(from.Allocation.Balances, idx); err != nil {
+		return errors.WithMessage(err, "forfeit")
+	}
+	if err := expectedAllocation.Equal(&to.Allocation); err != nil {
+		return errors.WithMessagef(err, "forfeit: wrong allocation: expected %v, got %v", expectedAllocation, to.Allocation)
 	}
 	return nil
 }
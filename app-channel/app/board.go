@@ -0,0 +1,148 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"perun.network/go-perun/channel"
+)
+
+// BoardConfig describes an m,n,k-game: a board with Rows × Cols fields on
+// which a player wins by getting WinLength marks in a horizontal, vertical or
+// diagonal line.
+type BoardConfig struct {
+	Rows      uint8
+	Cols      uint8
+	WinLength uint8
+}
+
+// DefaultBoardConfig is the board of classic tic-tac-toe.
+var DefaultBoardConfig = BoardConfig{Rows: 3, Cols: 3, WinLength: 3}
+
+// BoardLimits are the board dimensions a client is willing to play on.
+type BoardLimits struct {
+	MaxRows uint8
+	MaxCols uint8
+}
+
+// DefaultBoardLimits allows boards up to the size of a gomoku board.
+var DefaultBoardLimits = BoardLimits{MaxRows: 15, MaxCols: 15}
+
+// NewBoardConfig returns the board with the given dimensions and win length.
+// It fails if a value does not fit into the app data or if the board is not
+// valid.
+func NewBoardConfig(rows, cols, winLength uint) (BoardConfig, error) {
+	for _, v := range []struct {
+		name string
+		v    uint
+	}{{"rows", rows}, {"columns", cols}, {"win length", winLength}} {
+		if v.v > math.MaxUint8 {
+			return BoardConfig{}, fmt.Errorf("number of %s out of range: %d > %d", v.name, v.v, math.MaxUint8)
+		}
+	}
+	b := BoardConfig{Rows: uint8(rows), Cols: uint8(cols), WinLength: uint8(winLength)}
+	return b, b.Validate()
+}
+
+// NumFields returns the number of fields on the board.
+func (b BoardConfig) NumFields() int {
+	return int(b.Rows) * int(b.Cols)
+}
+
+// Validate checks that a line of WinLength fits onto the board.
+func (b BoardConfig) Validate() error {
+	if b.Rows == 0 || b.Cols == 0 {
+		return fmt.Errorf("empty board: %dx%d", b.Rows, b.Cols)
+	}
+	if b.WinLength == 0 || (b.WinLength > b.Rows && b.WinLength > b.Cols) {
+		return fmt.Errorf("win length %d does not fit on %dx%d board", b.WinLength, b.Rows, b.Cols)
+	}
+	return nil
+}
+
+// Allows returns an error if the board exceeds the limits.
+func (l BoardLimits) Allows(b BoardConfig) error {
+	if b.Rows > l.MaxRows || b.Cols > l.MaxCols {
+		return fmt.Errorf("board %dx%d exceeds limit %dx%d", b.Rows, b.Cols, l.MaxRows, l.MaxCols)
+	}
+	return nil
+}
+
+// directions are the line directions that need to be checked for a winner:
+// horizontal, vertical, diagonal and anti-diagonal.
+var directions = [][2]int{{1, 0}, {0, 1}, {1, 1}, {1, -1}}
+
+func (b BoardConfig) onBoard(x, y int) bool {
+	return x >= 0 && x < int(b.Cols) && y >= 0 && y < int(b.Rows)
+}
+
+// CheckFinal checks the whole board for a winner or a full board.
+func (d TicTacToeAppData) CheckFinal() (isFinal bool, winner *channel.Index) {
+	for i := range d.Grid {
+		if ok, idx := d.lineThrough(i); ok {
+			return true, &idx
+		}
+	}
+	return d.isFull(), nil
+}
+
+// checkFinalAt checks whether the mark at field i ends the game. As any new
+// line must contain the latest mark, only the lines through this field need
+// to be checked.
+func (d TicTacToeAppData) checkFinalAt(i int) (isFinal bool, winner *channel.Index) {
+	if ok, idx := d.lineThrough(i); ok {
+		return true, &idx
+	}
+	return d.isFull(), nil
+}
+
+// lineThrough checks whether field i is part of a line of at least WinLength
+// marks of the same player.
+func (d TicTacToeAppData) lineThrough(i int) (ok bool, player channel.Index) {
+	v := d.Grid[i]
+	if v == notSet {
+		return false, 0
+	}
+
+	x, y := i%int(d.Cols), i/int(d.Cols)
+	for _, dir := range directions {
+		n := 1 + d.countFrom(x, y, dir[0], dir[1], v) + d.countFrom(x, y, -dir[0], -dir[1], v)
+		if n >= int(d.WinLength) {
+			return true, v.PlayerIndex()
+		}
+	}
+	return false, 0
+}
+
+// countFrom counts the consecutive fields with value v starting next to
+// (x, y) in direction (dx, dy).
+func (d TicTacToeAppData) countFrom(x, y, dx, dy int, v FieldValue) (n int) {
+	for x, y = x+dx, y+dy; d.onBoard(x, y) && d.Grid[y*int(d.Cols)+x] == v; x, y = x+dx, y+dy {
+		n++
+	}
+	return n
+}
+
+// isFull returns whether all fields are set.
+func (d TicTacToeAppData) isFull() bool {
+	for _, v := range d.Grid {
+		if v == notSet {
+			return false
+		}
+	}
+	return true
+}
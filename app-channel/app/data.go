@@ -4,77 +4,254 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"perun.network/go-perun/channel"
 )
 
 // TicTacToeAppData is the app data struct.
-// Grid:
+// Deadline is the Unix time in seconds by which the next actor must move.
+// Forfeit is set while a forfeit claimed by the waiting player is unanswered.
+// The board has the dimensions given by the BoardConfig and the grid is
+// stored row by row, e.g., for a 3×3 board:
 // 0 1 2
 // 3 4 5
 // 6 7 8
+// A match consists of several rounds as given by the MatchConfig. After each
+// round, the loser pays according to the Payout and the board is reset.
 type TicTacToeAppData struct {
 	NextActor uint8
-	Grid      [9]FieldValue
+	Deadline  uint64
+	Forfeit   bool
+	BoardConfig
+	MatchConfig
+	Round      uint8           // The current round, starting at 0.
+	FirstActor uint8           // The player who opened the current round.
+	Wins       [numParts]uint8 // The number of rounds won by each player.
+	LastMove   uint16          // The field of the last mark plus one, or 0 if there was none.
+	RoundStake []channel.Bal   // The amount per asset at stake in a round.
+	Payout     Payout
+	Grid       []FieldValue
 }
 
 func (d *TicTacToeAppData) String() string {
 	var b bytes.Buffer
-	fmt.Fprintf(&b, "%v|%v|%v\n", d.Grid[0], d.Grid[1], d.Grid[2])
-	fmt.Fprintf(&b, "%v|%v|%v\n", d.Grid[3], d.Grid[4], d.Grid[5])
-	fmt.Fprintf(&b, "%v|%v|%v\n", d.Grid[6], d.Grid[7], d.Grid[8])
+	for y := 0; y < int(d.Rows); y++ {
+		row := make([]string, d.Cols)
+		for x := range row {
+			row[x] = d.Grid[y*int(d.Cols)+x].String()
+		}
+		fmt.Fprintln(&b, strings.Join(row, "|"))
+	}
+	fmt.Fprintf(&b, "Round: %d/%d (%v), wins: %v\n", d.Round+1, d.Rounds, d.Mode, d.Wins)
+	fmt.Fprintf(&b, "Payout: %v\n", d.Payout.Scheme)
 	fmt.Fprintf(&b, "Next actor: %v\n", d.NextActor)
+	fmt.Fprintf(&b, "Deadline: %v\n", d.DeadlineTime().Format(time.RFC3339))
+	if d.Forfeit {
+		fmt.Fprintln(&b, "Forfeit claimed")
+	}
 	return b.String()
 }
 
 func (d *TicTacToeAppData) MarshalBinary() ([]byte, error) {
-	var b bytes.Buffer
-	err := d.Encode(&b)
-	return b.Bytes(), err
+	return marshal(d)
 }
 
 func (d *TicTacToeAppData) UnmarshalBinary(data []byte) error {
-	var err error
-	buffer := bytes.NewBuffer(data)
-	d.NextActor, err = readUInt8(buffer)
-	if err != nil {
-		return errors.WithMessage(err, "reading actor")
-	}
-	grid, err := readUInt8Array(buffer, len(d.Grid))
+	_d, err := unmarshal(data, decodeTicTacToeAppData)
 	if err != nil {
-		return errors.WithMessage(err, "reading grid")
+		return err
 	}
-	copy(d.Grid[:], makeFieldValueArray(grid))
+	*d = *_d
 	return nil
 }
 
-// Encode encodes app data onto an io.Writer.
+// Encode encodes app data onto an io.Writer as laid out by TicTacToeSchema.
 func (d *TicTacToeAppData) Encode(w io.Writer) error {
+	if err := TicTacToeSchema.WriteVersion(w); err != nil {
+		return errors.WithMessage(err, "writing version")
+	}
+
 	err := writeUInt8(w, d.NextActor)
 	if err != nil {
 		return errors.WithMessage(err, "writing actor")
 	}
 
-	err = writeUInt8Array(w, makeUInt8Array(d.Grid[:]))
+	err = writeUInt64(w, d.Deadline)
+	if err != nil {
+		return errors.WithMessage(err, "writing deadline")
+	}
+
+	err = writeBool(w, d.Forfeit)
+	if err != nil {
+		return errors.WithMessage(err, "writing forfeit")
+	}
+
+	err = writeUInt8Array(w, []uint8{d.Rows, d.Cols, d.WinLength})
+	if err != nil {
+		return errors.WithMessage(err, "writing board config")
+	}
+
+	err = writeUInt8Array(w, []uint8{uint8(d.Mode), d.Rounds, d.Round, d.FirstActor, d.Wins[0], d.Wins[1]})
+	if err != nil {
+		return errors.WithMessage(err, "writing match state")
+	}
+
+	err = writeUInt16(w, d.LastMove)
+	if err != nil {
+		return errors.WithMessage(err, "writing last move")
+	}
+
+	err = writeUInt8(w, uint8safe(uint16(len(d.RoundStake))))
+	if err != nil {
+		return errors.WithMessage(err, "writing number of round stakes")
+	}
+	for i, stake := range d.RoundStake {
+		if err := writeUInt256(w, stake); err != nil {
+			return errors.WithMessagef(err, "writing round stake %d", i)
+		}
+	}
+
+	err = d.Payout.encode(w)
+	if err != nil {
+		return errors.WithMessage(err, "writing payout")
+	}
+
+	err = writeUInt8Array(w, makeUInt8Array(d.Grid))
 	return errors.WithMessage(err, "writing grid")
 }
 
+// decodeTicTacToeAppData decodes app data from an io.Reader. It rejects data
+// of another version than TicTacToeSchema.
+func decodeTicTacToeAppData(r io.Reader) (*TicTacToeAppData, error) {
+	d := TicTacToeAppData{}
+
+	if err := TicTacToeSchema.ReadVersion(r); err != nil {
+		return nil, errors.WithMessage(err, "reading version")
+	}
+
+	var err error
+	d.NextActor, err = readUInt8(r)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading actor")
+	}
+
+	d.Deadline, err = readUInt64(r)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading deadline")
+	}
+
+	d.Forfeit, err = readBool(r)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading forfeit")
+	}
+
+	cfg, err := readUInt8Array(r, 3)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading board config")
+	}
+	d.BoardConfig = BoardConfig{Rows: cfg[0], Cols: cfg[1], WinLength: cfg[2]}
+
+	match, err := readUInt8Array(r, 6)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading match state")
+	}
+	d.MatchConfig = MatchConfig{Mode: MatchMode(match[0]), Rounds: match[1]}
+	d.Round, d.FirstActor, d.Wins = match[2], match[3], [numParts]uint8{match[4], match[5]}
+
+	d.LastMove, err = readUInt16(r)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading last move")
+	}
+
+	numStakes, err := readUInt8(r)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading number of round stakes")
+	}
+	d.RoundStake = make([]channel.Bal, numStakes)
+	for i := range d.RoundStake {
+		if d.RoundStake[i], err = readUInt256(r); err != nil {
+			return nil, errors.WithMessagef(err, "reading round stake %d", i)
+		}
+	}
+
+	d.Payout, err = decodePayout(r)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading payout")
+	}
+
+	grid, err := readUInt8Array(r, d.NumFields())
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading grid")
+	}
+	d.Grid = makeFieldValueArray(grid)
+	return &d, nil
+}
+
 // Clone returns a deep copy of the app data.
 func (d *TicTacToeAppData) Clone() channel.Data {
 	_d := *d
+	_d.Grid = append([]FieldValue(nil), d.Grid...)
+	_d.RoundStake = cloneBals(d.RoundStake)
+	_d.Payout = d.Payout.Clone()
 	return &_d
 }
 
+// Equal returns whether the app data equals the given app data.
+func (d *TicTacToeAppData) Equal(o *TicTacToeAppData) bool {
+	if d.NextActor != o.NextActor || d.Deadline != o.Deadline || d.Forfeit != o.Forfeit || d.BoardConfig != o.BoardConfig ||
+		d.MatchConfig != o.MatchConfig || d.Round != o.Round || d.FirstActor != o.FirstActor ||
+		d.Wins != o.Wins || d.LastMove != o.LastMove ||
+		!equalBals(d.RoundStake, o.RoundStake) || !d.Payout.Equal(o.Payout) || len(d.Grid) != len(o.Grid) {
+		return false
+	}
+	for i := range d.Grid {
+		if d.Grid[i] != o.Grid[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (d *TicTacToeAppData) Set(x, y int, actorIdx channel.Index) {
 	if d.NextActor != uint8safe(uint16(actorIdx)) {
 		panic("invalid actor")
 	}
+	if !d.onBoard(x, y) {
+		panic("invalid field")
+	}
 	v := makeFieldValueFromPlayerIdx(actorIdx)
-	d.Grid[y*3+x] = v
+	d.Grid[y*int(d.Cols)+x] = v
 	d.NextActor = calcNextActor(d.NextActor)
 }
 
+// CheckMove checks whether the actor may mark the field (x, y), i.e., whether
+// it is the actor's turn and the field is on the board and not set.
+func (d *TicTacToeAppData) CheckMove(x, y int, actorIdx channel.Index) error {
+	if channel.Index(d.NextActor) != actorIdx {
+		return fmt.Errorf("not the turn of participant %d", actorIdx)
+	}
+	if !d.onBoard(x, y) {
+		return fmt.Errorf("field (%d, %d) not on the %dx%d board", x, y, d.Rows, d.Cols)
+	}
+	if d.Grid[y*int(d.Cols)+x] != notSet {
+		return fmt.Errorf("field (%d, %d) already set", x, y)
+	}
+	return nil
+}
+
+// DeadlineTime returns the deadline of the next move.
+func (d *TicTacToeAppData) DeadlineTime() time.Time {
+	return time.Unix(int64(d.Deadline), 0)
+}
+
+// setDeadline sets the deadline of the next move.
+func (d *TicTacToeAppData) setDeadline(t time.Time) {
+	d.Deadline = uint64(t.Unix())
+}
+
 func calcNextActor(actor uint8) uint8 {
 	return (actor + 1) % numParts
 }
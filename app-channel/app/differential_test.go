@@ -0,0 +1,289 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+	"perun.network/perun-examples/app-channel/contracts/generated/rockPaperScissorsApp"
+	"perun.network/perun-examples/app-channel/contracts/generated/ticTacToeApp"
+	"perun.network/perun-examples/app-channel/differential"
+)
+
+// TestTicTacToeDifferential compares TicTacToeApp with the deployed
+// TicTacToeApp contract on random and adversarial transitions.
+func TestTicTacToeDifferential(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	contract, err := differential.DeployContract(ticTacToeApp.TicTacToeAppMetaData)
+	require.NoError(t, err)
+
+	a := NewTicTacToeApp(ethwallet.AsWalletAddr(common.Address{1}))
+	h := differential.Harness{App: a, Contract: contract}
+
+	// The bindings are generated from the contract by contracts/generate.sh.
+	// Outdated bindings reject every move, so fail early with a hint.
+	params, from := newTicTacToeState(rng, a, DefaultBoardConfig, SingleGame, WinnerTakesAllPayout)
+	to := from.Clone()
+	to.Version++
+	playRandom(rng, to)
+	actor := channel.Index(from.Data.(*TicTacToeAppData).NextActor) //nolint:forcetypeassert
+	require.NoError(t, contract.ValidTransition(params, from, to, actor),
+		"contract rejects a valid opening move, regenerate the bindings with contracts/generate.sh")
+
+	for _, d := range h.Run(rng, 2000, ticTacToeTransitions(a)) {
+		t.Error(d)
+	}
+}
+
+// ticTacToeTransitions generates transitions from random reachable states,
+// some of which have a claimed forfeit. Half of them are valid moves, the
+// others are forfeit claims or moves with an adversarial modification.
+func ticTacToeTransitions(a *TicTacToeApp) differential.Generator {
+	return func(rng *rand.Rand) *differential.Transition {
+		board := BoardConfig{Rows: uint8(1 + rng.Intn(5)), Cols: uint8(1 + rng.Intn(5))}
+		board.WinLength = uint8(1 + rng.Intn(int(max(board.Rows, board.Cols))))
+		match := MatchConfig{Mode: MatchMode(rng.Intn(2)), Rounds: uint8(1 + rng.Intn(3))}
+
+		params, from := newTicTacToeState(rng, a, board, match, randomPayout(rng))
+		for n := rng.Intn(board.NumFields() * int(match.Rounds)); n > 0; n-- {
+			if !playRandom(rng, from) {
+				break
+			}
+		}
+		fromData := from.Data.(*TicTacToeAppData) //nolint:forcetypeassert
+		actor := channel.Index(fromData.NextActor)
+		if !from.IsFinal && rng.Intn(4) == 0 {
+			_ = a.Forfeit(from, 1-actor)
+		}
+
+		to := from.Clone()
+		to.Version++
+		if rng.Intn(2) == 0 {
+			playRandom(rng, to)
+			return &differential.Transition{Params: params, From: from, To: to, Actor: actor}
+		}
+
+		switch rng.Intn(9) {
+		case 0: // Forfeit by the waiting player, which is invalid if claimed.
+			actor = 1 - actor
+			_ = a.Forfeit(to, actor)
+		case 1: // Move by the waiting player.
+			playRandom(rng, to)
+			actor = 1 - actor
+		case 2: // Wrong final flag.
+			playRandom(rng, to)
+			to.IsFinal = !to.IsFinal
+		case 3: // Wrong balances.
+			playRandom(rng, to)
+			bals := to.Balances[0]
+			if bals[actor].Sign() > 0 {
+				bals[actor].Sub(bals[actor], big.NewInt(1))
+				bals[1-actor].Add(bals[1-actor], big.NewInt(1))
+			}
+		case 4: // Decreased deadline.
+			playRandom(rng, to)
+			to.Data.(*TicTacToeAppData).Deadline -= uint64(1 + rng.Intn(60)) //nolint:forcetypeassert
+		case 5: // Corrupted data.
+			playRandom(rng, to)
+			enc, err := to.Data.MarshalBinary()
+			if err != nil {
+				return nil
+			}
+			enc[rng.Intn(len(enc))] = byte(rng.Intn(4))
+			if to.Data, err = a.DecodeData(bytes.NewReader(enc)); err != nil {
+				return nil
+			}
+		case 6: // Move or forfeit by the house.
+			if len(params.Parts) <= numParts {
+				return nil
+			}
+			if rng.Intn(2) == 0 {
+				playRandom(rng, to)
+			} else {
+				_ = a.Forfeit(to, 1-actor)
+			}
+			actor = HouseIdx
+		case 7: // Final forfeit.
+			actor = 1 - actor
+			_ = a.Forfeit(to, actor)
+			to.IsFinal = true
+		case 8: // Answer to a claimed forfeit that keeps the claim.
+			if !fromData.Forfeit {
+				return nil
+			}
+			playRandom(rng, to)
+			if rng.Intn(2) == 0 {
+				to.Data.(*TicTacToeAppData).Forfeit = true //nolint:forcetypeassert
+			} else {
+				to.Balances = from.Balances.Clone()
+			}
+		}
+		return &differential.Transition{Params: params, From: from, To: to, Actor: actor}
+	}
+}
+
+// roundStake is the amount per round each player puts at stake in generated
+// matches.
+const roundStake = 100
+
+// newTicTacToeState returns the parameters and the initial state of a match.
+func newTicTacToeState(rng *rand.Rand, a *TicTacToeApp, board BoardConfig, match MatchConfig, payout Payout) (*channel.Params, *channel.State) {
+	params := differential.NewParams(rng, a, uint64(a.MoveTimeout.Seconds()), payout.NumParts())
+	stake := big.NewInt(roundStake * int64(match.Rounds))
+	firstActor := channel.Index(rng.Intn(numParts))
+	data := a.InitData(firstActor, board, match, match.RoundStakes(stake), payout)
+	bals := []channel.Bal{stake, new(big.Int).Set(stake)}
+	if payout.NumParts() > numParts {
+		bals = append(bals, big.NewInt(rng.Int63n(roundStake)))
+	}
+	return params, differential.NewState(rng, params, data, bals)
+}
+
+// randomPayout returns a random valid payout.
+func randomPayout(rng *rand.Rand) Payout {
+	p := Payout{Scheme: PayoutScheme(rng.Intn(int(maxPayoutScheme) + 1))}
+	switch p.Scheme {
+	case FixedPrize:
+		p.Prize = []channel.Bal{big.NewInt(rng.Int63n(roundStake + 1))}
+	case PartialStake, HouseRake:
+		p.Share = uint16(1 + rng.Intn(MaxShare))
+	}
+	return p
+}
+
+// playRandom lets the next actor mark a random free field, which answers a
+// claimed forfeit, and advances the deadline. It returns false if the match is
+// already over.
+func playRandom(rng *rand.Rand, s *channel.State) bool {
+	d := s.Data.(*TicTacToeAppData) //nolint:forcetypeassert
+	if s.IsFinal {
+		return false
+	}
+	if d.Forfeit {
+		bals, err := d.revertForfeitBalances(s.Balances, channel.Index(calcNextActor(d.NextActor)))
+		if err != nil {
+			return false
+		}
+		s.Balances, d.Forfeit = bals, false
+	}
+	var free []int
+	for i, v := range d.Grid {
+		if v == notSet {
+			free = append(free, i)
+		}
+	}
+	_, winner, matchOver := d.play(free[rng.Intn(len(free))], channel.Index(d.NextActor))
+	d.Deadline += uint64(rng.Intn(60))
+	if winner != nil {
+		s.Balances = d.computeRoundBalances(s.Balances, *winner)
+	}
+	s.IsFinal = matchOver
+	return true
+}
+
+// TestRockPaperScissorsDifferential compares RockPaperScissorsApp with the
+// deployed RockPaperScissorsApp contract on random and adversarial
+// transitions.
+func TestRockPaperScissorsDifferential(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	contract, err := differential.DeployContract(rockPaperScissorsApp.RockPaperScissorsAppMetaData)
+	require.NoError(t, err)
+
+	a := NewRockPaperScissorsApp(ethwallet.AsWalletAddr(common.Address{2}))
+	h := differential.Harness{App: a, Contract: contract}
+	for _, d := range h.Run(rng, 1000, rockPaperScissorsTransitions(a)) {
+		t.Error(d)
+	}
+}
+
+// rockPaperScissorsTransitions generates transitions from random reachable
+// states. Half of them are valid moves, the others are moves with an
+// adversarial modification.
+func rockPaperScissorsTransitions(a *RockPaperScissorsApp) differential.Generator {
+	return func(rng *rand.Rand) *differential.Transition {
+		params := differential.NewParams(rng, a, 10, numParts)
+		stake := big.NewInt(1 + rng.Int63n(roundStake))
+		from := differential.NewState(rng, params, a.InitData(), []channel.Bal{stake, new(big.Int).Set(stake)})
+
+		var choices [numParts]Choice
+		var salts [numParts]Salt
+		for i := range choices {
+			choices[i] = Choice(1 + rng.Intn(int(maxChoice)))
+			rng.Read(salts[i][:])
+		}
+		// A game has two commits and two reveals.
+		for n := rng.Intn(4); n > 0; n-- {
+			playRockPaperScissors(from, choices, salts)
+		}
+
+		to := from.Clone()
+		to.Version++
+		actor := channel.Index(from.Data.(*RockPaperScissorsAppData).NextActor) //nolint:forcetypeassert
+		playRockPaperScissors(to, choices, salts)
+		if rng.Intn(2) == 0 {
+			return &differential.Transition{Params: params, From: from, To: to, Actor: actor}
+		}
+
+		d := to.Data.(*RockPaperScissorsAppData) //nolint:forcetypeassert
+		switch rng.Intn(5) {
+		case 0: // Move by the waiting player.
+			actor = 1 - actor
+		case 1: // Wrong final flag.
+			to.IsFinal = !to.IsFinal
+		case 2: // Wrong balances.
+			bals := to.Balances[0]
+			if bals[0].Sign() > 0 {
+				bals[0].Sub(bals[0], big.NewInt(1))
+				bals[1].Add(bals[1], big.NewInt(1))
+			}
+		case 3: // Corrupted data.
+			enc, err := d.MarshalBinary()
+			if err != nil {
+				return nil
+			}
+			enc[rng.Intn(len(enc))] = byte(rng.Intn(4))
+			if to.Data, err = a.DecodeData(bytes.NewReader(enc)); err != nil {
+				return nil
+			}
+		case 4: // Reveal of another choice or with another salt.
+			if rng.Intn(2) == 0 {
+				d.Choices[actor] = Choice(rng.Intn(int(maxChoice) + 2))
+			} else {
+				d.Salts[actor][rng.Intn(len(Salt{}))]++
+			}
+		}
+		return &differential.Transition{Params: params, From: from, To: to, Actor: actor}
+	}
+}
+
+// playRockPaperScissors lets the next actor commit to or reveal its choice.
+func playRockPaperScissors(s *channel.State, choices [numParts]Choice, salts [numParts]Salt) {
+	d := s.Data.(*RockPaperScissorsAppData) //nolint:forcetypeassert
+	idx := channel.Index(d.NextActor)
+	if d.Phase == PhaseCommit {
+		d.Commit(computeCommitment(choices[idx], salts[idx]), idx)
+	} else {
+		d.Reveal(choices[idx], salts[idx], idx)
+	}
+	s.Balances = d.computeBalances(s.Balances)
+	s.IsFinal = d.Phase == PhaseDone
+}
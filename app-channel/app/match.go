@@ -0,0 +1,182 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"perun.network/go-perun/channel"
+)
+
+// MatchMode determines when a match ends.
+type MatchMode uint8
+
+const (
+	// FixedRounds ends the match after the configured number of rounds.
+	FixedRounds MatchMode = iota
+	// BestOf ends the match as soon as a player has won the majority of the
+	// configured number of rounds, or after all rounds have been played.
+	BestOf
+	maxMatchMode = BestOf
+)
+
+func (m MatchMode) String() string {
+	switch m {
+	case FixedRounds:
+		return "fixed rounds"
+	case BestOf:
+		return "best of"
+	default:
+		return fmt.Sprintf("unknown mode %d", uint8(m))
+	}
+}
+
+// MatchConfig describes a match of several rounds played in one channel.
+type MatchConfig struct {
+	Mode   MatchMode
+	Rounds uint8 // The maximum number of rounds.
+}
+
+// SingleGame is a match consisting of a single round.
+var SingleGame = MatchConfig{Mode: FixedRounds, Rounds: 1}
+
+// NewMatchConfig returns the match of the given mode and number of rounds.
+// It fails if the number of rounds does not fit into the app data or if the
+// match is not valid.
+func NewMatchConfig(mode MatchMode, rounds uint) (MatchConfig, error) {
+	if rounds > math.MaxUint8 {
+		return MatchConfig{}, fmt.Errorf("number of rounds out of range: %d > %d", rounds, math.MaxUint8)
+	}
+	m := MatchConfig{Mode: mode, Rounds: uint8(rounds)}
+	return m, m.Validate()
+}
+
+// Validate checks that the match has at least one round.
+func (m MatchConfig) Validate() error {
+	if m.Mode > maxMatchMode {
+		return fmt.Errorf("invalid match mode: %d", m.Mode)
+	}
+	if m.Rounds == 0 {
+		return fmt.Errorf("match without rounds")
+	}
+	return nil
+}
+
+// RoundStakes returns the amount per asset the loser of a round pays to the
+// winner if each player puts stake at stake for the whole match.
+func (m MatchConfig) RoundStakes(stake ...channel.Bal) []channel.Bal {
+	stakes := make([]channel.Bal, len(stake))
+	for i, s := range stake {
+		stakes[i] = new(big.Int).Div(s, big.NewInt(int64(m.Rounds)))
+	}
+	return stakes
+}
+
+// play sets the mark of the next actor on field i. If this ends the round,
+// the result is recorded and the board is reset for the next round, which is
+// opened by the other player. It returns whether the round ended, its winner
+// and whether the match is over.
+func (d *TicTacToeAppData) play(i int, actorIdx channel.Index) (roundOver bool, winner *channel.Index, matchOver bool) {
+	d.Set(i%int(d.Cols), i/int(d.Cols), actorIdx)
+	d.LastMove = uint16(i + 1)
+
+	roundOver, winner = d.checkFinalAt(i)
+	if !roundOver {
+		return false, nil, false
+	}
+
+	if winner != nil {
+		d.Wins[*winner]++
+	}
+	if d.matchOver() {
+		return true, winner, true
+	}
+
+	d.Round++
+	d.FirstActor = calcNextActor(d.FirstActor)
+	d.NextActor = d.FirstActor
+	d.Grid = make([]FieldValue, d.NumFields())
+	return true, winner, false
+}
+
+// matchOver returns whether the match is over after the current round.
+func (d *TicTacToeAppData) matchOver() bool {
+	if d.Round+1 >= d.Rounds {
+		return true
+	}
+	if d.Mode == BestOf {
+		for _, w := range d.Wins {
+			if w > d.Rounds/2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// forfeitedRounds returns the number of rounds the waiting player claimer wins
+// by forfeit: the current round and every further round the match would last
+// if claimer won them all.
+func (d *TicTacToeAppData) forfeitedRounds(claimer channel.Index) (n int) {
+	sim := *d
+	for {
+		sim.Wins[claimer]++
+		n++
+		if sim.matchOver() {
+			return n
+		}
+		sim.Round++
+	}
+}
+
+// computeForfeitBalances pays out each forfeited round from the other player
+// to claimer according to the payout scheme. The rounds played so far are
+// already paid out.
+func (d *TicTacToeAppData) computeForfeitBalances(bals channel.Balances, claimer channel.Index) (channel.Balances, error) {
+	return d.transferForfeitedRounds(bals, claimer, 1)
+}
+
+// revertForfeitBalances reverts computeForfeitBalances when the next actor
+// answers the forfeit claimed by claimer.
+func (d *TicTacToeAppData) revertForfeitBalances(bals channel.Balances, claimer channel.Index) (channel.Balances, error) {
+	return d.transferForfeitedRounds(bals, claimer, -1)
+}
+
+// transferForfeitedRounds pays out the forfeited rounds sign times.
+func (d *TicTacToeAppData) transferForfeitedRounds(bals channel.Balances, claimer channel.Index, sign int64) (channel.Balances, error) {
+	if len(d.RoundStake) != len(bals) {
+		return nil, fmt.Errorf("invalid number of round stakes: expected %d, got %d", len(bals), len(d.RoundStake))
+	}
+	n := big.NewInt(sign * int64(d.forfeitedRounds(claimer)))
+	loser := 1 - claimer
+	finalBals := bals.Clone()
+	for i := range finalBals {
+		paid, raked := d.roundPayment(i)
+		paid, raked = new(big.Int).Mul(paid, n), new(big.Int).Mul(raked, n)
+		finalBals[i][loser] = new(big.Int).Sub(bals[i][loser], paid)
+		finalBals[i][claimer] = new(big.Int).Add(bals[i][claimer], new(big.Int).Sub(paid, raked))
+		if raked.Sign() != 0 {
+			finalBals[i][HouseIdx] = new(big.Int).Add(bals[i][HouseIdx], raked)
+		}
+		for j, bal := range finalBals[i] {
+			if bal.Sign() < 0 {
+				return nil, fmt.Errorf("insufficient balance of participant %d for asset %d: %v", j, i, bals[i][j])
+			}
+		}
+	}
+	return finalBals, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+)
+
+func TestForfeit(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		match  MatchConfig
+		round  uint8
+		wins   [numParts]uint8 // The wins of the next actor and of the claimer.
+		rounds int             // The number of forfeited rounds.
+	}{
+		{"single game", SingleGame, 0, [numParts]uint8{0, 0}, 1},
+		{"fixed rounds", MatchConfig{Mode: FixedRounds, Rounds: 3}, 0, [numParts]uint8{0, 0}, 3},
+		{"fixed rounds, last round", MatchConfig{Mode: FixedRounds, Rounds: 3}, 2, [numParts]uint8{1, 1}, 1},
+		{"best of", MatchConfig{Mode: BestOf, Rounds: 3}, 0, [numParts]uint8{0, 0}, 2},
+		{"best of, claimer leads", MatchConfig{Mode: BestOf, Rounds: 3}, 1, [numParts]uint8{0, 1}, 1},
+		{"best of, claimer trails", MatchConfig{Mode: BestOf, Rounds: 3}, 1, [numParts]uint8{1, 0}, 2},
+		{"best of, draws", MatchConfig{Mode: BestOf, Rounds: 5}, 3, [numParts]uint8{1, 0}, 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			a := NewTicTacToeApp(ethwallet.AsWalletAddr(common.Address{1}))
+			params, from := newTicTacToeState(rng, a, DefaultBoardConfig, tt.match, WinnerTakesAllPayout)
+			d := from.Data.(*TicTacToeAppData) //nolint:forcetypeassert
+			actor := channel.Index(d.NextActor)
+			claimer := 1 - actor
+			d.Round = tt.round
+			d.Wins[actor], d.Wins[claimer] = tt.wins[0], tt.wins[1]
+
+			to := from.Clone()
+			to.Version++
+			require.NoError(t, a.Forfeit(to, claimer))
+			require.False(t, to.IsFinal)
+			require.True(t, to.Data.(*TicTacToeAppData).Forfeit) //nolint:forcetypeassert
+			require.NoError(t, a.ValidTransition(params, from, to, claimer))
+
+			paid := new(big.Int).Mul(d.RoundStake[0], big.NewInt(int64(tt.rounds)))
+			require.Zero(t, to.Balances[0][actor].Cmp(new(big.Int).Sub(from.Balances[0][actor], paid)))
+			require.Zero(t, to.Balances[0][claimer].Cmp(new(big.Int).Add(from.Balances[0][claimer], paid)))
+
+			// The claimer must not take the whole pot.
+			if tt.rounds < int(tt.match.Rounds) {
+				to.Balances = computeFinalBalances(from.Balances, claimer)
+				require.ErrorContains(t, a.ValidTransition(params, from, to, claimer), "wrong allocation")
+			}
+		})
+	}
+}
+
+func TestForfeitInvalid(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	a := NewTicTacToeApp(ethwallet.AsWalletAddr(common.Address{1}))
+	params, from := newTicTacToeState(rng, a, DefaultBoardConfig, MatchConfig{Mode: FixedRounds, Rounds: 2}, WinnerTakesAllPayout)
+	actor := channel.Index(from.Data.(*TicTacToeAppData).NextActor) //nolint:forcetypeassert
+
+	require.ErrorContains(t, a.Forfeit(from.Clone(), actor), "own turn")
+	require.ErrorContains(t, a.Forfeit(from.Clone(), HouseIdx), "house")
+
+	// The next actor cannot pay the stakes of both rounds.
+	from.Balances[0][actor].Sub(from.Balances[0][actor], big.NewInt(1))
+	require.ErrorContains(t, a.Forfeit(from.Clone(), 1-actor), "insufficient balance")
+	to := from.Clone()
+	to.Data.(*TicTacToeAppData).Forfeit = true //nolint:forcetypeassert
+	require.ErrorContains(t, a.ValidTransition(params, from, to, 1-actor), "insufficient balance")
+
+	// A claim must not be final.
+	from.Balances[0][actor].Add(from.Balances[0][actor], big.NewInt(1))
+	to = from.Clone()
+	require.NoError(t, a.Forfeit(to, 1-actor))
+	to.IsFinal = true
+	require.ErrorContains(t, a.ValidTransition(params, from, to, 1-actor), "must not be final")
+}
+
+func TestForfeitAnswer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	a := NewTicTacToeApp(ethwallet.AsWalletAddr(common.Address{1}))
+	params, start := newTicTacToeState(rng, a, DefaultBoardConfig, MatchConfig{Mode: BestOf, Rounds: 3}, Payout{Scheme: HouseRake, Share: 1000})
+	actor := channel.Index(start.Data.(*TicTacToeAppData).NextActor) //nolint:forcetypeassert
+	claimer := 1 - actor
+
+	claimed := start.Clone()
+	require.NoError(t, a.Forfeit(claimed, claimer))
+	require.ErrorContains(t, a.Forfeit(claimed.Clone(), claimer), "already claimed")
+	again := claimed.Clone()
+	again.Balances, _ = claimed.Data.(*TicTacToeAppData).computeForfeitBalances(claimed.Balances, claimer) //nolint:forcetypeassert
+	require.ErrorContains(t, a.ValidTransition(params, claimed, again, claimer), "already claimed")
+
+	// A move answers the claim and reverts its payout.
+	answered := claimed.Clone()
+	require.NoError(t, a.Set(answered, 0, 0, actor))
+	require.False(t, answered.Data.(*TicTacToeAppData).Forfeit) //nolint:forcetypeassert
+	require.NoError(t, answered.Balances.AssertEqual(start.Balances))
+	require.NoError(t, a.ValidTransition(params, claimed, answered, actor))
+
+	// The answer must revert the payout and clear the flag.
+	kept := answered.Clone()
+	kept.Balances = claimed.Balances.Clone()
+	require.ErrorContains(t, a.ValidTransition(params, claimed, kept, actor), "wrong allocation")
+	flagged := answered.Clone()
+	flagged.Data.(*TicTacToeAppData).Forfeit = true //nolint:forcetypeassert
+	require.ErrorContains(t, a.ValidTransition(params, claimed, flagged, actor), "invalid data")
+
+	// Afterwards, the claimer is the next actor again.
+	require.NoError(t, a.Set(answered.Clone(), 1, 1, claimer))
+}
+
+func TestForfeitPayout(t *testing.T) {
+	// A fresh best-of-three match with a round stake of 100 forfeits two
+	// rounds.
+	for _, tt := range []struct {
+		payout      Payout
+		paid, raked int64
+	}{
+		{WinnerTakesAllPayout, 200, 0},
+		{Payout{Scheme: FixedPrize, Prize: []channel.Bal{big.NewInt(30)}}, 60, 0},
+		{Payout{Scheme: PartialStake, Share: 2500}, 50, 0},
+		{Payout{Scheme: HouseRake, Share: 1000}, 200, 20},
+	} {
+		t.Run(tt.payout.Scheme.String(), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			a := NewTicTacToeApp(ethwallet.AsWalletAddr(common.Address{1}))
+			params, from := newTicTacToeState(rng, a, DefaultBoardConfig, MatchConfig{Mode: BestOf, Rounds: 3}, tt.payout)
+			actor := channel.Index(from.Data.(*TicTacToeAppData).NextActor) //nolint:forcetypeassert
+			claimer := 1 - actor
+
+			to := from.Clone()
+			to.Version++
+			require.NoError(t, a.Forfeit(to, claimer))
+			require.NoError(t, a.ValidTransition(params, from, to, claimer))
+
+			bals := from.Balances[0]
+			require.Zero(t, to.Balances[0][actor].Cmp(new(big.Int).Sub(bals[actor], big.NewInt(tt.paid))))
+			require.Zero(t, to.Balances[0][claimer].Cmp(new(big.Int).Add(bals[claimer], big.NewInt(tt.paid-tt.raked))))
+			if tt.payout.Scheme == HouseRake {
+				require.Zero(t, to.Balances[0][HouseIdx].Cmp(new(big.Int).Add(bals[HouseIdx], big.NewInt(tt.raked))))
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"perun.network/go-perun/channel"
+)
+
+// PayoutScheme determines what the loser of a round pays. A drawn round pays
+// nothing, so a drawn match refunds each player's stake.
+type PayoutScheme uint8
+
+const (
+	// WinnerTakesAll pays the round stake to the winner.
+	WinnerTakesAll PayoutScheme = iota
+	// FixedPrize pays a fixed prize to the winner.
+	FixedPrize
+	// PartialStake pays a share of the round stake to the winner.
+	PartialStake
+	// HouseRake pays the round stake, of which the house takes a share and
+	// the winner receives the rest. The house is the third channel
+	// participant and never acts.
+	HouseRake
+	maxPayoutScheme = HouseRake
+)
+
+func (s PayoutScheme) String() string {
+	switch s {
+	case WinnerTakesAll:
+		return "winner takes all"
+	case FixedPrize:
+		return "fixed prize"
+	case PartialStake:
+		return "partial stake"
+	case HouseRake:
+		return "house rake"
+	default:
+		return fmt.Sprintf("unknown scheme %d", uint8(s))
+	}
+}
+
+// MaxShare is a share of 100% in basis points.
+const MaxShare = 10000
+
+// HouseIdx is the participant index of the house if the payout scheme is
+// HouseRake.
+const HouseIdx channel.Index = numParts
+
+// Payout is the payout scheme of a match together with its parameters.
+type Payout struct {
+	Scheme PayoutScheme
+	Share  uint16        // The paid (PartialStake) or raked (HouseRake) share in basis points.
+	Prize  []channel.Bal // The prize per asset (FixedPrize).
+}
+
+// WinnerTakesAllPayout pays the round stake to the winner of a round.
+var WinnerTakesAllPayout = Payout{Scheme: WinnerTakesAll}
+
+// NumParts returns the number of channel participants, including the house.
+func (p Payout) NumParts() int {
+	if p.Scheme == HouseRake {
+		return numParts + 1
+	}
+	return numParts
+}
+
+// Validate checks the payout parameters against the round stakes.
+func (p Payout) Validate(roundStake []channel.Bal) error {
+	if p.Scheme > maxPayoutScheme {
+		return fmt.Errorf("invalid payout scheme: %d", p.Scheme)
+	}
+	if p.Share > MaxShare {
+		return fmt.Errorf("share exceeds %d basis points: %d", MaxShare, p.Share)
+	}
+	if (p.Scheme == PartialStake || p.Scheme == HouseRake) != (p.Share != 0) {
+		return fmt.Errorf("share %d not allowed for %v", p.Share, p.Scheme)
+	}
+
+	if p.Scheme != FixedPrize {
+		if len(p.Prize) != 0 {
+			return fmt.Errorf("prize not allowed for %v", p.Scheme)
+		}
+		return nil
+	}
+	if len(p.Prize) != len(roundStake) {
+		return fmt.Errorf("invalid number of prizes: expected %d, got %d", len(roundStake), len(p.Prize))
+	}
+	for i, prize := range p.Prize {
+		if prize.Sign() < 0 || prize.Cmp(roundStake[i]) > 0 {
+			return fmt.Errorf("prize for asset %d exceeds round stake: %v > %v", i, prize, roundStake[i])
+		}
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the payout.
+func (p Payout) Clone() Payout {
+	p.Prize = cloneBals(p.Prize)
+	return p
+}
+
+// Equal returns whether the payout equals the given payout.
+func (p Payout) Equal(o Payout) bool {
+	return p.Scheme == o.Scheme && p.Share == o.Share && equalBals(p.Prize, o.Prize)
+}
+
+// computeRoundBalances pays out the round stake of each asset from the loser
+// according to the payout scheme.
+func (d *TicTacToeAppData) computeRoundBalances(bals channel.Balances, winner channel.Index) channel.Balances {
+	loser := 1 - winner
+	finalBals := bals.Clone()
+	for i := range finalBals {
+		paid, raked := d.roundPayment(i)
+		finalBals[i][loser] = new(big.Int).Sub(bals[i][loser], paid)
+		finalBals[i][winner] = new(big.Int).Add(bals[i][winner], new(big.Int).Sub(paid, raked))
+		if raked.Sign() != 0 {
+			finalBals[i][HouseIdx] = new(big.Int).Add(bals[i][HouseIdx], raked)
+		}
+	}
+	return finalBals
+}
+
+// roundPayment returns the amount of asset i the loser of a round pays and
+// the part of it that goes to the house.
+func (d *TicTacToeAppData) roundPayment(i int) (paid, raked *big.Int) {
+	stake := d.RoundStake[i]
+	switch d.Payout.Scheme {
+	case FixedPrize:
+		return d.Payout.Prize[i], new(big.Int)
+	case PartialStake:
+		return shareOf(stake, d.Payout.Share), new(big.Int)
+	case HouseRake:
+		return stake, shareOf(stake, d.Payout.Share)
+	default:
+		return stake, new(big.Int)
+	}
+}
+
+// shareOf returns the share of v given in basis points, rounded down.
+func shareOf(v *big.Int, share uint16) *big.Int {
+	r := new(big.Int).Mul(v, big.NewInt(int64(share)))
+	return r.Div(r, big.NewInt(MaxShare))
+}
+
+func cloneBals(bals []channel.Bal) []channel.Bal {
+	if bals == nil {
+		return nil
+	}
+	c := make([]channel.Bal, len(bals))
+	for i, b := range bals {
+		c[i] = new(big.Int).Set(b)
+	}
+	return c
+}
+
+func equalBals(a, b []channel.Bal) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Cmp(b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// encode writes the scheme, the share and the prizes.
+func (p Payout) encode(w io.Writer) error {
+	if err := writeUInt8(w, uint8(p.Scheme)); err != nil {
+		return errors.WithMessage(err, "writing scheme")
+	}
+	if err := writeUInt16(w, p.Share); err != nil {
+		return errors.WithMessage(err, "writing share")
+	}
+	if err := writeUInt8(w, uint8safe(uint16(len(p.Prize)))); err != nil {
+		return errors.WithMessage(err, "writing number of prizes")
+	}
+	for i, prize := range p.Prize {
+		if err := writeUInt256(w, prize); err != nil {
+			return errors.WithMessagef(err, "writing prize %d", i)
+		}
+	}
+	return nil
+}
+
+// decodePayout reads a payout written by encode.
+func decodePayout(r io.Reader) (p Payout, err error) {
+	scheme, err := readUInt8(r)
+	if err != nil {
+		return p, errors.WithMessage(err, "reading scheme")
+	}
+	p.Scheme = PayoutScheme(scheme)
+	if p.Share, err = readUInt16(r); err != nil {
+		return p, errors.WithMessage(err, "reading share")
+	}
+	numPrizes, err := readUInt8(r)
+	if err != nil {
+		return p, errors.WithMessage(err, "reading number of prizes")
+	}
+	if numPrizes > 0 {
+		p.Prize = make([]channel.Bal, numPrizes)
+	}
+	for i := range p.Prize {
+		if p.Prize[i], err = readUInt256(r); err != nil {
+			return p, errors.WithMessagef(err, "reading prize %d", i)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+
+	ethchannel "github.com/perun-network/perun-eth-backend/channel"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"perun.network/go-perun/channel"
+)
+
+// DefaultRevealTimeout is the default time a player has for revealing.
+const DefaultRevealTimeout = 30 * time.Second
+
+// RockPaperScissorsApp is a channel app for playing rock-paper-scissors.
+//
+// A game runs through a commit phase, in which both players submit the hash
+// of their choice and a secret salt, and a reveal phase, in which both players
+// open their commitments. Refusing to reveal is punished through the
+// allocation: whenever a player has to reveal, the current state already
+// assigns the whole pot to the opponent. If the player stalls, the opponent
+// registers that state on-chain and the channel concludes with it after the
+// challenge duration.
+//
+// A player has RevealTimeout for a reveal. Neither the app nor the contract
+// can read the time, so the clients enforce the timeout by settling the
+// channel once it passes.
+type RockPaperScissorsApp struct {
+	ID            channel.AppID
+	RevealTimeout time.Duration // The time a player has for revealing.
+}
+
+func NewRockPaperScissorsApp(addr *ethwallet.Address) *RockPaperScissorsApp {
+	return &RockPaperScissorsApp{
+		ID:            &ethchannel.AppID{Address: addr},
+		RevealTimeout: DefaultRevealTimeout,
+	}
+}
+
+// Def returns the app identifier as definition.
+func (a *RockPaperScissorsApp) Def() channel.AppID {
+	return a.ID
+}
+
+func (a *RockPaperScissorsApp) NewData() channel.Data {
+	return &RockPaperScissorsAppData{}
+}
+
+func (a *RockPaperScissorsApp) InitData() *RockPaperScissorsAppData {
+	return &RockPaperScissorsAppData{}
+}
+
+// DecodeData decodes the channel data.
+func (a *RockPaperScissorsApp) DecodeData(r io.Reader) (channel.Data, error) {
+	return decodeRockPaperScissorsAppData(r)
+}
+
+// ValidInit checks that the initial state is valid.
+func (a *RockPaperScissorsApp) ValidInit(p *channel.Params, s *channel.State) error {
+	if len(p.Parts) != numParts {
+		return fmt.Errorf("invalid number of participants: expected %d, got %d", numParts, len(p.Parts))
+	}
+
+	appData, ok := s.Data.(*RockPaperScissorsAppData)
+	if !ok {
+		return fmt.Errorf("invalid data type: %T", s.Data)
+	}
+
+	if *appData != (RockPaperScissorsAppData{}) {
+		return fmt.Errorf("invalid initial data: %v", appData)
+	}
+
+	if s.IsFinal {
+		return fmt.Errorf("must not be final")
+	}
+
+	// The stakes must be equal so that a draw can be refunded.
+	for i, bals := range s.Balances {
+		if bals[0].Cmp(bals[1]) != 0 {
+			return fmt.Errorf("unequal stakes for asset %d: %v != %v", i, bals[0], bals[1])
+		}
+	}
+	return nil
+}
+
+// ValidTransition is called whenever the channel state transitions.
+func (a *RockPaperScissorsApp) ValidTransition(params *channel.Params, from, to *channel.State, idx channel.Index) error {
+	err := channel.AssertAssetsEqual(from.Assets, to.Assets)
+	if err != nil {
+		return fmt.Errorf("invalid assets: %v", err)
+	}
+
+	fromData, ok := from.Data.(*RockPaperScissorsAppData)
+	if !ok {
+		panic(fmt.Sprintf("from state: invalid data type: %T", from.Data))
+	}
+
+	toData, ok := to.Data.(*RockPaperScissorsAppData)
+	if !ok {
+		panic(fmt.Sprintf("to state: invalid data type: %T", to.Data))
+	}
+
+	// Check actor.
+	if len(params.Parts) != numParts {
+		return fmt.Errorf("invalid number of participants: %d", len(params.Parts))
+	}
+	if fromData.NextActor != uint8safe(uint16(idx)) {
+		return fmt.Errorf("invalid actor: expected %v, got %v", fromData.NextActor, idx)
+	}
+
+	// Compute the expected data by applying the actor's move.
+	expectedData := *fromData
+	switch fromData.Phase {
+	case PhaseCommit:
+		if toData.Commitments[idx] == (Commitment{}) {
+			return fmt.Errorf("empty commitment")
+		}
+		expectedData.Commit(toData.Commitments[idx], idx)
+	case PhaseReveal:
+		c, salt := toData.Choices[idx], toData.Salts[idx]
+		if c == noChoice || c > maxChoice {
+			return fmt.Errorf("invalid choice: %d", c)
+		}
+		if computeCommitment(c, salt) != fromData.Commitments[idx] {
+			return fmt.Errorf("choice does not match commitment")
+		}
+		expectedData.Reveal(c, salt, idx)
+	default:
+		return fmt.Errorf("invalid phase: %v", fromData.Phase)
+	}
+	if *toData != expectedData {
+		return fmt.Errorf("invalid data: expected %v, got %v", &expectedData, toData)
+	}
+
+	// Check final and allocation.
+	isFinal := toData.Phase == PhaseDone
+	if to.IsFinal != isFinal {
+		return fmt.Errorf("final flag: expected %v, got %v", isFinal, to.IsFinal)
+	}
+	expectedAllocation := from.Allocation.Clone()
+	expectedAllocation.Balances = toData.computeBalances(from.Allocation.Balances)
+	if err := expectedAllocation.Equal(&to.Allocation); err != nil {
+		return errors.WithMessagef(err, "wrong allocation: expected %v, got %v", expectedAllocation, to.Allocation)
+	}
+	return nil
+}
+
+// Commit commits the actor to a choice.
+func (a *RockPaperScissorsApp) Commit(s *channel.State, cm Commitment, actorIdx channel.Index) error {
+	d, ok := s.Data.(*RockPaperScissorsAppData)
+	if !ok {
+		return fmt.Errorf("invalid data type: %T", d)
+	}
+
+	d.Commit(cm, actorIdx)
+	s.Balances = d.computeBalances(s.Balances)
+	return nil
+}
+
+// Reveal opens the actor's commitment.
+func (a *RockPaperScissorsApp) Reveal(s *channel.State, c Choice, salt Salt, actorIdx channel.Index) error {
+	d, ok := s.Data.(*RockPaperScissorsAppData)
+	if !ok {
+		return fmt.Errorf("invalid data type: %T", d)
+	}
+
+	d.Reveal(c, salt, actorIdx)
+	log.Println("\n" + d.String())
+
+	s.Balances = d.computeBalances(s.Balances)
+	s.IsFinal = d.Phase == PhaseDone
+	return nil
+}
+
+// computeBalances computes the balances that apply if the channel is
+// concluded in a state with data d. As long as a player owes a reveal, the
+// whole pot is assigned to the opponent.
+func (d *RockPaperScissorsAppData) computeBalances(bals channel.Balances) channel.Balances {
+	switch d.Phase {
+	case PhaseCommit:
+		return bals.Clone()
+	case PhaseReveal:
+		return computeFinalBalances(bals, channel.Index(calcNextActor(d.NextActor)))
+	default:
+		if winner := d.Winner(); winner != nil {
+			return computeFinalBalances(bals, *winner)
+		}
+		return computeDrawBalances(bals)
+	}
+}
+
+// computeDrawBalances splits the pot of each asset equally.
+func computeDrawBalances(bals channel.Balances) channel.Balances {
+	finalBals := bals.Clone()
+	for i := range finalBals {
+		pot := new(big.Int).Add(bals[i][0], bals[i][1])
+		half := new(big.Int).Rsh(pot, 1)
+		finalBals[i][0] = half
+		finalBals[i][1] = new(big.Int).Sub(pot, half)
+	}
+	return finalBals
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"perun.network/go-perun/channel"
+)
+
+// Choice is a rock-paper-scissors move.
+type Choice uint8
+
+const (
+	noChoice Choice = iota
+	Rock
+	Paper
+	Scissors
+	maxChoice = Scissors
+)
+
+func (c Choice) String() string {
+	switch c {
+	case noChoice:
+		return "-"
+	case Rock:
+		return "rock"
+	case Paper:
+		return "paper"
+	case Scissors:
+		return "scissors"
+	default:
+		panic(fmt.Sprintf("unsupported choice: %d", c))
+	}
+}
+
+// beats returns whether c wins against o.
+func (c Choice) beats(o Choice) bool {
+	return (uint8(c)+3-uint8(o))%3 == 1
+}
+
+// RPSPhase is the phase of a rock-paper-scissors game.
+type RPSPhase uint8
+
+const (
+	// PhaseCommit is the phase in which the players commit to their choices.
+	PhaseCommit RPSPhase = iota
+	// PhaseReveal is the phase in which the players reveal their choices.
+	PhaseReveal
+	// PhaseDone is the phase after both players revealed.
+	PhaseDone
+)
+
+// Commitment is the hash of a choice and a salt.
+type Commitment [32]byte
+
+// Salt is the secret randomness that hides a committed choice.
+type Salt [32]byte
+
+// NewCommitment creates a commitment to the given choice using a random salt.
+func NewCommitment(c Choice) (Commitment, Salt, error) {
+	var salt Salt
+	if _, err := rand.Read(salt[:]); err != nil {
+		return Commitment{}, Salt{}, errors.WithMessage(err, "sampling salt")
+	}
+	return computeCommitment(c, salt), salt, nil
+}
+
+// computeCommitment computes keccak256(choice || salt). This matches
+// `keccak256(abi.encodePacked(uint8 choice, bytes32 salt))` in Solidity.
+func computeCommitment(c Choice, salt Salt) (cm Commitment) {
+	copy(cm[:], crypto.Keccak256([]byte{uint8(c)}, salt[:]))
+	return
+}
+
+// RockPaperScissorsAppData is the app data struct.
+// The players first commit to their choices in turn and then reveal them in
+// turn. The commitments hide the first choice from the second player, so the
+// moves are effectively simultaneous.
+type RockPaperScissorsAppData struct {
+	Phase       RPSPhase
+	NextActor   uint8
+	Commitments [numParts]Commitment
+	Choices     [numParts]Choice
+	Salts       [numParts]Salt
+}
+
+func (d *RockPaperScissorsAppData) String() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Phase: %v\n", d.Phase)
+	fmt.Fprintf(&b, "Choices: %v | %v\n", d.Choices[0], d.Choices[1])
+	fmt.Fprintf(&b, "Next actor: %v\n", d.NextActor)
+	return b.String()
+}
+
+func (p RPSPhase) String() string {
+	switch p {
+	case PhaseCommit:
+		return "commit"
+	case PhaseReveal:
+		return "reveal"
+	case PhaseDone:
+		return "done"
+	default:
+		panic(fmt.Sprintf("unsupported phase: %d", p))
+	}
+}
+
+// Encode encodes app data onto an io.Writer as laid out by
+// RockPaperScissorsSchema.
+func (d *RockPaperScissorsAppData) Encode(w io.Writer) error {
+	if err := RockPaperScissorsSchema.WriteVersion(w); err != nil {
+		return errors.WithMessage(err, "writing version")
+	}
+	if err := writeUInt8(w, uint8(d.Phase)); err != nil {
+		return errors.WithMessage(err, "writing phase")
+	}
+	if err := writeUInt8(w, d.NextActor); err != nil {
+		return errors.WithMessage(err, "writing actor")
+	}
+	for i := range d.Commitments {
+		if err := writeUInt8Array(w, d.Commitments[i][:]); err != nil {
+			return errors.WithMessagef(err, "writing commitment %d", i)
+		}
+	}
+	for i := range d.Choices {
+		if err := writeUInt8(w, uint8(d.Choices[i])); err != nil {
+			return errors.WithMessagef(err, "writing choice %d", i)
+		}
+	}
+	for i := range d.Salts {
+		if err := writeUInt8Array(w, d.Salts[i][:]); err != nil {
+			return errors.WithMessagef(err, "writing salt %d", i)
+		}
+	}
+	return nil
+}
+
+// decodeRockPaperScissorsAppData decodes app data from an io.Reader. It
+// rejects data of another version than RockPaperScissorsSchema.
+func decodeRockPaperScissorsAppData(r io.Reader) (*RockPaperScissorsAppData, error) {
+	d := RockPaperScissorsAppData{}
+
+	if err := RockPaperScissorsSchema.ReadVersion(r); err != nil {
+		return nil, errors.WithMessage(err, "reading version")
+	}
+
+	phase, err := readUInt8(r)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading phase")
+	}
+	d.Phase = RPSPhase(phase)
+
+	d.NextActor, err = readUInt8(r)
+	if err != nil {
+		return nil, errors.WithMessage(err, "reading actor")
+	}
+
+	for i := range d.Commitments {
+		if _, err := io.ReadFull(r, d.Commitments[i][:]); err != nil {
+			return nil, errors.WithMessagef(err, "reading commitment %d", i)
+		}
+	}
+	for i := range d.Choices {
+		c, err := readUInt8(r)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "reading choice %d", i)
+		}
+		d.Choices[i] = Choice(c)
+	}
+	for i := range d.Salts {
+		if _, err := io.ReadFull(r, d.Salts[i][:]); err != nil {
+			return nil, errors.WithMessagef(err, "reading salt %d", i)
+		}
+	}
+	return &d, nil
+}
+
+func (d *RockPaperScissorsAppData) MarshalBinary() ([]byte, error) {
+	return marshal(d)
+}
+
+func (d *RockPaperScissorsAppData) UnmarshalBinary(data []byte) error {
+	_d, err := unmarshal(data, decodeRockPaperScissorsAppData)
+	if err != nil {
+		return err
+	}
+	*d = *_d
+	return nil
+}
+
+// Clone returns a deep copy of the app data.
+func (d *RockPaperScissorsAppData) Clone() channel.Data {
+	_d := *d
+	return &_d
+}
+
+// Commit stores the commitment of the next actor.
+func (d *RockPaperScissorsAppData) Commit(cm Commitment, actorIdx channel.Index) {
+	if d.Phase != PhaseCommit || d.NextActor != uint8safe(uint16(actorIdx)) {
+		panic("invalid actor")
+	}
+	d.Commitments[actorIdx] = cm
+	d.NextActor = calcNextActor(d.NextActor)
+	if d.NextActor == 0 {
+		d.Phase = PhaseReveal
+	}
+}
+
+// Reveal stores the choice and salt of the next actor.
+func (d *RockPaperScissorsAppData) Reveal(c Choice, salt Salt, actorIdx channel.Index) {
+	if d.Phase != PhaseReveal || d.NextActor != uint8safe(uint16(actorIdx)) {
+		panic("invalid actor")
+	}
+	d.Choices[actorIdx] = c
+	d.Salts[actorIdx] = salt
+	d.NextActor = calcNextActor(d.NextActor)
+	if d.NextActor == 0 {
+		d.Phase = PhaseDone
+	}
+}
+
+// Winner returns the winner of a finished game or nil on a draw.
+func (d *RockPaperScissorsAppData) Winner() *channel.Index {
+	var winner channel.Index
+	switch {
+	case d.Choices[0].beats(d.Choices[1]):
+		winner = 0
+	case d.Choices[1].beats(d.Choices[0]):
+		winner = 1
+	default:
+		return nil
+	}
+	return &winner
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+	"perun.network/perun-examples/app-channel/differential"
+)
+
+func TestRockPaperScissorsGame(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		choices [numParts]Choice
+		bals    []int64 // The final balances for a stake of 5 each.
+	}{
+		{"rock beats scissors", [numParts]Choice{Rock, Scissors}, []int64{10, 0}},
+		{"paper beats rock", [numParts]Choice{Rock, Paper}, []int64{0, 10}},
+		{"scissors beats paper", [numParts]Choice{Scissors, Paper}, []int64{10, 0}},
+		{"draw", [numParts]Choice{Paper, Paper}, []int64{5, 5}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			a, params, s := newRockPaperScissorsState(rng, 5)
+			require.NoError(t, a.ValidInit(params, s))
+
+			var salts [numParts]Salt
+			for idx := channel.Index(0); idx < numParts; idx++ {
+				rng.Read(salts[idx][:])
+				next := s.Clone()
+				require.NoError(t, a.Commit(next, computeCommitment(tt.choices[idx], salts[idx]), idx))
+				require.NoError(t, a.ValidTransition(params, s, next, idx))
+				s = next
+			}
+			// The first player owes a reveal, so the pot goes to the second.
+			requireBalances(t, s, 0, 10)
+
+			for idx := channel.Index(0); idx < numParts; idx++ {
+				next := s.Clone()
+				require.NoError(t, a.Reveal(next, tt.choices[idx], salts[idx], idx))
+				require.NoError(t, a.ValidTransition(params, s, next, idx))
+				s = next
+			}
+			require.True(t, s.IsFinal)
+			requireBalances(t, s, tt.bals...)
+		})
+	}
+}
+
+func TestRockPaperScissorsInvalidTransitions(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	a, params, s := newRockPaperScissorsState(rng, 5)
+
+	commitment, salt, err := NewCommitment(Rock)
+	require.NoError(t, err)
+	next := s.Clone()
+	require.NoError(t, a.Commit(next, commitment, 0))
+	require.ErrorContains(t, a.ValidTransition(params, s, next, 1), "invalid actor")
+	require.ErrorContains(t, a.ValidTransition(params, s, s.Clone(), 0), "empty commitment")
+
+	threeParties := differential.NewParams(rng, a, 10, numParts+1)
+	require.ErrorContains(t, a.ValidTransition(threeParties, s, next, 0), "number of participants")
+
+	// The second player commits, then the first reveals another choice.
+	s = next
+	next = s.Clone()
+	require.NoError(t, a.Commit(next, computeCommitment(Paper, Salt{1}), 1))
+	s = next
+	next = s.Clone()
+	require.NoError(t, a.Reveal(next, Paper, salt, 0))
+	require.ErrorContains(t, a.ValidTransition(params, s, next, 0), "does not match commitment")
+
+	next = s.Clone()
+	require.NoError(t, a.Reveal(next, Rock, salt, 0))
+	next.Balances[0][0], next.Balances[0][1] = big.NewInt(5), big.NewInt(5)
+	require.ErrorContains(t, a.ValidTransition(params, s, next, 0), "wrong allocation")
+}
+
+func TestRockPaperScissorsValidInit(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	a, params, s := newRockPaperScissorsState(rng, 5)
+	s.Balances[0][1] = big.NewInt(4)
+	require.ErrorContains(t, a.ValidInit(params, s), "unequal stakes")
+}
+
+// newRockPaperScissorsState returns a rock-paper-scissors app and the
+// parameters and initial state of a game in which each player puts stake at
+// stake.
+func newRockPaperScissorsState(rng *rand.Rand, stake int64) (*RockPaperScissorsApp, *channel.Params, *channel.State) {
+	a := NewRockPaperScissorsApp(ethwallet.AsWalletAddr(common.Address{2}))
+	params := differential.NewParams(rng, a, 10, numParts)
+	s := differential.NewState(rng, params, a.InitData(), []channel.Bal{big.NewInt(stake), big.NewInt(stake)})
+	return a, params, s
+}
+
+func requireBalances(t *testing.T, s *channel.State, bals ...int64) {
+	t.Helper()
+	for i, b := range bals {
+		require.Zero(t, s.Balances[0][i].Cmp(big.NewInt(b)), "balance %d: expected %d, got %v", i, b, s.Balances[0][i])
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import "perun.network/perun-examples/app-channel/schema"
+
+//go:generate go run ../cmd/schemagen -out ../contracts
+
+// TicTacToeSchema is the layout of the encoded TicTacToeAppData. The app
+// contract inherits its layout from the contract generated from it. A change
+// of the layout requires a new version.
+var TicTacToeSchema = schema.Schema{
+	Name:    "TicTacToeApp",
+	Version: 2,
+	Fields: []schema.Field{
+		{Name: "actor", Type: schema.Uint8, Doc: "The index of the next actor."},
+		{Name: "deadline", Type: schema.Uint64, Doc: "The deadline of the next move as Unix time in seconds."},
+		{Name: "forfeit", Type: schema.Uint8, Doc: "1 if the waiting player claimed a forfeit that the next actor has not answered, 0 otherwise."},
+		{Name: "rows", Type: schema.Uint8, Doc: "The number of rows m."},
+		{Name: "cols", Type: schema.Uint8, Doc: "The number of columns n."},
+		{Name: "winLength", Type: schema.Uint8, Doc: "The number of marks k in a line needed to win."},
+		{Name: "mode", Type: schema.Uint8, Doc: "The match mode. 0 means a fixed number of rounds, 1 means best of the number of rounds."},
+		{Name: "rounds", Type: schema.Uint8, Doc: "The number of rounds."},
+		{Name: "round", Type: schema.Uint8, Doc: "The current round, starting at 0."},
+		{Name: "firstActor", Type: schema.Uint8, Doc: "The index of the player who opened the current round."},
+		{Name: "wins", Type: schema.Uint8, Count: numParts, Doc: "The number of rounds won by player 1 and 2."},
+		{Name: "lastMove", Type: schema.Uint16, Doc: "The field of the last mark plus one, or 0 if there was none."},
+		{Name: "numStakes", Type: schema.Uint8, Doc: "The number of assets."},
+		{Name: "stakes", Type: schema.Uint256, CountField: "numStakes", Doc: "The round stake per asset."},
+		{Name: "scheme", Type: schema.Uint8, Doc: "The payout scheme. 0 means winner takes all, 1 fixed prize, 2 partial stake, 3 house rake."},
+		{Name: "share", Type: schema.Uint16, Doc: "The paid (partial stake) or raked (house rake) share in basis points."},
+		{Name: "numPrizes", Type: schema.Uint8, Doc: "The number of prizes, which is the number of assets for a fixed prize and 0 otherwise."},
+		{Name: "prizes", Type: schema.Uint256, CountField: "numPrizes", Doc: "The prize per asset."},
+		{Name: "grid", Type: schema.Uint8, Rest: true, Doc: "The m*n fields, row by row. 0 means no mark, 1 means a mark by player 1, 2 a mark by player 2."},
+	},
+}
+
+// RockPaperScissorsSchema is the layout of the encoded
+// RockPaperScissorsAppData.
+var RockPaperScissorsSchema = schema.Schema{
+	Name:    "RockPaperScissorsApp",
+	Version: 1,
+	Fields: []schema.Field{
+		{Name: "phase", Type: schema.Uint8, Doc: "The phase (0 = commit, 1 = reveal, 2 = done)."},
+		{Name: "actor", Type: schema.Uint8, Doc: "The index of the next actor."},
+		{Name: "commitment", Type: schema.Bytes32, Count: numParts, Doc: "The commitments of player 1 and 2."},
+		{Name: "choice", Type: schema.Uint8, Count: numParts, Doc: "The choices of player 1 and 2. 0 means not revealed, 1 rock, 2 paper, 3 scissors."},
+		{Name: "salt", Type: schema.Bytes32, Count: numParts, Doc: "The salts of player 1 and 2."},
+	},
+}
+
+// Schemas are the layouts of the data of all apps.
+var Schemas = []*schema.Schema{&TicTacToeSchema, &RockPaperScissorsSchema}
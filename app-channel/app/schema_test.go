@@ -0,0 +1,202 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+	"perun.network/perun-examples/app-channel/schema"
+)
+
+// TestSchemaContracts checks that the generated layout contracts are up to
+// date. Regenerate them with go generate.
+func TestSchemaContracts(t *testing.T) {
+	for _, s := range Schemas {
+		src, err := s.Solidity()
+		require.NoError(t, err)
+		file, err := os.ReadFile(filepath.Join("..", "contracts", s.ContractName()+".sol"))
+		require.NoError(t, err)
+		require.Equal(t, src, string(file), "%s is outdated, run go generate ./app", s.ContractName())
+	}
+}
+
+func TestTicTacToeAppDataEncoding(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	a := NewTicTacToeApp(ethwallet.AsWalletAddr(common.Address{1}))
+	for i := 0; i < 50; i++ {
+		board := BoardConfig{Rows: uint8(1 + rng.Intn(5)), Cols: uint8(1 + rng.Intn(5)), WinLength: 1}
+		match := MatchConfig{Mode: BestOf, Rounds: uint8(1 + rng.Intn(3))}
+		_, s := newTicTacToeState(rng, a, board, match, randomPayout(rng))
+		playRandom(rng, s)
+		if rng.Intn(2) == 0 {
+			_ = a.Forfeit(s, channel.Index(1-s.Data.(*TicTacToeAppData).NextActor)) //nolint:forcetypeassert
+		}
+		d := s.Data.(*TicTacToeAppData) //nolint:forcetypeassert
+
+		enc, err := d.MarshalBinary()
+		require.NoError(t, err)
+		var dec TicTacToeAppData
+		require.NoError(t, dec.UnmarshalBinary(enc))
+		require.True(t, d.Equal(&dec))
+		requireSchemaFields(t, &TicTacToeSchema, enc, ticTacToeSchemaFields(d))
+	}
+}
+
+func TestRockPaperScissorsAppDataEncoding(t *testing.T) {
+	d := &RockPaperScissorsAppData{Phase: PhaseDone, NextActor: 1, Choices: [numParts]Choice{Rock, Scissors}}
+	d.Commitments[0][3], d.Salts[1][31] = 7, 9
+
+	enc, err := d.MarshalBinary()
+	require.NoError(t, err)
+	var dec RockPaperScissorsAppData
+	require.NoError(t, dec.UnmarshalBinary(enc))
+	require.Equal(t, *d, dec)
+	requireSchemaFields(t, &RockPaperScissorsSchema, enc, rockPaperScissorsSchemaFields(d))
+}
+
+// requireSchemaFields requires the data enc to decode with the schema into
+// exactly the given values. want must contain every field of the schema, so
+// that the Go encoding cannot silently diverge from the schema the contracts
+// are generated from.
+func requireSchemaFields(t *testing.T, s *schema.Schema, enc []byte, want map[string][]*big.Int) {
+	t.Helper()
+	values, err := s.Decode(enc)
+	require.NoError(t, err)
+	require.Len(t, want, len(s.Fields), "every schema field must be checked")
+	for _, v := range values {
+		w, ok := want[v.Name]
+		require.True(t, ok, "schema field %s not checked", v.Name)
+		require.Len(t, v.Elems, len(w), "field %s", v.Name)
+		for i := range w {
+			require.Zero(t, w[i].Cmp(v.Uint(i)), "field %s[%d]: expected %v, got %v", v.Name, i, w[i], v.Uint(i))
+		}
+	}
+}
+
+// ticTacToeSchemaFields returns the values of d by TicTacToeSchema field.
+func ticTacToeSchemaFields(d *TicTacToeAppData) map[string][]*big.Int {
+	var forfeit uint64
+	if d.Forfeit {
+		forfeit = 1
+	}
+	grid := make([]*big.Int, len(d.Grid))
+	for i, v := range d.Grid {
+		grid[i] = big.NewInt(int64(v))
+	}
+	return map[string][]*big.Int{
+		"actor":      uints(uint64(d.NextActor)),
+		"deadline":   uints(d.Deadline),
+		"forfeit":    uints(forfeit),
+		"rows":       uints(uint64(d.Rows)),
+		"cols":       uints(uint64(d.Cols)),
+		"winLength":  uints(uint64(d.WinLength)),
+		"mode":       uints(uint64(d.Mode)),
+		"rounds":     uints(uint64(d.Rounds)),
+		"round":      uints(uint64(d.Round)),
+		"firstActor": uints(uint64(d.FirstActor)),
+		"wins":       uints(uint64(d.Wins[0]), uint64(d.Wins[1])),
+		"lastMove":   uints(uint64(d.LastMove)),
+		"numStakes":  uints(uint64(len(d.RoundStake))),
+		"stakes":     d.RoundStake,
+		"scheme":     uints(uint64(d.Payout.Scheme)),
+		"share":      uints(uint64(d.Payout.Share)),
+		"numPrizes":  uints(uint64(len(d.Payout.Prize))),
+		"prizes":     d.Payout.Prize,
+		"grid":       grid,
+	}
+}
+
+// rockPaperScissorsSchemaFields returns the values of d by
+// RockPaperScissorsSchema field.
+func rockPaperScissorsSchemaFields(d *RockPaperScissorsAppData) map[string][]*big.Int {
+	return map[string][]*big.Int{
+		"phase":      uints(uint64(d.Phase)),
+		"actor":      uints(uint64(d.NextActor)),
+		"commitment": {new(big.Int).SetBytes(d.Commitments[0][:]), new(big.Int).SetBytes(d.Commitments[1][:])},
+		"choice":     uints(uint64(d.Choices[0]), uint64(d.Choices[1])),
+		"salt":       {new(big.Int).SetBytes(d.Salts[0][:]), new(big.Int).SetBytes(d.Salts[1][:])},
+	}
+}
+
+func uints(vs ...uint64) []*big.Int {
+	r := make([]*big.Int, len(vs))
+	for i, v := range vs {
+		r[i] = new(big.Int).SetUint64(v)
+	}
+	return r
+}
+
+func TestDecodeRejectsOtherVersions(t *testing.T) {
+	for _, d := range []interface {
+		channel.Data
+		UnmarshalBinary([]byte) error
+	}{
+		NewTicTacToeApp(nil).InitData(0, DefaultBoardConfig, SingleGame, []channel.Bal{big.NewInt(1)}, WinnerTakesAllPayout),
+		&RockPaperScissorsAppData{},
+	} {
+		enc, err := d.MarshalBinary()
+		require.NoError(t, err)
+
+		enc[0]++
+		require.ErrorContains(t, d.UnmarshalBinary(enc), fmt.Sprintf("data version: %d, expected %d", enc[0], enc[0]-1))
+		enc[0]--
+		require.ErrorContains(t, d.UnmarshalBinary(append(enc, 0)), "1 trailing bytes")
+		require.NoError(t, d.UnmarshalBinary(enc))
+	}
+}
+
+func FuzzTicTacToeAppData(f *testing.F) {
+	a := NewTicTacToeApp(nil)
+	for _, p := range []Payout{WinnerTakesAllPayout, {Scheme: FixedPrize, Prize: []channel.Bal{big.NewInt(3)}}} {
+		enc, err := a.InitData(1, DefaultBoardConfig, SingleGame, []channel.Bal{big.NewInt(5)}, p).MarshalBinary()
+		require.NoError(f, err)
+		f.Add(enc)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var d TicTacToeAppData
+		if d.UnmarshalBinary(data) != nil {
+			return
+		}
+		enc, err := d.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, data, enc)
+		requireSchemaFields(t, &TicTacToeSchema, data, ticTacToeSchemaFields(&d))
+	})
+}
+
+func FuzzRockPaperScissorsAppData(f *testing.F) {
+	enc, err := (&RockPaperScissorsAppData{Phase: PhaseReveal}).MarshalBinary()
+	require.NoError(f, err)
+	f.Add(enc)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var d RockPaperScissorsAppData
+		if d.UnmarshalBinary(data) != nil {
+			return
+		}
+		enc, err := d.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, data, enc)
+		requireSchemaFields(t, &RockPaperScissorsSchema, data, rockPaperScissorsSchemaFields(&d))
+	})
+}
@@ -15,6 +15,8 @@
 package app
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"math/big"
@@ -68,51 +70,6 @@ func (v FieldValue) PlayerIndex() channel.Index {
 	}
 }
 
-func (d TicTacToeAppData) CheckFinal() (isFinal bool, winner *channel.Index) {
-	// 0 1 2
-	// 3 4 5
-	// 6 7 8
-
-	// Check winner.
-	v := [][]int{
-		{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, // rows
-		{0, 3, 6}, {1, 4, 7}, {2, 5, 8}, // columns
-		{0, 4, 8}, {2, 4, 6}, // diagonals
-	}
-
-	for _, _v := range v {
-		ok, idx := d.samePlayer(_v...)
-		if ok {
-			return true, &idx
-		}
-	}
-
-	// Check all set.
-	for _, v := range d.Grid {
-		if v != notSet {
-			return false, nil
-		}
-	}
-	return true, nil
-}
-
-func (d TicTacToeAppData) samePlayer(gridIndices ...int) (ok bool, player channel.Index) {
-	if len(gridIndices) < 2 {
-		panic("expecting at least two inputs")
-	}
-
-	first := d.Grid[gridIndices[0]]
-	if first == notSet {
-		return false, 0
-	}
-	for _, i := range gridIndices {
-		if d.Grid[i] != first {
-			return false, 0
-		}
-	}
-	return true, first.PlayerIndex()
-}
-
 func uint8safe(a uint16) uint8 {
 	b := uint8(a)
 	if uint16(b) != a {
@@ -132,6 +89,67 @@ func writeUInt8(w io.Writer, v uint8) error {
 	return err
 }
 
+// readBool reads a byte that must be 0 or 1.
+func readBool(r io.Reader) (bool, error) {
+	v, err := readUInt8(r)
+	if err != nil {
+		return false, err
+	}
+	if v > 1 {
+		return false, fmt.Errorf("invalid bool: %d", v)
+	}
+	return v == 1, nil
+}
+
+func writeBool(w io.Writer, v bool) error {
+	if v {
+		return writeUInt8(w, 1)
+	}
+	return writeUInt8(w, 0)
+}
+
+func readUInt16(r io.Reader) (uint16, error) {
+	buf := make([]byte, 2)
+	_, err := io.ReadFull(r, buf)
+	return binary.BigEndian.Uint16(buf), err
+}
+
+func writeUInt16(w io.Writer, v uint16) error {
+	buf := make([]byte, 2)
+	binary.BigEndian.PutUint16(buf, v)
+	_, err := w.Write(buf)
+	return err
+}
+
+func readUInt64(r io.Reader) (uint64, error) {
+	buf := make([]byte, 8)
+	_, err := io.ReadFull(r, buf)
+	return binary.BigEndian.Uint64(buf), err
+}
+
+func writeUInt64(w io.Writer, v uint64) error {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	_, err := w.Write(buf)
+	return err
+}
+
+// readUInt256 reads a 32-byte big-endian unsigned integer.
+func readUInt256(r io.Reader) (*big.Int, error) {
+	buf := make([]byte, 32)
+	_, err := io.ReadFull(r, buf)
+	return new(big.Int).SetBytes(buf), err
+}
+
+// writeUInt256 writes v as a 32-byte big-endian unsigned integer.
+func writeUInt256(w io.Writer, v *big.Int) error {
+	if v.Sign() < 0 || v.BitLen() > 256 {
+		return fmt.Errorf("value out of range: %v", v)
+	}
+	_, err := w.Write(v.FillBytes(make([]byte, 32)))
+	return err
+}
+
 func readUInt8Array(r io.Reader, n int) ([]uint8, error) {
 	buf := make([]byte, n)
 	_, err := io.ReadFull(r, buf)
@@ -143,6 +161,27 @@ func writeUInt8Array(w io.Writer, v []uint8) error {
 	return err
 }
 
+// marshal encodes the data into a byte slice.
+func marshal(d interface{ Encode(io.Writer) error }) ([]byte, error) {
+	var b bytes.Buffer
+	err := d.Encode(&b)
+	return b.Bytes(), err
+}
+
+// unmarshal decodes data with the given decoder. Unlike the decoder, which
+// reads from a stream, it fails if bytes are left over.
+func unmarshal[T any](data []byte, decode func(io.Reader) (*T, error)) (*T, error) {
+	r := bytes.NewReader(data)
+	d, err := decode(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%d trailing bytes", r.Len())
+	}
+	return d, nil
+}
+
 func makeFieldValueArray(a []uint8) []FieldValue {
 	b := make([]FieldValue, len(a))
 	for i := range b {
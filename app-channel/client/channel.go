@@ -3,6 +3,9 @@ package client
 import (
 	"context"
 	"fmt"
+	"log"
+	"sync"
+	"time"
 
 	"perun.network/go-perun/channel"
 	"perun.network/go-perun/client"
@@ -10,36 +13,160 @@ import (
 )
 
 // TicTacToeChannel is a wrapper for a Perun channel for the Tic-tac-toe app use case.
+// It automatically claims a forfeit on-chain if the peer misses a move deadline.
+// The peer can answer the claim on-chain within the challenge duration.
 type TicTacToeChannel struct {
 	ch *client.Channel
+
+	mtx      sync.Mutex
+	timer    *time.Timer          // Fires when the peer's move deadline passes.
+	onUpdate func(*channel.State) // Called on every off-chain update.
 }
 
 // newTicTacToeChannel creates a new tic-tac-toe app channel.
 func newTicTacToeChannel(ch *client.Channel) *TicTacToeChannel {
-	return &TicTacToeChannel{ch: ch}
+	g := &TicTacToeChannel{ch: ch}
+	ch.OnUpdate(func(_, to *channel.State) {
+		g.scheduleTimeout(to)
+
+		g.mtx.Lock()
+		cb := g.onUpdate
+		g.mtx.Unlock()
+		if cb != nil {
+			cb(to)
+		}
+	})
+	g.scheduleTimeout(ch.State())
+	return g
 }
 
-// Set sends a game move to the channel peer.
-func (g *TicTacToeChannel) Set(x, y int) {
-	err := g.ch.Update(context.TODO(), func(state *channel.State) {
+// ID returns the channel ID.
+func (g *TicTacToeChannel) ID() channel.ID {
+	return g.ch.ID()
+}
+
+// Idx returns our participant index.
+func (g *TicTacToeChannel) Idx() channel.Index {
+	return g.ch.Idx()
+}
+
+// State returns a copy of the current state.
+func (g *TicTacToeChannel) State() *channel.State {
+	return g.ch.State().Clone()
+}
+
+// Phase returns the phase of the channel, which tells whether the channel is
+// disputed on-chain.
+func (g *TicTacToeChannel) Phase() channel.Phase {
+	return g.ch.Phase()
+}
+
+// OnUpdate sets a callback that is called with the new state on every
+// off-chain update. It must not call State.
+func (g *TicTacToeChannel) OnUpdate(cb func(*channel.State)) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.onUpdate = cb
+}
+
+// scheduleTimeout restarts the move timer for the given state. The timer only
+// runs while the peer is the next actor and no forfeit is claimed. The house
+// never claims a forfeit.
+func (g *TicTacToeChannel) scheduleTimeout(s *channel.State) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+
+	d, ok := s.Data.(*app.TicTacToeAppData)
+	if !ok || s.IsFinal || d.Forfeit || channel.Index(d.NextActor) == g.ch.Idx() || g.ch.Idx() == app.HouseIdx {
+		return
+	}
+	g.timer = time.AfterFunc(time.Until(d.DeadlineTime()), g.enforceTimeout)
+}
+
+// stopTimeout stops the move timer.
+func (g *TicTacToeChannel) stopTimeout() {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+}
+
+// enforceTimeout registers the latest state on-chain and claims a forfeit
+// because the peer missed the move deadline. If the peer does not answer the
+// claim within the challenge duration, settling the channel pays out the
+// forfeited rounds.
+func (g *TicTacToeChannel) enforceTimeout() {
+	// If the channel is already disputed, it is resolved on-chain.
+	if g.ch.Phase() != channel.Acting {
+		return
+	}
+
+	log.Println("Peer missed the move deadline, claiming forfeit. The peer may answer within the challenge duration.")
+	err := g.ch.ForceUpdate(context.TODO(), func(state *channel.State) {
 		app, ok := state.App.(*app.TicTacToeApp)
 		if !ok {
 			panic(fmt.Errorf("invalid app type: %T", app))
 		}
 
-		err := app.Set(state, x, y, g.ch.Idx())
+		err := app.Forfeit(state, g.ch.Idx())
 		if err != nil {
 			panic(err)
 		}
 	})
 	if err != nil {
+		log.Printf("Error claiming forfeit: %v", err)
+	}
+}
+
+// Set sends a game move to the channel peer.
+func (g *TicTacToeChannel) Set(x, y int) {
+	if err := g.TrySet(x, y); err != nil {
 		panic(err) // We panic on error to keep the code simple.
 	}
 }
 
+// TrySet sends a game move to the channel peer and returns an error if the
+// move is invalid or the peer rejects it.
+func (g *TicTacToeChannel) TrySet(x, y int) error {
+	if err := g.checkMove(x, y); err != nil {
+		return err
+	}
+	return g.ch.Update(context.TODO(), func(state *channel.State) {
+		app, ok := state.App.(*app.TicTacToeApp)
+		if !ok {
+			panic(fmt.Errorf("invalid app type: %T", app))
+		}
+
+		err := app.Set(state, x, y, g.ch.Idx())
+		if err != nil {
+			panic(err)
+		}
+	})
+}
+
 // ForceSet registers a game move on-chain.
 func (g *TicTacToeChannel) ForceSet(x, y int) {
-	err := g.ch.ForceUpdate(context.TODO(), func(state *channel.State) {
+	if err := g.TryForceSet(x, y); err != nil {
+		panic(err)
+	}
+}
+
+// TryForceSet registers a game move on-chain and returns an error if the move
+// is invalid or the transaction fails.
+func (g *TicTacToeChannel) TryForceSet(x, y int) error {
+	if err := g.checkMove(x, y); err != nil {
+		return err
+	}
+	return g.ch.ForceUpdate(context.TODO(), func(state *channel.State) {
 		err := func() error {
 			app, ok := state.App.(*app.TicTacToeApp)
 			if !ok {
@@ -52,20 +179,41 @@ func (g *TicTacToeChannel) ForceSet(x, y int) {
 			panic(err)
 		}
 	})
-	if err != nil {
-		panic(err)
+}
+
+// checkMove checks that we may mark the field (x, y) in the current state.
+func (g *TicTacToeChannel) checkMove(x, y int) error {
+	s := g.ch.State()
+	if s.IsFinal {
+		return fmt.Errorf("game over")
+	}
+	d, ok := s.Data.(*app.TicTacToeAppData)
+	if !ok {
+		return fmt.Errorf("invalid data type: %T", s.Data)
 	}
+	return d.CheckMove(x, y, g.ch.Idx())
 }
 
 // Settle settles the app channel and withdraws the funds.
 func (g *TicTacToeChannel) Settle() {
+	if err := g.TrySettle(); err != nil {
+		panic(err)
+	}
+}
+
+// TrySettle settles the app channel and withdraws the funds. It returns an
+// error if settling fails.
+func (g *TicTacToeChannel) TrySettle() error {
+	g.stopTimeout()
+
 	// Channel should be finalized through last ("winning") move.
 	// No need to set `isFinal` here.
 	err := g.ch.Settle(context.TODO(), false)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	// Cleanup.
 	g.ch.Close()
+	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 
 	ethchannel "github.com/perun-network/perun-eth-backend/channel"
 	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
@@ -29,6 +30,7 @@ import (
 	"perun.network/go-perun/watcher/local"
 	"perun.network/go-perun/wire"
 	"perun.network/perun-examples/app-channel/app"
+	"perun.network/perun-examples/app-channel/transcript"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
@@ -44,10 +46,12 @@ type AppClient struct {
 	perunClient *client.Client                      // The core Perun client.
 	account     map[wallet.BackendID]wallet.Address // The account we use for on-chain and off-chain transactions.
 	waddress    map[wallet.BackendID]wire.Address
-	currency    channel.Asset     // The currency we expect to get paid in.
-	stake       channel.Bal       // The amount we put at stake.
-	app         *app.TicTacToeApp // The app definition.
-	channels    chan *TicTacToeChannel
+	currency    channel.Asset // The currency we expect to get paid in.
+	channels    chan AppChannel
+	recorder    *transcript.Recorder // Records the transcripts of our channels.
+
+	gamesMtx sync.RWMutex
+	games    map[channel.AppIDKey]Game // The games we play, by app ID.
 }
 
 // SetupAppClient creates a new app client.
@@ -61,8 +65,7 @@ func SetupAppClient(
 	chainID uint64, // chainID is the identifier of the blockchain.
 	adjudicator common.Address, // adjudicator is the address of the adjudicator.
 	assetaddr ethwallet.Address, // asset is the address of the asset holder for our app channels.
-	app *app.TicTacToeApp, // app is the channel app we want to set up the client with.
-	stake channel.Bal, // stake is the balance the client is willing to fund the channel with.
+	games ...Game, // games are the games we want to set up the client with.
 ) (*AppClient, error) {
 	// Create Ethereum client and contract backend.
 	cb, err := CreateContractBackend(nodeURL, chainID, w)
@@ -103,6 +106,9 @@ func SetupAppClient(
 		return nil, errors.WithMessage(err, "creating client")
 	}
 
+	recorder := transcript.NewRecorder()
+	perunClient.EnablePersistence(recorder)
+
 	eAddrs := map[wallet.BackendID]wallet.Address{ethwallet.BackendID: eaddress}
 
 	// Create client and start request handler.
@@ -111,36 +117,86 @@ func SetupAppClient(
 		account:     eAddrs,
 		waddress:    wireAddrs,
 		currency:    asset,
-		stake:       stake,
-		app:         app,
-		channels:    make(chan *TicTacToeChannel, 1),
+		channels:    make(chan AppChannel, 1),
+		recorder:    recorder,
+		games:       make(map[channel.AppIDKey]Game),
 	}
 
-	channel.RegisterApp(app)
+	for _, g := range games {
+		c.RegisterGame(g)
+	}
 	go perunClient.Handle(c, c)
 
 	return c, nil
 }
 
-// OpenAppChannel opens a new app channel with the specified peer.
-func (c *AppClient) OpenAppChannel(peer map[wallet.BackendID]wire.Address) *TicTacToeChannel {
-	participants := []map[wallet.BackendID]wire.Address{c.waddress, peer}
+// RegisterGame registers a game. Afterwards, the client accepts proposals
+// for channels of this game.
+func (c *AppClient) RegisterGame(g Game) {
+	c.gamesMtx.Lock()
+	defer c.gamesMtx.Unlock()
 
-	// We create an initial allocation which defines the starting balances.
-	initAlloc := channel.NewAllocation(2, []wallet.BackendID{ethwallet.BackendID}, c.currency)
-	initAlloc.SetAssetBalances(c.currency, []channel.Bal{
-		c.stake, // Our initial balance.
-		c.stake, // Peer's initial balance.
-	})
+	channel.RegisterApp(g.App())
+	c.games[g.App().Def().Key()] = g
+}
 
-	// Prepare the channel proposal by defining the channel parameters.
-	challengeDuration := uint64(10) // On-chain challenge duration in seconds.
+// game returns the registered game for the app with the given ID.
+func (c *AppClient) game(id channel.AppID) (Game, bool) {
+	c.gamesMtx.RLock()
+	defer c.gamesMtx.RUnlock()
+
+	g, ok := c.games[id.Key()]
+	return g, ok
+}
 
+// OpenTicTacToeChannel opens a new tic-tac-toe channel with the specified
+// peer. The match is played on the given board and each player puts the
+// whole stake at stake over all rounds. With the app.HouseRake payout, the
+// house set by TicTacToeGame.UseHouse joins as third participant.
+func (c *AppClient) OpenTicTacToeChannel(peer map[wallet.BackendID]wire.Address, g *TicTacToeGame, board app.BoardConfig, match app.MatchConfig, payout app.Payout) *TicTacToeChannel {
 	firstActorIdx := channel.Index(0)
-	withApp := client.WithApp(c.app, c.app.InitData(firstActorIdx))
+	initData := g.app.InitData(firstActorIdx, board, match, match.RoundStakes(g.stake), payout)
+	peers := []map[wallet.BackendID]wire.Address{peer}
+	if payout.Scheme == app.HouseRake {
+		if g.house == nil {
+			panic("no house set")
+		}
+		peers = append(peers, g.house)
+	}
+	return c.OpenAppChannel(g, initData, peers...).(*TicTacToeChannel) //nolint:forcetypeassert
+}
+
+// OpenRockPaperScissorsChannel opens a new rock-paper-scissors channel with
+// the specified peer.
+func (c *AppClient) OpenRockPaperScissorsChannel(peer map[wallet.BackendID]wire.Address, g *RockPaperScissorsGame) *RockPaperScissorsChannel {
+	return c.OpenAppChannel(g, g.app.InitData(), peer).(*RockPaperScissorsChannel) //nolint:forcetypeassert
+}
+
+// OpenAppChannel opens a new app channel for a registered game with the
+// specified peers. We and the first peer put the game's stake into the
+// channel, further peers join without a stake.
+func (c *AppClient) OpenAppChannel(g Game, initData channel.Data, peers ...map[wallet.BackendID]wire.Address) AppChannel {
+	if _, ok := c.game(g.App().Def()); !ok {
+		panic("game not registered")
+	}
+
+	participants := append([]map[wallet.BackendID]wire.Address{c.waddress}, peers...)
+
+	// We create an initial allocation which defines the starting balances.
+	initAlloc := channel.NewAllocation(len(participants), []wallet.BackendID{ethwallet.BackendID}, c.currency)
+	initBals := make([]channel.Bal, len(participants))
+	for i := range initBals {
+		initBals[i] = big.NewInt(0)
+	}
+	initBals[0] = g.Stake() // Our initial balance.
+	initBals[1] = g.Stake() // Peer's initial balance.
+	initAlloc.SetAssetBalances(c.currency, initBals)
+
+	// Prepare the channel proposal by defining the channel parameters.
+	withApp := client.WithApp(g.App(), initData)
 
 	proposal, err := client.NewLedgerChannelProposal(
-		challengeDuration,
+		g.ChallengeDuration(),
 		c.account,
 		initAlloc,
 		participants,
@@ -159,7 +215,7 @@ func (c *AppClient) OpenAppChannel(peer map[wallet.BackendID]wire.Address) *TicT
 	// Start the on-chain event watcher. It automatically handles disputes.
 	c.startWatching(ch)
 
-	return newTicTacToeChannel(ch)
+	return g.NewChannel(ch)
 }
 
 // startWatching starts the dispute watcher for the specified channel.
@@ -173,10 +229,28 @@ func (c *AppClient) startWatching(ch *client.Channel) {
 }
 
 // AcceptedChannel returns the next accepted app channel.
-func (c *AppClient) AcceptedChannel() *TicTacToeChannel {
+func (c *AppClient) AcceptedChannel() AppChannel {
 	return <-c.channels
 }
 
+// Transcript returns the transcript of all co-signed states of the channel.
+func (c *AppClient) Transcript(ch AppChannel) (*transcript.Transcript, error) {
+	t, ok := c.recorder.Transcript(ch.ID())
+	if !ok {
+		return nil, fmt.Errorf("no transcript for channel %x", ch.ID())
+	}
+	return t, nil
+}
+
+// ExportTranscript writes the transcript of the channel to the file at path.
+func (c *AppClient) ExportTranscript(ch AppChannel, path string) error {
+	t, err := c.Transcript(ch)
+	if err != nil {
+		return err
+	}
+	return t.WriteFile(path)
+}
+
 // Shutdown gracefully shuts down the client.
 func (c *AppClient) Shutdown() {
 	c.perunClient.Close()
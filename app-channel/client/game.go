@@ -0,0 +1,325 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/client"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+	"perun.network/perun-examples/app-channel/app"
+)
+
+// deadlineTolerance is the tolerated clock difference between the peers when
+// checking move deadlines.
+const deadlineTolerance = 10 * time.Second
+
+// Game is a channel app together with the client-side logic for playing it.
+// An AppClient hosts several games and dispatches proposals and updates to
+// the game whose app they refer to.
+type Game interface {
+	// App returns the app definition.
+	App() channel.App
+	// Stake returns the amount we put at stake in a channel of this game.
+	Stake() channel.Bal
+	// ChallengeDuration returns the challenge duration in seconds for
+	// channels of this game.
+	ChallengeDuration() uint64
+	// CheckProposal checks the game-specific parts of an incoming proposal,
+	// including the number of participants, after the initial allocation has
+	// been checked. ourIdx is our participant index.
+	CheckProposal(lcp *client.LedgerChannelProposalMsg, ourIdx channel.Index) error
+	// CheckUpdate checks an incoming update beyond the app's transition rules.
+	CheckUpdate(cur *channel.State, next client.ChannelUpdate, ourIdx channel.Index) error
+	// NewChannel wraps a channel of this game.
+	NewChannel(ch *client.Channel) AppChannel
+}
+
+// AppChannel is a channel wrapper for a specific game.
+type AppChannel interface {
+	// ID returns the channel ID.
+	ID() channel.ID
+	// Settle settles the app channel and withdraws the funds.
+	Settle()
+}
+
+// TicTacToeGame is the tic-tac-toe game.
+type TicTacToeGame struct {
+	app     *app.TicTacToeApp
+	stake   channel.Bal
+	limits  app.BoardLimits // The board dimensions we accept.
+	house   map[wallet.BackendID]wire.Address
+	rake    uint16 // The maximum rake we pay as player or the minimum rake we take as house.
+	isHouse bool
+}
+
+// NewTicTacToeGame creates a tic-tac-toe game with the given stake. Proposals
+// for boards exceeding the limits are rejected.
+func NewTicTacToeGame(app *app.TicTacToeApp, stake channel.Bal, limits app.BoardLimits) *TicTacToeGame {
+	return &TicTacToeGame{app: app, stake: stake, limits: limits}
+}
+
+// NewTicTacToeHouse creates a tic-tac-toe game in which we act as the house:
+// we join channels with the app.HouseRake payout as third participant without
+// a stake and accept proposals taking at least minRake basis points.
+func NewTicTacToeHouse(app *app.TicTacToeApp, limits app.BoardLimits, minRake uint16) *TicTacToeGame {
+	return &TicTacToeGame{app: app, stake: big.NewInt(0), limits: limits, rake: minRake, isHouse: true}
+}
+
+// UseHouse sets the house that joins our proposals with the app.HouseRake
+// payout and lets us accept proposals raking at most maxRake basis points.
+func (g *TicTacToeGame) UseHouse(house map[wallet.BackendID]wire.Address, maxRake uint16) {
+	g.house = house
+	g.rake = maxRake
+}
+
+// App returns the app definition.
+func (g *TicTacToeGame) App() channel.App {
+	return g.app
+}
+
+// Stake returns the amount we put at stake.
+func (g *TicTacToeGame) Stake() channel.Bal {
+	return g.stake
+}
+
+// ChallengeDuration returns the move timeout, so that a missed deadline can
+// be enforced on-chain.
+func (g *TicTacToeGame) ChallengeDuration() uint64 {
+	return uint64(g.app.MoveTimeout.Seconds())
+}
+
+// CheckProposal checks the board, the match, the payout and the first
+// deadline.
+func (g *TicTacToeGame) CheckProposal(lcp *client.LedgerChannelProposalMsg, ourIdx channel.Index) error {
+	initData, ok := lcp.InitData.(*app.TicTacToeAppData)
+	if !ok {
+		return fmt.Errorf("invalid data type: %T", lcp.InitData)
+	}
+	if err := g.checkPayout(initData.Payout, initData.RoundStake, ourIdx); err != nil {
+		return err
+	}
+	if lcp.NumPeers() != initData.Payout.NumParts() {
+		return fmt.Errorf("invalid number of participants: %d", lcp.NumPeers())
+	}
+
+	// Check that a missed deadline can be enforced on-chain and that we
+	// have enough time for our first move.
+	if time.Duration(lcp.ChallengeDuration)*time.Second < g.app.MoveTimeout {
+		return fmt.Errorf("challenge duration shorter than move timeout: %ds", lcp.ChallengeDuration)
+	}
+	if err := g.checkDeadline(lcp.InitData, ourIdx); err != nil {
+		return err
+	}
+
+	// Check that the board is within our limits.
+	if err := initData.BoardConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid board: %v", err)
+	}
+	if err := g.limits.Allows(initData.BoardConfig); err != nil {
+		return err
+	}
+	if err := initData.MatchConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid match: %v", err)
+	}
+
+	// Check that the round stakes split the stakes over the rounds, so that
+	// the players can pay every round of the match.
+	stakes := lcp.InitBals.Balances[0]
+	if err := checkRoundStakes(initData.RoundStake, initData.MatchConfig.RoundStakes(stakes[0])); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkRoundStakes checks that the proposed round stakes equal the expected
+// ones.
+func checkRoundStakes(proposed, expected []channel.Bal) error {
+	if len(proposed) != len(expected) {
+		return fmt.Errorf("invalid number of round stakes: %d", len(proposed))
+	}
+	for i := range proposed {
+		if proposed[i].Cmp(expected[i]) != 0 {
+			return fmt.Errorf("invalid round stake for asset %d: expected %v, got %v", i, expected[i], proposed[i])
+		}
+	}
+	return nil
+}
+
+// checkPayout checks that the payout is valid and that we take part in it as
+// the house or as a player as configured.
+func (g *TicTacToeGame) checkPayout(p app.Payout, roundStake []channel.Bal, ourIdx channel.Index) error {
+	if err := p.Validate(roundStake); err != nil {
+		return fmt.Errorf("invalid payout: %v", err)
+	}
+	if g.isHouse != (ourIdx == app.HouseIdx) {
+		return fmt.Errorf("invalid participant index: %d", ourIdx)
+	}
+	if p.Scheme != app.HouseRake {
+		if g.isHouse {
+			return fmt.Errorf("house without rake")
+		}
+		return nil
+	}
+	if g.isHouse && p.Share < g.rake {
+		return fmt.Errorf("rake too low: %d < %d", p.Share, g.rake)
+	} else if !g.isHouse && p.Share > g.rake {
+		return fmt.Errorf("rake too high: %d > %d", p.Share, g.rake)
+	}
+	return nil
+}
+
+// CheckUpdate checks the move deadlines, which depend on the time.
+func (g *TicTacToeGame) CheckUpdate(cur *channel.State, next client.ChannelUpdate, ourIdx channel.Index) error {
+	curData, ok := cur.Data.(*app.TicTacToeAppData)
+	if !ok {
+		return fmt.Errorf("invalid data type: %T", cur.Data)
+	}
+
+	// A forfeit may only be claimed after the deadline has passed.
+	if channel.Index(curData.NextActor) != next.ActorIdx {
+		if time.Now().Before(curData.DeadlineTime()) {
+			return fmt.Errorf("forfeit before deadline %v", curData.DeadlineTime())
+		}
+		return nil
+	}
+
+	if next.State.IsFinal {
+		return nil
+	}
+	return g.checkDeadline(next.State.Data, ourIdx)
+}
+
+// checkDeadline checks that the deadline in data leaves us enough time for
+// our move if we are the next actor.
+func (g *TicTacToeGame) checkDeadline(data channel.Data, ourIdx channel.Index) error {
+	d, ok := data.(*app.TicTacToeAppData)
+	if !ok {
+		return fmt.Errorf("invalid data type: %T", data)
+	}
+	if channel.Index(d.NextActor) != ourIdx {
+		return nil
+	}
+
+	earliest := time.Now().Add(g.app.MoveTimeout - deadlineTolerance)
+	if d.DeadlineTime().Before(earliest) {
+		return fmt.Errorf("deadline too early: %v", d.DeadlineTime())
+	}
+	return nil
+}
+
+// NewChannel wraps a tic-tac-toe channel.
+func (g *TicTacToeGame) NewChannel(ch *client.Channel) AppChannel {
+	return newTicTacToeChannel(ch)
+}
+
+// RockPaperScissorsGame is the rock-paper-scissors game.
+type RockPaperScissorsGame struct {
+	app   *app.RockPaperScissorsApp
+	stake channel.Bal
+
+	mtx       sync.Mutex
+	deadlines map[channel.ID]time.Time // The reveal deadlines of the peers by channel.
+}
+
+// NewRockPaperScissorsGame creates a rock-paper-scissors game with the given
+// stake.
+func NewRockPaperScissorsGame(app *app.RockPaperScissorsApp, stake channel.Bal) *RockPaperScissorsGame {
+	return &RockPaperScissorsGame{app: app, stake: stake, deadlines: make(map[channel.ID]time.Time)}
+}
+
+// App returns the app definition.
+func (g *RockPaperScissorsGame) App() channel.App {
+	return g.app
+}
+
+// Stake returns the amount we put at stake.
+func (g *RockPaperScissorsGame) Stake() channel.Bal {
+	return g.stake
+}
+
+// ChallengeDuration returns the reveal timeout, so that a player who stalls
+// after the reveal deadline cannot delay the settlement further than that.
+func (g *RockPaperScissorsGame) ChallengeDuration() uint64 {
+	return uint64(g.app.RevealTimeout.Seconds())
+}
+
+// CheckProposal checks that the game starts in the commit phase and that the
+// challenge duration covers the reveal timeout.
+func (g *RockPaperScissorsGame) CheckProposal(lcp *client.LedgerChannelProposalMsg, _ channel.Index) error {
+	if lcp.NumPeers() != 2 {
+		return fmt.Errorf("invalid number of participants: %d", lcp.NumPeers())
+	}
+	initData, ok := lcp.InitData.(*app.RockPaperScissorsAppData)
+	if !ok {
+		return fmt.Errorf("invalid data type: %T", lcp.InitData)
+	}
+	if initData.Phase != app.PhaseCommit {
+		return fmt.Errorf("invalid initial phase: %v", initData.Phase)
+	}
+	if time.Duration(lcp.ChallengeDuration)*time.Second < g.app.RevealTimeout {
+		return fmt.Errorf("challenge duration shorter than reveal timeout: %ds", lcp.ChallengeDuration)
+	}
+	return nil
+}
+
+// CheckUpdate rejects a reveal of the peer after its reveal deadline, after
+// which we settle the channel with the pot assigned to us.
+func (g *RockPaperScissorsGame) CheckUpdate(cur *channel.State, _ client.ChannelUpdate, ourIdx channel.Index) error {
+	d, ok := cur.Data.(*app.RockPaperScissorsAppData)
+	if !ok {
+		return fmt.Errorf("invalid data type: %T", cur.Data)
+	}
+	if d.Phase != app.PhaseReveal || channel.Index(d.NextActor) == ourIdx {
+		return nil
+	}
+	if deadline, ok := g.revealDeadline(cur.ID); ok && time.Now().After(deadline) {
+		return fmt.Errorf("reveal after deadline %v", deadline)
+	}
+	return nil
+}
+
+// revealDeadline returns the reveal deadline of the peer in the channel, if
+// the peer owes a reveal.
+func (g *RockPaperScissorsGame) revealDeadline(id channel.ID) (time.Time, bool) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	deadline, ok := g.deadlines[id]
+	return deadline, ok
+}
+
+// setRevealDeadline sets the reveal deadline of the peer in the channel. A
+// zero deadline removes it.
+func (g *RockPaperScissorsGame) setRevealDeadline(id channel.ID, deadline time.Time) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if deadline.IsZero() {
+		delete(g.deadlines, id)
+		return
+	}
+	g.deadlines[id] = deadline
+}
+
+// NewChannel wraps a rock-paper-scissors channel.
+func (g *RockPaperScissorsGame) NewChannel(ch *client.Channel) AppChannel {
+	return newRockPaperScissorsChannel(ch, g)
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/client"
+	"perun.network/perun-examples/app-channel/app"
+)
+
+func TestRockPaperScissorsTimeout(t *testing.T) {
+	a := app.NewRockPaperScissorsApp(ethwallet.AsWalletAddr(common.Address{2}))
+	g := NewRockPaperScissorsGame(a, big.NewInt(5))
+	require.Equal(t, uint64(app.DefaultRevealTimeout.Seconds()), g.ChallengeDuration())
+
+	// The challenge duration must cover the reveal timeout.
+	lcp := &client.LedgerChannelProposalMsg{}
+	lcp.InitBals = &channel.Allocation{Balances: channel.Balances{{big.NewInt(5), big.NewInt(5)}}}
+	lcp.InitData = a.InitData()
+	lcp.ChallengeDuration = g.ChallengeDuration()
+	require.NoError(t, g.CheckProposal(lcp, 1))
+	lcp.ChallengeDuration--
+	require.ErrorContains(t, g.CheckProposal(lcp, 1), "shorter than reveal timeout")
+
+	// The peer may reveal until its deadline.
+	cur := &channel.State{ID: channel.ID{1}, Data: &app.RockPaperScissorsAppData{Phase: app.PhaseReveal, NextActor: 1}}
+	require.NoError(t, g.CheckUpdate(cur, client.ChannelUpdate{}, 0), "no deadline")
+	g.setRevealDeadline(cur.ID, time.Now().Add(time.Minute))
+	require.NoError(t, g.CheckUpdate(cur, client.ChannelUpdate{}, 0), "before deadline")
+	g.setRevealDeadline(cur.ID, time.Now().Add(-time.Second))
+	require.ErrorContains(t, g.CheckUpdate(cur, client.ChannelUpdate{}, 0), "reveal after deadline")
+	require.NoError(t, g.CheckUpdate(cur, client.ChannelUpdate{}, 1), "own reveal")
+	g.setRevealDeadline(cur.ID, time.Time{})
+	require.NoError(t, g.CheckUpdate(cur, client.ChannelUpdate{}, 0), "removed deadline")
+}
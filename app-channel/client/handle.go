@@ -21,44 +21,51 @@ import (
 
 	"perun.network/go-perun/channel"
 	"perun.network/go-perun/client"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
 )
 
 // HandleProposal is the callback for incoming channel proposals.
 func (c *AppClient) HandleProposal(p client.ChannelProposal, r *client.ProposalResponder) {
-	lcp, err := func() (*client.LedgerChannelProposalMsg, error) {
+	lcp, g, err := func() (*client.LedgerChannelProposalMsg, Game, error) {
 		// Ensure that we got a ledger channel proposal.
 		lcp, ok := p.(*client.LedgerChannelProposalMsg)
 		if !ok {
-			return nil, fmt.Errorf("invalid proposal type: %T", p)
+			return nil, nil, fmt.Errorf("invalid proposal type: %T", p)
 		}
 
-		// Ensure the ledger channel proposal includes the expected app.
-		if !lcp.App.Def().Equal(c.app.Def()) {
-			return nil, fmt.Errorf("invalid app type ")
+		// Ensure the ledger channel proposal includes a game we play.
+		if channel.IsNoApp(lcp.App) {
+			return nil, nil, fmt.Errorf("missing app")
 		}
-
-		// Check that we have the correct number of participants.
-		if lcp.NumPeers() != 2 {
-			return nil, fmt.Errorf("invalid number of participants: %d", lcp.NumPeers())
+		g, ok := c.game(lcp.App.Def())
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown app")
 		}
 
-		// Check that the channel has the expected assets.
-		err := channel.AssertAssetsEqual(lcp.InitBals.Assets, []channel.Asset{c.currency})
-		if err != nil {
-			return nil, fmt.Errorf("invalid assets: %v", err)
+		// Find our participant index.
+		ourIdx, ok := c.participantIdx(lcp.Peers)
+		if !ok || ourIdx == 0 {
+			return nil, nil, fmt.Errorf("not a proposee")
 		}
 
-		// Check that the channel has the expected assets and funding balances.
-		const assetIdx, peerIdx = 0, 1
+		// Check that the channel has the expected assets and balances.
 		if err := channel.AssertAssetsEqual(lcp.InitBals.Assets, []channel.Asset{c.currency}); err != nil {
-			return nil, fmt.Errorf("invalid assets: %v", err)
-		} else if lcp.FundingAgreement[assetIdx][peerIdx].Cmp(c.stake) != 0 {
-			return nil, fmt.Errorf("invalid funding balance")
+			return nil, nil, fmt.Errorf("invalid assets: %v", err)
+		}
+		if err := checkAllocation(lcp, g.Stake(), ourIdx); err != nil {
+			return nil, nil, err
 		}
-		return lcp, nil
+
+		// Check the game-specific parts of the proposal.
+		if err := g.CheckProposal(lcp, ourIdx); err != nil {
+			return nil, nil, err
+		}
+		return lcp, g, nil
 	}()
 	if err != nil {
 		r.Reject(context.TODO(), err.Error()) //nolint:errcheck // It's OK if rejection fails.
+		return
 	}
 
 	// Create a channel accept message and send it.
@@ -75,19 +82,71 @@ func (c *AppClient) HandleProposal(p client.ChannelProposal, r *client.ProposalR
 	// Start the on-chain event watcher. It automatically handles disputes.
 	c.startWatching(ch)
 
-	c.channels <- newTicTacToeChannel(ch)
+	c.channels <- g.NewChannel(ch)
 }
 
 // HandleUpdate is the callback for incoming channel updates.
 func (c *AppClient) HandleUpdate(cur *channel.State, next client.ChannelUpdate, r *client.UpdateResponder) {
 	// Perun automatically checks that the transition is valid.
-	// We always accept.
-	err := r.Accept(context.TODO())
+	// We additionally let the game check the update.
+	err := func() error {
+		g, ok := c.game(cur.App.Def())
+		if !ok {
+			return fmt.Errorf("unknown app")
+		}
+		ch, err := c.perunClient.Channel(cur.ID)
+		if err != nil {
+			return err
+		}
+		return g.CheckUpdate(cur, next, ch.Idx())
+	}()
+	if err != nil {
+		r.Reject(context.TODO(), err.Error()) //nolint:errcheck // It's OK if rejection fails.
+		return
+	}
+
+	err = r.Accept(context.TODO())
 	if err != nil {
 		panic(err)
 	}
 }
 
+// checkAllocation checks that the proposal is funded with its initial
+// balances, in which both players put the same stake into the channel, we
+// put stake, and further participants, such as the house, put nothing.
+func checkAllocation(lcp *client.LedgerChannelProposalMsg, stake channel.Bal, ourIdx channel.Index) error {
+	const assetIdx = 0
+	if err := lcp.FundingAgreement.AssertEqual(lcp.InitBals.Balances); err != nil {
+		return fmt.Errorf("funding agreement differs from initial balances: %v", err)
+	}
+	bals := lcp.InitBals.Balances[assetIdx]
+	if len(bals) < 2 || int(ourIdx) >= len(bals) {
+		return fmt.Errorf("invalid number of balances: %d", len(bals))
+	}
+	if bals[ourIdx].Cmp(stake) != 0 {
+		return fmt.Errorf("invalid stake: expected %v, got %v", stake, bals[ourIdx])
+	}
+	if bals[0].Sign() <= 0 || bals[0].Cmp(bals[1]) != 0 {
+		return fmt.Errorf("unequal player stakes: %v and %v", bals[0], bals[1])
+	}
+	for i, bal := range bals[2:] {
+		if bal.Sign() != 0 {
+			return fmt.Errorf("non-zero balance of participant %d: %v", i+2, bal)
+		}
+	}
+	return nil
+}
+
+// participantIdx returns our index among the given participants.
+func (c *AppClient) participantIdx(peers []map[wallet.BackendID]wire.Address) (channel.Index, bool) {
+	for i, p := range peers {
+		if channel.EqualWireMaps(p, c.waddress) {
+			return channel.Index(i), true
+		}
+	}
+	return 0, false
+}
+
 // HandleAdjudicatorEvent is the callback for smart contract events.
 func (c *AppClient) HandleAdjudicatorEvent(e channel.AdjudicatorEvent) {
 	log.Printf("Adjudicator event: type = %T, client = %v", e, c.account)
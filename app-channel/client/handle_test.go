@@ -0,0 +1,52 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/client"
+)
+
+func TestCheckAllocation(t *testing.T) {
+	proposal := func(bals ...int64) *client.LedgerChannelProposalMsg {
+		b := make([]channel.Bal, len(bals))
+		for i, bal := range bals {
+			b[i] = big.NewInt(bal)
+		}
+		lcp := &client.LedgerChannelProposalMsg{}
+		lcp.InitBals = &channel.Allocation{Balances: channel.Balances{b}}
+		lcp.FundingAgreement = lcp.InitBals.Balances.Clone()
+		return lcp
+	}
+	stake := big.NewInt(5)
+
+	require.NoError(t, checkAllocation(proposal(5, 5), stake, 1))
+	require.NoError(t, checkAllocation(proposal(5, 5, 0), stake, 1))
+	require.NoError(t, checkAllocation(proposal(7, 7, 0), big.NewInt(0), 2))
+
+	require.ErrorContains(t, checkAllocation(proposal(5, 6), stake, 1), "invalid stake")
+	require.ErrorContains(t, checkAllocation(proposal(4, 5), stake, 1), "unequal player stakes")
+	require.ErrorContains(t, checkAllocation(proposal(0, 0, 0), big.NewInt(0), 2), "unequal player stakes")
+	require.ErrorContains(t, checkAllocation(proposal(5, 5, 1), stake, 1), "participant 2")
+	require.ErrorContains(t, checkAllocation(proposal(5), stake, 0), "number of balances")
+
+	lcp := proposal(5, 5)
+	lcp.FundingAgreement[0][0] = big.NewInt(1)
+	require.ErrorContains(t, checkAllocation(lcp, stake, 1), "funding agreement")
+}
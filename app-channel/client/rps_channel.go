@@ -0,0 +1,195 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/client"
+	"perun.network/perun-examples/app-channel/app"
+)
+
+// RockPaperScissorsChannel is a wrapper for a Perun channel for the
+// rock-paper-scissors app use case. It keeps the committed choice and salt
+// locally until they are revealed. It automatically settles the channel if
+// the peer misses the reveal deadline, so that we receive the pot.
+type RockPaperScissorsChannel struct {
+	ch     *client.Channel
+	game   *RockPaperScissorsGame
+	choice app.Choice
+	salt   app.Salt
+
+	mtx     sync.Mutex
+	timer   *time.Timer // Fires when the peer's reveal deadline passes.
+	settled bool        // Whether the channel is settled or settling.
+}
+
+// newRockPaperScissorsChannel creates a new rock-paper-scissors app channel.
+func newRockPaperScissorsChannel(ch *client.Channel, game *RockPaperScissorsGame) *RockPaperScissorsChannel {
+	g := &RockPaperScissorsChannel{ch: ch, game: game}
+	ch.OnUpdate(func(_, to *channel.State) {
+		g.scheduleTimeout(to)
+	})
+	g.scheduleTimeout(ch.State())
+	return g
+}
+
+// scheduleTimeout restarts the reveal timer for the given state. The timer
+// only runs while the peer owes a reveal.
+func (g *RockPaperScissorsChannel) scheduleTimeout(s *channel.State) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.stopTimeoutLocked()
+	d, ok := s.Data.(*app.RockPaperScissorsAppData)
+	if !ok || s.IsFinal || g.settled || d.Phase != app.PhaseReveal || channel.Index(d.NextActor) == g.ch.Idx() {
+		return
+	}
+	timeout := g.game.app.RevealTimeout
+	g.game.setRevealDeadline(g.ch.ID(), time.Now().Add(timeout))
+	g.timer = time.AfterFunc(timeout, g.enforceTimeout)
+}
+
+// stopTimeoutLocked stops the reveal timer and removes the deadline. The
+// mutex must be held.
+func (g *RockPaperScissorsChannel) stopTimeoutLocked() {
+	if g.timer != nil {
+		g.timer.Stop()
+		g.timer = nil
+	}
+	g.game.setRevealDeadline(g.ch.ID(), time.Time{})
+}
+
+// enforceTimeout settles the channel because the peer missed the reveal
+// deadline. The latest state assigns the pot to us.
+func (g *RockPaperScissorsChannel) enforceTimeout() {
+	// If the channel is already disputed, it is resolved on-chain.
+	if g.ch.Phase() != channel.Acting {
+		return
+	}
+
+	log.Println("Peer missed the reveal deadline, settling the channel. The pot is paid out after the challenge duration.")
+	if err := g.settle(); err != nil {
+		log.Printf("Error settling channel: %v", err)
+	}
+}
+
+// ID returns the channel ID.
+func (g *RockPaperScissorsChannel) ID() channel.ID {
+	return g.ch.ID()
+}
+
+// Commit commits to a choice and sends the commitment to the channel peer.
+func (g *RockPaperScissorsChannel) Commit(c app.Choice) {
+	cm, salt, err := app.NewCommitment(c)
+	if err != nil {
+		panic(err)
+	}
+	g.choice, g.salt = c, salt
+
+	err = g.ch.Update(context.TODO(), func(state *channel.State) {
+		app, ok := state.App.(*app.RockPaperScissorsApp)
+		if !ok {
+			panic(fmt.Errorf("invalid app type: %T", app))
+		}
+
+		err := app.Commit(state, cm, g.ch.Idx())
+		if err != nil {
+			panic(err)
+		}
+	})
+	if err != nil {
+		panic(err) // We panic on error to keep the code simple.
+	}
+}
+
+// Reveal reveals the committed choice to the channel peer.
+func (g *RockPaperScissorsChannel) Reveal() {
+	err := g.ch.Update(context.TODO(), func(state *channel.State) {
+		app, ok := state.App.(*app.RockPaperScissorsApp)
+		if !ok {
+			panic(fmt.Errorf("invalid app type: %T", app))
+		}
+
+		err := app.Reveal(state, g.choice, g.salt, g.ch.Idx())
+		if err != nil {
+			panic(err)
+		}
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ForceReveal registers the reveal on-chain. This is used if the peer does
+// not respond anymore.
+func (g *RockPaperScissorsChannel) ForceReveal() {
+	err := g.ch.ForceUpdate(context.TODO(), func(state *channel.State) {
+		err := func() error {
+			app, ok := state.App.(*app.RockPaperScissorsApp)
+			if !ok {
+				return fmt.Errorf("invalid app type: %T", app)
+			}
+
+			return app.Reveal(state, g.choice, g.salt, g.ch.Idx())
+		}()
+		if err != nil {
+			panic(err)
+		}
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Settle settles the app channel and withdraws the funds.
+func (g *RockPaperScissorsChannel) Settle() {
+	// If the game is not finished, settling registers the latest state and
+	// the player that owes a reveal forfeits their stake.
+	err := g.settle()
+	if err != nil {
+		panic(err)
+	}
+
+	// Cleanup.
+	g.ch.Close()
+}
+
+// settle settles the channel unless it is already settled or settling. The
+// mutex is not held while settling, as the channel may call the update
+// handler meanwhile.
+func (g *RockPaperScissorsChannel) settle() error {
+	g.mtx.Lock()
+	if g.settled {
+		g.mtx.Unlock()
+		return nil
+	}
+	g.settled = true
+	g.stopTimeoutLocked()
+	g.mtx.Unlock()
+
+	if err := g.ch.Settle(context.TODO(), false); err != nil {
+		g.mtx.Lock()
+		g.settled = false
+		g.mtx.Unlock()
+		return err
+	}
+	return nil
+}
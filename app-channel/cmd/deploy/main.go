@@ -0,0 +1,44 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command deploy deploys the contracts of the app channel and records them in
+// the deployment registry. Contracts that are recorded and still deployed are
+// reused, so the command can be run repeatedly.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"perun.network/perun-examples/app-channel/deployment"
+	"perun.network/perun-examples/shared/registry"
+)
+
+// keyDeployer is the private key of the deployer account of the demo.
+const keyDeployer = "79ea8f62d97bc0591a4224c1725fca6b00de5b2cea286fe2e0bb35c5e76be46e"
+
+func main() {
+	nodeURL := flag.String("node", "ws://127.0.0.1:8545", "URL of the blockchain node")
+	chainID := flag.Uint64("chain-id", 1337, "chain ID of the blockchain")
+	key := flag.String("key", keyDeployer, "private key of the deployer")
+	registryFile := flag.String("registry", registry.DefaultFile, "path of the deployment registry")
+	flag.Parse()
+
+	c, err := deployment.DeployFile(*nodeURL, *chainID, *key, *registryFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Adjudicator: %v, asset holder: %v, app: %v, rock-paper-scissors app: %v. Recorded in %s.",
+		c.Adjudicator, c.AssetHolder, c.App, c.RockPaperScissorsApp, *registryFile)
+}
@@ -0,0 +1,279 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command play is an interactive terminal client for tic-tac-toe app channels.
+// Each player runs their own process:
+//
+//	go run ./cmd/play -key <alice's key> -propose -peer <bob's address>
+//	go run ./cmd/play -key <bob's key>
+//
+// In headless mode, the moves are read from a script instead of the keyboard.
+// With -local, two headless players run in one process over a local bus:
+//
+//	go run ./cmd/play -local alice.txt bob.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"perun.network/perun-examples/app-channel/app"
+	"perun.network/perun-examples/app-channel/client"
+	"perun.network/perun-examples/app-channel/deployment"
+	"perun.network/perun-examples/shared/registry"
+)
+
+// Accounts used in local mode, funded by the testnet setup of the demo.
+const (
+	keyDeployer = "79ea8f62d97bc0591a4224c1725fca6b00de5b2cea286fe2e0bb35c5e76be46e"
+	keyAlice    = "1af2e950272dd403de7a5760d41c6e44d92b6d02797e51810795ff03cc2cda4f"
+	keyBob      = "f63d7d8e930bccd74e93cf5662fde2c28fd8be95edb70c73f1bdd863d07f412e"
+)
+
+// config is the configuration given by the command line flags.
+type config struct {
+	nodeURL     string
+	chainID     uint64
+	key         string
+	adjudicator common.Address
+	assetHolder common.Address
+	app         common.Address
+
+	propose bool
+	peer    common.Address
+	stake   *big.Int
+	board   app.BoardConfig
+	match   app.MatchConfig
+
+	headless   bool
+	script     string
+	transcript string
+	registry   string
+}
+
+func main() {
+	var (
+		cfg                      config
+		adjudicator, assetHolder addressFlag
+		appAddr, peer            addressFlag
+		stake                    float64
+		rows, cols, winLength    uint
+		rounds                   uint
+		deploy, local            bool
+		logFile                  string
+	)
+	flag.StringVar(&cfg.nodeURL, "node", "ws://127.0.0.1:8545", "URL of the blockchain node")
+	flag.Uint64Var(&cfg.chainID, "chain-id", 1337, "chain ID of the blockchain")
+	flag.StringVar(&cfg.key, "key", "", "hex-encoded private key of our Ethereum account")
+	flag.Var(&adjudicator, "adjudicator", "address of the adjudicator contract")
+	flag.Var(&assetHolder, "assetholder", "address of the ETH asset holder contract")
+	flag.Var(&appAddr, "app", "address of the tic-tac-toe app contract")
+	flag.BoolVar(&deploy, "deploy", false, "deploy the contracts with the account of -key, record them in -registry and exit")
+	flag.StringVar(&cfg.registry, "registry", registry.DefaultFile, "deployment registry to load the contracts from if their addresses are not given")
+	flag.BoolVar(&cfg.propose, "propose", false, "propose a game to -peer instead of waiting for a proposal")
+	flag.Var(&peer, "peer", "Ethereum address of the peer to propose the game to")
+	flag.Float64Var(&stake, "stake", 5, "stake in ETH; as proposee, only proposals with this stake are accepted")
+	flag.UintVar(&rows, "rows", uint(app.DefaultBoardConfig.Rows), "number of board rows")
+	flag.UintVar(&cols, "cols", uint(app.DefaultBoardConfig.Cols), "number of board columns")
+	flag.UintVar(&winLength, "win", uint(app.DefaultBoardConfig.WinLength), "number of marks in a line needed to win")
+	flag.UintVar(&rounds, "rounds", 1, "number of rounds, of which the majority wins the match")
+	flag.BoolVar(&cfg.headless, "headless", false, "read commands from -script or stdin and print the game as a log")
+	flag.StringVar(&cfg.script, "script", "", "file with commands for headless mode")
+	flag.BoolVar(&local, "local", false, "run two headless players with the scripts given as arguments in this process over a local bus")
+	flag.StringVar(&cfg.transcript, "transcript", "", "file to export the game transcript to after settling")
+	flag.StringVar(&logFile, "log", "play.log", `file for the client log, or "-" for stderr`)
+	flag.Parse()
+
+	var err error
+	if cfg.board, cfg.match, err = gameConfig(rows, cols, winLength, rounds); err != nil {
+		usagef("%v", err)
+	}
+	if !(stake > 0) || math.IsInf(stake, 0) {
+		usagef("invalid stake: %v", stake)
+	}
+	if local && flag.NArg() != 2 {
+		usagef("Local mode expects the scripts of both players as arguments.")
+	}
+	if err := setupLog(logFile); err != nil {
+		fatalf("Opening log: %v", err)
+	}
+	cfg.adjudicator, cfg.assetHolder, cfg.app, cfg.peer = common.Address(adjudicator), common.Address(assetHolder), common.Address(appAddr), common.Address(peer)
+	cfg.stake = client.EthToWei(big.NewFloat(stake))
+
+	switch {
+	case deploy:
+		err = runDeploy(cfg)
+	case local:
+		err = runLocal(cfg, flag.Arg(0), flag.Arg(1))
+	default:
+		err = runPlayer(cfg)
+	}
+	if err != nil {
+		fatalf("%v", err)
+	}
+}
+
+// gameConfig returns the board and the match given by the flags. A match of
+// one round is a single game, longer matches are best of the rounds.
+func gameConfig(rows, cols, winLength, rounds uint) (app.BoardConfig, app.MatchConfig, error) {
+	board, err := app.NewBoardConfig(rows, cols, winLength)
+	if err != nil {
+		return board, app.MatchConfig{}, errors.WithMessage(err, "invalid board")
+	}
+	mode := app.BestOf
+	if rounds == 1 {
+		mode = app.SingleGame.Mode
+	}
+	match, err := app.NewMatchConfig(mode, rounds)
+	return board, match, errors.WithMessage(err, "invalid match")
+}
+
+// runDeploy deploys the contracts, records them in the registry and prints
+// the flags for using them.
+func runDeploy(cfg config) error {
+	if cfg.key == "" {
+		return fmt.Errorf("missing -key")
+	}
+	c, err := deployment.DeployFile(cfg.nodeURL, cfg.chainID, cfg.key, cfg.registry)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("-adjudicator %v -assetholder %v -app %v\n", c.Adjudicator, c.AssetHolder, c.App)
+	return nil
+}
+
+// loadContracts sets the contract addresses that are not given by flags from
+// the registry.
+func loadContracts(cfg *config) error {
+	if cfg.adjudicator != (common.Address{}) && cfg.assetHolder != (common.Address{}) && cfg.app != (common.Address{}) {
+		return nil
+	}
+	c, err := deployment.LoadFile(cfg.nodeURL, cfg.chainID, cfg.registry)
+	if err != nil {
+		return errors.WithMessage(err, "loading contracts, deploy them with -deploy")
+	}
+	if cfg.adjudicator == (common.Address{}) {
+		cfg.adjudicator = c.Adjudicator
+	}
+	if cfg.assetHolder == (common.Address{}) {
+		cfg.assetHolder = c.AssetHolder
+	}
+	if cfg.app == (common.Address{}) {
+		cfg.app = c.App
+	}
+	return nil
+}
+
+// runPlayer plays a game with a peer in another process.
+func runPlayer(cfg config) error {
+	if cfg.key == "" {
+		return fmt.Errorf("missing -key")
+	}
+	if cfg.propose && cfg.peer == (common.Address{}) {
+		return fmt.Errorf("missing -peer")
+	}
+	if err := loadContracts(&cfg); err != nil {
+		return err
+	}
+
+	in := io.Reader(os.Stdin)
+	if cfg.headless && cfg.script != "" {
+		f, err := os.Open(cfg.script)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	return playNetwork(cfg, in, os.Stdout)
+}
+
+// runLocal deploys the contracts if they are not in the registry and lets
+// Alice propose a game to Bob over a local bus, both playing the moves of
+// their script.
+func runLocal(cfg config, aliceScript, bobScript string) error {
+	if cfg.adjudicator == (common.Address{}) {
+		fmt.Println("Deploying contracts.")
+		c, err := deployment.DeployFile(cfg.nodeURL, cfg.chainID, keyDeployer, cfg.registry)
+		if err != nil {
+			return err
+		}
+		cfg.adjudicator, cfg.assetHolder, cfg.app = c.Adjudicator, c.AssetHolder, c.App
+	}
+	cfg.headless = true
+
+	scripts := make([]io.Reader, 2)
+	for i, path := range []string{aliceScript, bobScript} {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		scripts[i] = f
+	}
+	return playLocal(cfg, [2]string{keyAlice, keyBob}, scripts, os.Stdout)
+}
+
+// setupLog redirects the log of the clients and the app, so that it does not
+// interfere with the game view.
+func setupLog(path string) error {
+	if path == "-" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	log.SetOutput(f)
+	return nil
+}
+
+// fatalf prints the error message to stderr and exits.
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+// usagef prints the error message and the usage to stderr and exits with the
+// exit code of the flag package for invalid flags.
+func usagef(format string, args ...interface{}) {
+	fmt.Fprintf(flag.CommandLine.Output(), format+"\n", args...)
+	flag.Usage()
+	os.Exit(2)
+}
+
+// addressFlag is a flag for an Ethereum address.
+type addressFlag common.Address
+
+func (a *addressFlag) String() string {
+	if a == nil || *a == (addressFlag{}) {
+		return ""
+	}
+	return common.Address(*a).Hex()
+}
+
+func (a *addressFlag) Set(s string) error {
+	if !common.IsHexAddress(s) {
+		return fmt.Errorf("invalid address: %s", s)
+	}
+	*a = addressFlag(common.HexToAddress(s))
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"perun.network/go-perun/channel"
+	"perun.network/perun-examples/app-channel/app"
+	"perun.network/perun-examples/app-channel/client"
+)
+
+// clearScreen moves the cursor to the top left and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// view is what the terminal shows of a game.
+type view struct {
+	state *channel.State
+	idx   channel.Index // Our participant index.
+	phase channel.Phase
+	msg   string // The result of the last command.
+}
+
+// render writes the view to w. In interactive mode, the terminal is cleared
+// first and the command prompt is shown.
+func (v view) render(w io.Writer, interactive bool) {
+	var b strings.Builder
+	if interactive {
+		b.WriteString(clearScreen)
+	}
+
+	d, ok := v.state.Data.(*app.TicTacToeAppData)
+	if !ok {
+		fmt.Fprintf(&b, "Unsupported game data: %T\n", v.state.Data)
+		io.WriteString(w, b.String()) //nolint:errcheck
+		return
+	}
+
+	fmt.Fprintf(&b, "Tic-tac-toe, version %d. You play %v.\n\n", v.state.Version, mark(v.idx))
+	renderBoard(&b, d)
+	fmt.Fprintf(&b, "\nRound %d/%d (%v), wins: you %d, peer %d\n", d.Round+1, d.Rounds, d.Mode, d.Wins[v.idx], d.Wins[1-v.idx])
+	fmt.Fprintln(&b, v.status(d))
+	fmt.Fprintln(&b, v.balances())
+	fmt.Fprintln(&b, v.dispute())
+
+	if v.msg != "" {
+		fmt.Fprintf(&b, "\n%s\n", v.msg)
+	}
+	if interactive {
+		b.WriteString("\nMove with \"<x> <y>\", or enter \"force <x> <y>\", \"settle\", \"help\" or \"quit\".\n> ")
+	}
+	io.WriteString(w, b.String()) //nolint:errcheck
+}
+
+// renderBoard draws the grid with column and row numbers.
+func renderBoard(b *strings.Builder, d *app.TicTacToeAppData) {
+	b.WriteString("   ")
+	for x := 0; x < int(d.Cols); x++ {
+		fmt.Fprintf(b, " %-3d", x)
+	}
+	b.WriteString("\n")
+	for y := 0; y < int(d.Rows); y++ {
+		if y > 0 {
+			b.WriteString("   " + strings.Repeat("---+", int(d.Cols)-1) + "---\n")
+		}
+		fmt.Fprintf(b, "%2d ", y)
+		for x := 0; x < int(d.Cols); x++ {
+			if x > 0 {
+				b.WriteString("|")
+			}
+			fmt.Fprintf(b, " %v ", d.Grid[y*int(d.Cols)+x])
+		}
+		b.WriteString("\n")
+	}
+}
+
+// status describes whose turn it is or how the game ended.
+func (v view) status(d *app.TicTacToeAppData) string {
+	if v.state.IsFinal {
+		bals := v.state.Balances[0]
+		switch bals[v.idx].Cmp(bals[1-v.idx]) {
+		case 1:
+			return "Game over: you won."
+		case -1:
+			return "Game over: you lost."
+		default:
+			return "Game over: draw."
+		}
+	}
+
+	if d.Forfeit {
+		if channel.Index(d.NextActor) == v.idx {
+			return "The peer claimed a forfeit: answer with \"force <x> <y>\" within the challenge duration."
+		}
+		return "Forfeit claimed: settle after the challenge duration unless the peer answers."
+	}
+
+	left := "deadline passed"
+	if d := time.Until(d.DeadlineTime()).Round(time.Second); d > 0 {
+		left = fmt.Sprintf("%v left", d)
+	}
+	if channel.Index(d.NextActor) == v.idx {
+		return fmt.Sprintf("Your turn, %s.", left)
+	}
+	return fmt.Sprintf("Peer's turn, %s.", left)
+}
+
+// balances describes the channel balances in ETH.
+func (v view) balances() string {
+	bals := v.state.Balances[0]
+	s := fmt.Sprintf("Balances: you %v ETH, peer %v ETH", client.WeiToEth(bals[v.idx]), client.WeiToEth(bals[1-v.idx]))
+	if len(bals) > int(app.HouseIdx) {
+		s += fmt.Sprintf(", house %v ETH", client.WeiToEth(bals[app.HouseIdx]))
+	}
+	return s
+}
+
+// dispute describes whether the channel is disputed on-chain.
+func (v view) dispute() string {
+	switch {
+	case disputed(v.phase):
+		return fmt.Sprintf("Channel: disputed on-chain (%v), moves are registered on-chain.", v.phase)
+	case v.phase >= channel.Withdrawing:
+		return fmt.Sprintf("Channel: settled (%v).", v.phase)
+	default:
+		return "Channel: off-chain, no dispute."
+	}
+}
+
+// disputed returns whether the channel is in a dispute phase, in which moves
+// can only be registered on-chain.
+func disputed(p channel.Phase) bool {
+	return p >= channel.Registering && p < channel.Withdrawing
+}
+
+// mark returns the mark of the player.
+func mark(idx channel.Index) string {
+	if idx == 0 {
+		return "x"
+	}
+	return "o"
+}
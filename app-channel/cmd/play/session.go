@@ -0,0 +1,250 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"perun.network/go-perun/channel"
+	"perun.network/perun-examples/app-channel/app"
+)
+
+const (
+	// pollInterval is the interval in which the state is polled for changes
+	// that are not signaled as updates, e.g., moves registered on-chain.
+	pollInterval = 500 * time.Millisecond
+	// defaultWaitTimeout is how long the wait command waits for our turn.
+	defaultWaitTimeout = 2 * time.Minute
+)
+
+const helpText = `Commands:
+  <x> <y>        mark column x, row y (registered on-chain during a dispute)
+  force <x> <y>  register the move on-chain, e.g., if the peer does not respond
+  wait [timeout] wait until it is our turn or the game is over
+  settle         settle the channel and withdraw the funds after the game
+  quit           leave without settling`
+
+// gameChannel is the part of a tic-tac-toe channel the session uses. It is
+// implemented by client.TicTacToeChannel.
+type gameChannel interface {
+	Idx() channel.Index
+	State() *channel.State
+	Phase() channel.Phase
+	OnUpdate(cb func(*channel.State))
+	TrySet(x, y int) error
+	TryForceSet(x, y int) error
+	TrySettle() error
+}
+
+// session lets a player play a game in a channel by commands, which are read
+// line by line. In interactive mode, the view is redrawn after each change
+// and failed commands are reported. In headless mode, the view is printed
+// after each change and a failed command ends the session.
+type session struct {
+	ch          gameChannel
+	out         io.Writer
+	interactive bool
+	updates     chan struct{} // Signals off-chain updates.
+
+	msg       string         // The result of the last command.
+	lastState *channel.State // The state last drawn.
+	lastPhase channel.Phase  // The phase last drawn.
+}
+
+// newSession creates a session for the channel that writes to out.
+func newSession(ch gameChannel, out io.Writer, interactive bool) *session {
+	s := &session{
+		ch:          ch,
+		out:         out,
+		interactive: interactive,
+		updates:     make(chan struct{}, 1),
+	}
+	ch.OnUpdate(func(*channel.State) {
+		select {
+		case s.updates <- struct{}{}:
+		default: // A redraw is already pending.
+		}
+	})
+	return s
+}
+
+// run executes the commands read from in until the channel is settled, the
+// player quits or in is exhausted.
+func (s *session) run(in io.Reader) error {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	s.draw(true)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return nil
+			}
+			done, err := s.exec(line)
+			if err != nil {
+				if !s.interactive {
+					return errors.WithMessagef(err, "command %q", line)
+				}
+				s.msg = "Error: " + err.Error()
+			}
+			if !s.interactive && s.msg != "" {
+				fmt.Fprintln(s.out, s.msg)
+			}
+			s.draw(s.interactive)
+			if done {
+				return nil
+			}
+		case <-s.updates:
+			s.draw(false)
+		case <-ticker.C:
+			s.draw(false)
+		}
+	}
+}
+
+// exec executes a command and returns whether the session is over.
+func (s *session) exec(line string) (done bool, err error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return false, nil
+	}
+	if !s.interactive {
+		fmt.Fprintf(s.out, "> %s\n", line)
+	}
+
+	s.msg = ""
+	switch fields[0] {
+	case "help":
+		s.msg = helpText
+	case "quit", "exit":
+		s.msg = "Left the game without settling."
+		return true, nil
+	case "settle":
+		if err := s.ch.TrySettle(); err != nil {
+			return false, errors.WithMessage(err, "settling")
+		}
+		s.msg = "Channel settled."
+		return true, nil
+	case "force":
+		x, y, err := parseField(fields[1:])
+		if err != nil {
+			return false, err
+		}
+		if err := s.ch.TryForceSet(x, y); err != nil {
+			return false, errors.WithMessage(err, "registering move")
+		}
+		s.msg = fmt.Sprintf("Registered move (%d, %d) on-chain.", x, y)
+	case "wait":
+		timeout := defaultWaitTimeout
+		if len(fields) > 1 {
+			if timeout, err = time.ParseDuration(fields[1]); err != nil {
+				return false, errors.WithMessage(err, "parsing timeout")
+			}
+		}
+		return false, s.wait(timeout)
+	default:
+		x, y, err := parseField(fields)
+		if err != nil {
+			return false, fmt.Errorf("unknown command %q, enter \"help\" for a list of commands", line)
+		}
+		return false, s.move(x, y)
+	}
+	return false, nil
+}
+
+// move marks the field off-chain or, if the channel is disputed, on-chain.
+func (s *session) move(x, y int) error {
+	if disputed(s.ch.Phase()) {
+		if err := s.ch.TryForceSet(x, y); err != nil {
+			return errors.WithMessage(err, "registering move")
+		}
+		s.msg = fmt.Sprintf("Registered move (%d, %d) on-chain.", x, y)
+		return nil
+	}
+	if err := s.ch.TrySet(x, y); err != nil {
+		return err
+	}
+	s.msg = fmt.Sprintf("Marked (%d, %d).", x, y)
+	return nil
+}
+
+// wait waits until it is our turn or the game is over.
+func (s *session) wait(timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for {
+		state := s.ch.State()
+		d, ok := state.Data.(*app.TicTacToeAppData)
+		if !ok {
+			return fmt.Errorf("invalid data type: %T", state.Data)
+		}
+		if state.IsFinal || channel.Index(d.NextActor) == s.ch.Idx() {
+			return nil
+		}
+
+		select {
+		case <-s.updates:
+		case <-time.After(pollInterval):
+		case <-deadline:
+			return fmt.Errorf("peer did not move within %v", timeout)
+		}
+		s.draw(false)
+	}
+}
+
+// draw shows the current state if it changed since it was last drawn or if
+// force is set.
+func (s *session) draw(force bool) {
+	state, phase := s.ch.State(), s.ch.Phase()
+	if !force && s.lastState != nil && phase == s.lastPhase && state.Equal(s.lastState) == nil {
+		return
+	}
+	s.lastState, s.lastPhase = state, phase
+
+	v := view{state: state, idx: s.ch.Idx(), phase: phase}
+	if s.interactive {
+		v.msg = s.msg // Headless mode prints messages right away.
+	}
+	v.render(s.out, s.interactive)
+}
+
+// parseField parses the column and row of a field.
+func parseField(args []string) (x, y int, err error) {
+	if len(args) != 2 {
+		return 0, 0, fmt.Errorf("expected column and row, got %q", strings.Join(args, " "))
+	}
+	if x, err = strconv.Atoi(args[0]); err != nil {
+		return 0, 0, errors.WithMessage(err, "parsing column")
+	}
+	if y, err = strconv.Atoi(args[1]); err != nil {
+		return 0, 0, errors.WithMessage(err, "parsing row")
+	}
+	return x, y, nil
+}
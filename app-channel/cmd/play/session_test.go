@@ -0,0 +1,190 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+	"perun.network/perun-examples/app-channel/app"
+	"perun.network/perun-examples/app-channel/differential"
+)
+
+func TestSessionScript(t *testing.T) {
+	ch := newFakeChannel(t, 0, 0)
+	var out strings.Builder
+	err := newSession(ch, &out, false).run(strings.NewReader("# Alice opens.\n0 0\nhelp\nsettle\n"))
+	require.NoError(t, err)
+
+	require.True(t, ch.settled)
+	require.Equal(t, uint64(1), ch.State().Version)
+	require.Contains(t, out.String(), "> 0 0\nMarked (0, 0).")
+	require.Contains(t, out.String(), " 0  x |   |   ")
+	require.Contains(t, out.String(), "Peer's turn")
+	require.Contains(t, out.String(), "Channel settled.")
+}
+
+func TestGameConfig(t *testing.T) {
+	board, match, err := gameConfig(4, 5, 3, 1)
+	require.NoError(t, err)
+	require.Equal(t, app.BoardConfig{Rows: 4, Cols: 5, WinLength: 3}, board)
+	require.Equal(t, app.SingleGame, match)
+
+	for _, v := range [][4]uint{{256, 3, 3, 1}, {3, 259, 3, 1}, {3, 3, 259, 1}, {3, 3, 3, 257}, {3, 3, 4, 1}, {3, 3, 3, 0}} {
+		_, _, err := gameConfig(v[0], v[1], v[2], v[3])
+		require.Error(t, err, v)
+	}
+}
+
+func TestSessionInvalidCommand(t *testing.T) {
+	for script, msg := range map[string]string{
+		"3 0":      "not on the 3x3 board",
+		"0 0\n1 0": "not the turn",
+		"force 1":  "expected column and row",
+		"jump":     "unknown command",
+	} {
+		ch := newFakeChannel(t, 0, 0)
+		err := newSession(ch, new(strings.Builder), false).run(strings.NewReader(script))
+		require.ErrorContains(t, err, msg, script)
+	}
+}
+
+func TestSessionWait(t *testing.T) {
+	ch := newFakeChannel(t, 1, 0)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ch.peerMove(0, 0)
+	}()
+
+	var out strings.Builder
+	err := newSession(ch, &out, false).run(strings.NewReader("wait 5s\n1 1\n"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), ch.State().Version)
+	require.Contains(t, out.String(), "Your turn")
+}
+
+func TestSessionDispute(t *testing.T) {
+	ch := newFakeChannel(t, 0, 0)
+	ch.phase = channel.Registered
+
+	var out strings.Builder
+	err := newSession(ch, &out, false).run(strings.NewReader("0 0\n"))
+	require.NoError(t, err)
+	require.Equal(t, 1, ch.forced)
+	require.Contains(t, out.String(), "disputed on-chain (Registered)")
+}
+
+// fakeChannel is a tic-tac-toe channel that applies moves locally.
+type fakeChannel struct {
+	mtx      sync.Mutex
+	app      *app.TicTacToeApp
+	idx      channel.Index
+	state    *channel.State
+	phase    channel.Phase
+	forced   int
+	settled  bool
+	onUpdate func(*channel.State)
+}
+
+func newFakeChannel(t *testing.T, idx, firstActor channel.Index) *fakeChannel {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	a := app.NewTicTacToeApp(ethwallet.AsWalletAddr(common.Address{1}))
+	params := differential.NewParams(rng, a, 60, 2)
+	stake := big.NewInt(5)
+	data := a.InitData(firstActor, app.DefaultBoardConfig, app.SingleGame, []channel.Bal{stake}, app.WinnerTakesAllPayout)
+	state := differential.NewState(rng, params, data, []channel.Bal{stake, new(big.Int).Set(stake)})
+	return &fakeChannel{app: a, idx: idx, state: state, phase: channel.Acting}
+}
+
+func (c *fakeChannel) Idx() channel.Index { return c.idx }
+
+func (c *fakeChannel) State() *channel.State {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.state.Clone()
+}
+
+func (c *fakeChannel) Phase() channel.Phase {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.phase
+}
+
+func (c *fakeChannel) OnUpdate(cb func(*channel.State)) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.onUpdate = cb
+}
+
+func (c *fakeChannel) TrySet(x, y int) error {
+	return c.set(x, y, c.idx)
+}
+
+func (c *fakeChannel) TryForceSet(x, y int) error {
+	if err := c.set(x, y, c.idx); err != nil {
+		return err
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.forced++
+	return nil
+}
+
+func (c *fakeChannel) TrySettle() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.settled, c.phase = true, channel.Withdrawn
+	return nil
+}
+
+// peerMove lets the peer mark a field and signals the update.
+func (c *fakeChannel) peerMove(x, y int) {
+	if err := c.set(x, y, 1-c.idx); err != nil {
+		panic(err)
+	}
+	c.mtx.Lock()
+	cb, s := c.onUpdate, c.state.Clone()
+	c.mtx.Unlock()
+	cb(s)
+}
+
+func (c *fakeChannel) set(x, y int, actor channel.Index) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.state.IsFinal {
+		return fmt.Errorf("game over")
+	}
+	if err := c.state.Data.(*app.TicTacToeAppData).CheckMove(x, y, actor); err != nil { //nolint:forcetypeassert
+		return err
+	}
+	next := c.state.Clone()
+	next.Version++
+	if err := c.app.Set(next, x, y, actor); err != nil {
+		return err
+	}
+	c.state = next
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	swallet "github.com/perun-network/perun-eth-backend/wallet/simple"
+	"github.com/pkg/errors"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+	"perun.network/go-perun/wire/net"
+	p2p "perun.network/go-perun/wire/net/libp2p"
+	perunio "perun.network/go-perun/wire/perunio/serializer"
+	"perun.network/perun-examples/app-channel/app"
+	"perun.network/perun-examples/app-channel/client"
+)
+
+// peerTimeout is how long the proposer waits for the peer to come online.
+const peerTimeout = 2 * time.Minute
+
+// playNetwork plays a game with a peer in another process. The players find
+// each other by their Ethereum addresses, which they register with the relay
+// server of the libp2p network.
+func playNetwork(cfg config, in io.Reader, out io.Writer) error {
+	k, err := crypto.HexToECDSA(cfg.key)
+	if err != nil {
+		return errors.WithMessage(err, "parsing private key")
+	}
+	ethAddr := crypto.PubkeyToAddress(k.PublicKey)
+
+	wireAcc := p2p.NewRandomAccount(rand.New(rand.NewSource(time.Now().UnixNano())))
+	if err := wireAcc.RegisterOnChainAddress(ethwallet.AsWalletAddr(ethAddr)); err != nil {
+		return errors.WithMessage(err, "registering with relay server")
+	}
+	bus, dialer := setupBus(wireAcc)
+
+	c, g, err := setupClient(bus, wireAcc.Address(), cfg.key, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Shutdown()
+	fmt.Fprintf(out, "Playing as %v.\n", ethAddr)
+
+	var peer map[wallet.BackendID]wire.Address
+	if cfg.propose {
+		fmt.Fprintf(out, "Looking up peer %v.\n", cfg.peer)
+		if peer, err = resolvePeer(wireAcc, dialer, cfg.peer); err != nil {
+			return errors.WithMessage(err, "looking up peer")
+		}
+	}
+	return play(c, g, cfg, peer, in, out)
+}
+
+// playLocal lets Alice propose a game to Bob over a local bus. Each player
+// reads the commands from their script and writes to out with a prefix.
+func playLocal(cfg config, keys [2]string, scripts []io.Reader, out io.Writer) error {
+	bus := wire.NewLocalBus()
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	names := []string{"alice", "bob"}
+
+	clients := make([]*client.AppClient, len(names))
+	games := make([]*client.TicTacToeGame, len(names))
+	for i := range names {
+		var err error
+		clients[i], games[i], err = setupClient(bus, p2p.NewRandomAddress(rng), keys[i], cfg)
+		if err != nil {
+			return errors.WithMessagef(err, "setting up %s", names[i])
+		}
+		defer clients[i].Shutdown()
+	}
+
+	var mtx sync.Mutex
+	errs := make(chan error, len(names))
+	for i, name := range names {
+		cfg, peer := cfg, clients[1].WireAddress()
+		if i > 0 {
+			cfg.transcript, peer = "", nil // Bob accepts and Alice exports the transcript.
+		}
+		w := &prefixWriter{mtx: &mtx, w: out, prefix: fmt.Sprintf("%-5s| ", name)}
+		go func(i int) {
+			errs <- errors.WithMessage(play(clients[i], games[i], cfg, peer, scripts[i], w), name)
+		}(i)
+	}
+
+	var err error
+	for range names {
+		if e := <-errs; e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// play proposes a game to the peer or, if peer is nil, accepts the next
+// proposal, and plays it by the commands read from in.
+func play(c *client.AppClient, g *client.TicTacToeGame, cfg config, peer map[wallet.BackendID]wire.Address, in io.Reader, out io.Writer) error {
+	var ch *client.TicTacToeChannel
+	if peer != nil {
+		fmt.Fprintf(out, "Proposing a %dx%d game for %v ETH.\n", cfg.board.Rows, cfg.board.Cols, client.WeiToEth(cfg.stake))
+		ch = c.OpenTicTacToeChannel(peer, g, cfg.board, cfg.match, app.WinnerTakesAllPayout)
+	} else {
+		fmt.Fprintf(out, "Waiting for a proposal for %v ETH.\n", client.WeiToEth(cfg.stake))
+		var ok bool
+		if ch, ok = c.AcceptedChannel().(*client.TicTacToeChannel); !ok {
+			return fmt.Errorf("accepted channel is not a tic-tac-toe channel")
+		}
+	}
+
+	if err := newSession(ch, out, !cfg.headless).run(in); err != nil {
+		return err
+	}
+	if cfg.transcript != "" {
+		if err := c.ExportTranscript(ch, cfg.transcript); err != nil {
+			return errors.WithMessage(err, "exporting transcript")
+		}
+		fmt.Fprintf(out, "Transcript exported to %s.\n", cfg.transcript)
+	}
+	return nil
+}
+
+// setupClient sets up a client for the account of the given private key that
+// plays tic-tac-toe with the configured stake.
+func setupClient(bus wire.Bus, wireAddr wire.Address, key string, cfg config) (*client.AppClient, *client.TicTacToeGame, error) {
+	k, err := crypto.HexToECDSA(key)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "parsing private key")
+	}
+	w := swallet.NewWallet(k)
+	acc := crypto.PubkeyToAddress(k.PublicKey)
+
+	g := client.NewTicTacToeGame(app.NewTicTacToeApp(ethwallet.AsWalletAddr(cfg.app)), cfg.stake, app.DefaultBoardLimits)
+	c, err := client.SetupAppClient(
+		bus,
+		w,
+		acc,
+		wireAddr,
+		ethwallet.AsWalletAddr(acc),
+		cfg.nodeURL,
+		cfg.chainID,
+		cfg.adjudicator,
+		*ethwallet.AsWalletAddr(cfg.assetHolder),
+		g,
+	)
+	return c, g, err
+}
+
+// setupBus sets up a wire.Bus for the given libp2p account.
+func setupBus(acc *p2p.Account) (wire.Bus, *p2p.Dialer) {
+	id := map[wallet.BackendID]wire.Account{ethwallet.BackendID: acc}
+	listener := p2p.NewP2PListener(acc)
+	dialer := p2p.NewP2PDialer(acc)
+
+	bus := net.NewBus(id, dialer, perunio.Serializer())
+	go bus.Listen(listener)
+	return bus, dialer
+}
+
+// resolvePeer looks up the wire address of the peer with the given Ethereum
+// address at the relay server and registers it with the dialer. It retries
+// until the peer is online or peerTimeout passes.
+func resolvePeer(acc *p2p.Account, dialer *p2p.Dialer, peer common.Address) (map[wallet.BackendID]wire.Address, error) {
+	deadline := time.Now().Add(peerTimeout)
+	for {
+		addr, err := acc.QueryOnChainAddress(ethwallet.AsWalletAddr(peer))
+		if err == nil {
+			wireAddr := map[wallet.BackendID]wire.Address{ethwallet.BackendID: addr}
+			dialer.Register(wireAddr, addr.ID.String())
+			return wireAddr, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, err
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// prefixWriter prefixes each line with the name of a player. Writers sharing
+// the mutex do not interleave their writes.
+type prefixWriter struct {
+	mtx    *sync.Mutex
+	w      io.Writer
+	prefix string
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(string(p), "\n") {
+		if line != "" {
+			b.WriteString(w.prefix + line)
+		}
+	}
+	if _, err := io.WriteString(w.w, b.String()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
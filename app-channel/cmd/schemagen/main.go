@@ -0,0 +1,48 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command schemagen generates the Solidity layout contracts of the app data
+// schemas, which the app contracts inherit.
+//
+// Usage:
+//
+//	go run ./cmd/schemagen [-out contracts]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"perun.network/perun-examples/app-channel/app"
+)
+
+func main() {
+	out := flag.String("out", "contracts", "directory of the generated contracts")
+	flag.Parse()
+
+	for _, s := range app.Schemas {
+		src, err := s.Solidity()
+		if err != nil {
+			log.Fatalf("Generating %s: %v", s.ContractName(), err)
+		}
+		path := filepath.Join(*out, s.ContractName()+".sol")
+		if err := os.WriteFile(path, []byte(src), 0o644); err != nil { //nolint:gosec
+			log.Fatalf("Writing %s: %v", path, err)
+		}
+		fmt.Printf("Generated %s.\n", path)
+	}
+}
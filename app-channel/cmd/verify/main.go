@@ -0,0 +1,77 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command verify verifies an exported game transcript and replays the game.
+//
+// Usage:
+//
+//	go run ./cmd/verify [-delay 1s] transcript.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"perun.network/go-perun/channel"
+	"perun.network/perun-examples/app-channel/transcript"
+)
+
+func main() {
+	delay := flag.Duration("delay", 0, "pause between replayed states")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-delay duration] transcript.json\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	t, err := transcript.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Reading transcript: %v", err)
+	}
+
+	steps, err := t.Verify()
+	if err == nil || len(steps) > 0 {
+		fmt.Printf("Channel %x\n\n", t.Params.ID())
+		printState(t.Txs[0].State, "initial state, signed by all")
+	}
+	for _, s := range steps {
+		time.Sleep(*delay)
+		signed := fmt.Sprintf("signed by %v", s.Signers)
+		if s.Progressed {
+			signed += ", progressed on-chain"
+		}
+		printState(s.To, fmt.Sprintf("move by participant %d, %s", s.Actor, signed))
+	}
+	if err != nil {
+		log.Fatalf("Invalid transcript: %v", err)
+	}
+	fmt.Printf("Transcript valid: %d states.\n", len(t.Txs))
+}
+
+func printState(s *channel.State, desc string) {
+	fmt.Printf("Version %d: %s\n", s.Version, desc)
+	fmt.Print(s.Data) // The app data prints itself line by line.
+	fmt.Printf("Balances: %v\n", s.Balances)
+	if s.IsFinal {
+		fmt.Println("Final.")
+	}
+	fmt.Println()
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 - See NOTICE file for copyright holders.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// SPDX-License-Identifier: Apache-2.0
+
+pragma solidity ^0.8.15;
+pragma abicoder v2;
+
+import "./perun-eth-contracts/contracts/App.sol";
+import "./RockPaperScissorsAppSchema.sol";
+
+/**
+ * @notice RockPaperScissorsApp is a channel app for playing rock paper scissors
+ * with hidden moves. Both players first commit to
+ * keccak256(abi.encodePacked(uint8 choice, bytes32 salt)) and then reveal
+ * their choice and salt. While a player owes a reveal, the state assigns the
+ * whole pot to the opponent, so refusing to reveal forfeits the stake once the
+ * channel is concluded.
+ * The data layout is inherited from RockPaperScissorsAppSchema, which is
+ * generated from app.RockPaperScissorsSchema. Data of another layout version
+ * is rejected.
+ */
+contract RockPaperScissorsApp is App, RockPaperScissorsAppSchema {
+    uint8 constant numParts = 2;
+    uint8 constant phaseCommit = 0;
+    uint8 constant phaseReveal = 1;
+    uint8 constant phaseDone = 2;
+    uint8 constant noChoice = 0;
+    uint8 constant maxChoice = 3;
+
+    /**
+     * @notice ValidTransition checks if there was a valid transition between two states.
+     * @param params The parameters of the channel.
+     * @param from The current state.
+     * @param to The potential next state.
+     * @param signerIdx Index of the participant who signed this transition.
+     */
+    function validTransition(
+        Channel.Params calldata params,
+        Channel.State calldata from,
+        Channel.State calldata to,
+        uint256 signerIdx)
+    external pure override
+    {
+        require(params.participants.length == numParts, "number of participants");
+        require(from.appData.length == appDataLength, "data length");
+        require(to.appData.length == appDataLength, "data length");
+        requireDataVersion(from.appData);
+        requireDataVersion(to.appData);
+
+        uint8 actorIndex = uint8(from.appData[actorDataIndex]);
+        require(actorIndex == signerIdx, "actor not signer");
+        uint8 nextActor = (actorIndex + 1) % numParts;
+        require(uint8(to.appData[actorDataIndex]) == nextActor, "next actor");
+
+        // Test valid action.
+        uint8 phase = uint8(from.appData[phaseDataIndex]);
+        uint8 nextPhase = phase;
+        if (phase == phaseCommit) {
+            require(readBytes32(to.appData, commitmentDataIndex + commitmentDataLength * actorIndex) != bytes32(0), "empty commitment");
+            requireUnchangedExcept(from.appData, to.appData, commitmentDataIndex + commitmentDataLength * actorIndex, commitmentDataLength, 0, 0);
+        } else if (phase == phaseReveal) {
+            uint8 choice = uint8(to.appData[choiceDataIndex + actorIndex]);
+            require(choice != noChoice && choice <= maxChoice, "choice");
+            bytes32 salt = readBytes32(to.appData, saltDataIndex + saltDataLength * actorIndex);
+            require(keccak256(abi.encodePacked(choice, salt)) == readBytes32(from.appData, commitmentDataIndex + commitmentDataLength * actorIndex), "commitment");
+            requireUnchangedExcept(from.appData, to.appData, choiceDataIndex + actorIndex, 1, saltDataIndex + saltDataLength * actorIndex, saltDataLength);
+        } else {
+            revert("phase");
+        }
+        if (nextActor == 0) {
+            nextPhase = phase + 1;
+        }
+        require(uint8(to.appData[phaseDataIndex]) == nextPhase, "next phase");
+
+        // Test final state.
+        require(to.isFinal == (nextPhase == phaseDone), "final flag");
+        Channel.requireEqualAssetArray(to.outcome.assets, from.outcome.assets);
+        Channel.requireEqualSubAllocArray(to.outcome.locked, from.outcome.locked);
+        uint256[][] memory expectedBalances = from.outcome.balances;
+        if (nextPhase == phaseReveal) {
+            // The next actor owes a reveal, so the pot goes to the other player.
+            expectedBalances = winnerTakesAll(from.outcome.balances, (nextActor + 1) % numParts);
+        } else if (nextPhase == phaseDone) {
+            (bool hasWinner, uint8 winner) = checkWinner(to.appData);
+            if (hasWinner) {
+                expectedBalances = winnerTakesAll(from.outcome.balances, winner);
+            } else {
+                expectedBalances = split(from.outcome.balances);
+            }
+        }
+        requireEqualUint256ArrayArray(to.outcome.balances, expectedBalances);
+    }
+
+    function checkWinner(bytes memory d) internal pure returns (bool hasWinner, uint8 winner) {
+        uint8 a = uint8(d[choiceDataIndex]);
+        uint8 b = uint8(d[choiceDataIndex + 1]);
+        if ((a + 3 - b) % 3 == 1) {
+            return (true, 0);
+        } else if ((b + 3 - a) % 3 == 1) {
+            return (true, 1);
+        }
+        return (false, 0);
+    }
+
+    function winnerTakesAll(uint256[][] memory balances, uint8 winner) internal pure returns (uint256[][] memory result) {
+        uint8 loser = 1 - winner;
+        result = new uint256[][](balances.length);
+        for (uint i = 0; i < balances.length; i++) {
+            result[i] = new uint256[](numParts);
+            result[i][winner] = balances[i][0] + balances[i][1];
+            result[i][loser] = 0;
+        }
+    }
+
+    function split(uint256[][] memory balances) internal pure returns (uint256[][] memory result) {
+        result = new uint256[][](balances.length);
+        for (uint i = 0; i < balances.length; i++) {
+            uint256 pot = balances[i][0] + balances[i][1];
+            result[i] = new uint256[](numParts);
+            result[i][0] = pot / 2;
+            result[i][1] = pot - pot / 2;
+        }
+    }
+
+    function readBytes32(bytes memory d, uint256 offset) internal pure returns (bytes32 v) {
+        require(d.length >= offset + 32, "out of bounds");
+        assembly {
+            v := mload(add(add(d, 32), offset))
+        }
+    }
+
+    /// @dev Asserts that the game fields of a and b only differ in the ranges [o1, o1+l1) and [o2, o2+l2).
+    function requireUnchangedExcept(bytes memory a, bytes memory b, uint256 o1, uint256 l1, uint256 o2, uint256 l2) internal pure {
+        for (uint i = commitmentDataIndex; i < a.length; i++) {
+            bool inFirst = i >= o1 && i < o1 + l1;
+            bool inSecond = i >= o2 && i < o2 + l2;
+            if (!inFirst && !inSecond) {
+                require(a[i] == b[i], "unexpected change");
+            }
+        }
+    }
+
+    function requireEqualUint256ArrayArray(
+        uint256[][] memory a,
+        uint256[][] memory b
+    )
+    internal pure
+    {
+        require(a.length == b.length, "uint256[][]: unequal length");
+        for (uint i = 0; i < a.length; i++) {
+            Array.requireEqualUint256Array(a[i], b[i]);
+        }
+    }
+}
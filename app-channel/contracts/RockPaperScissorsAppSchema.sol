@@ -0,0 +1,47 @@
+// Copyright 2025 - See NOTICE file for copyright holders.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by schemagen. DO NOT EDIT.
+
+pragma solidity ^0.8.15;
+
+/**
+ * @notice RockPaperScissorsAppSchema describes the data layout of RockPaperScissorsApp, version 1.
+ * Multi-byte integers are big-endian. The data is encoded as follows:
+ * - data[0]: The data version.
+ * - data[1]: The phase (0 = commit, 1 = reveal, 2 = done).
+ * - data[2]: The index of the next actor.
+ * - data[3:67]: The commitments of player 1 and 2.
+ * - data[67:69]: The choices of player 1 and 2. 0 means not revealed, 1 rock, 2 paper, 3 scissors.
+ * - data[69:133]: The salts of player 1 and 2.
+ */
+abstract contract RockPaperScissorsAppSchema {
+    uint8 constant dataVersion = 1;
+    uint constant versionDataIndex = 0;
+    uint constant phaseDataIndex = 1;
+    uint constant actorDataIndex = 2;
+    uint constant commitmentDataIndex = 3;
+    uint constant commitmentDataLength = 32;
+    uint constant choiceDataIndex = 67;
+    uint constant saltDataIndex = 69;
+    uint constant saltDataLength = 32;
+    uint constant appDataLength = 133;
+
+    /// @dev requireDataVersion reverts if the data is not of version dataVersion.
+    function requireDataVersion(bytes memory d) internal pure {
+        require(d.length > versionDataIndex && uint8(d[versionDataIndex]) == dataVersion, "data version");
+    }
+}
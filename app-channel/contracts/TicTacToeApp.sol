@@ -18,23 +18,42 @@ pragma solidity ^0.8.15;
 pragma abicoder v2;
 
 import "./perun-eth-contracts/contracts/App.sol";
+import "./TicTacToeAppSchema.sol";
 
 /**
- * @notice TicTacToeApp is a channel app for playing tic tac toe.
- * The data is encoded as follows:
- * - data[0]: The index of the next actor.
- * - data[i], i in [1,10]: The value of field i. 0 means no tick, 1 means tick by player 1, 2 means tick by player 2.
+ * @notice TicTacToeApp is a channel app for playing tic tac toe and its
+ * generalization, the m,n,k-game: players take turns on a board with m rows
+ * and n columns and the first player with k marks in a line wins.
+ * A channel hosts a match of several rounds. After each round, the loser pays
+ * the round stake to the winner, the board is reset and the other player opens
+ * the next round. The channel becomes final when the match is over.
+ * The data layout is inherited from TicTacToeAppSchema, which is generated
+ * from app.TicTacToeSchema. Data of another layout version is rejected.
+ * A drawn round pays nothing. For a won round, the loser pays the round stake
+ * (winner takes all), the prize (fixed prize) or the share of the round stake
+ * (partial stake) to the winner. With a house rake, the loser pays the round
+ * stake, of which the house, the third participant, receives the share.
+ * If the next actor misses the deadline, the other player may claim a forfeit:
+ * the claim only sets the forfeit flag and pays the signer the current round
+ * and every further round the match would last if the signer won them all,
+ * each paid out like a won round. The claim is not final, so the next actor
+ * can answer it with a move, which reverts the payout of the forfeit.
+ * validTransition cannot read the time, so the deadline is not enforced
+ * on-chain and a claim is valid at any time. On-chain, the claim progresses
+ * the channel, after which the next actor has one challenge duration to answer
+ * before the channel can be concluded with the claimed state.
  */
-contract TicTacToeApp is App {
-    uint8 constant actorDataIndex = 0;
-    uint8 constant actorDataLength = 1;
-    uint8 constant gridDataIndex = actorDataIndex + actorDataLength;
-    uint8 constant gridDataLength = 9;
-    uint8 constant appDataLength = gridDataIndex + gridDataLength; // Actor index + grid.
+contract TicTacToeApp is App, TicTacToeAppSchema {
     uint8 constant numParts = 2;
     uint8 constant notSet = 0;
     uint8 constant firstPlayer = 1;
     uint8 constant secondPlayer = 2;
+    uint8 constant modeBestOf = 1;
+    uint8 constant fixedPrize = 1;
+    uint8 constant partialStake = 2;
+    uint8 constant houseRake = 3;
+    uint8 constant houseIndex = numParts;
+    uint constant maxShare = 10000;
 
     /**
      * @notice ValidTransition checks if there was a valid transition between two states.
@@ -50,95 +69,268 @@ contract TicTacToeApp is App {
         uint256 signerIdx)
     external pure override
     {
-        require(params.participants.length == numParts, "number of participants");
-
-        uint8 actorIndex = uint8(from.appData[actorDataIndex]);
-        require(to.appData.length == appDataLength, "data length");
-        require(actorIndex == signerIdx, "actor not signer");
-        require((actorIndex + 1) % numParts == uint8(to.appData[actorDataIndex]), "next actor");
+        bytes memory fromData = from.appData;
+        requireDataVersion(fromData);
+        requireDataVersion(to.appData);
+        uint grid = gridDataIndex(fromData);
+        require(params.participants.length == numParticipants(fromData), "number of participants");
+        require(signerIdx < numParts, "house must not act");
+        require(fromData.length == grid + numFields(fromData), "data length");
+        require(to.appData.length == fromData.length, "data length");
+        uint8 forfeit = uint8(fromData[forfeitDataIndex]);
+        require(forfeit <= 1, "forfeit flag");
+        uint8 actorIndex = uint8(fromData[actorDataIndex]);
+        if (actorIndex != signerIdx) {
+            requireValidForfeit(from, to, fromData, uint8(signerIdx));
+            return;
+        }
+        require(readUint(to.appData, deadlineDataIndex, deadlineDataLength) >= readUint(fromData, deadlineDataIndex, deadlineDataLength), "deadline");
 
         // Test valid action.
-        bool changed = false;
-        for (uint i = gridDataIndex; i < gridDataIndex + gridDataLength; i++) {
-            require(uint8(to.appData[i]) <= 2, "grid value");
-            if (to.appData[i] != from.appData[i]) {
-                require(uint8(from.appData[i]) == notSet, "overwrite");
-                require(!changed, "two actions");
-                changed = true;
-            }
+        uint lastMove = readUint(to.appData, lastMoveDataIndex, lastMoveDataLength);
+        require(lastMove > 0 && lastMove <= numFields(fromData), "field");
+        uint field = lastMove - 1;
+        require(uint8(fromData[grid + field]) == notSet, "overwrite");
+
+        // Compute the expected data by applying the move, which also answers
+        // a claimed forfeit.
+        bytes memory expected = from.appData;
+        expected[forfeitDataIndex] = 0;
+        (bool hasWinner, uint8 winner, bool matchOver) = play(expected, grid, field, actorIndex);
+        for (uint i = deadlineDataIndex; i < deadlineDataIndex + deadlineDataLength; i++) {
+            expected[i] = to.appData[i];
         }
+        require(keccak256(expected) == keccak256(to.appData), "data");
 
         // Test final state.
-        (bool isFinal, bool hasWinner, uint8 winner) = checkFinal(to.appData);
-        require(to.isFinal == isFinal, "final flag");
+        require(to.isFinal == matchOver, "final flag");
         Channel.requireEqualAssetArray(to.outcome.assets, from.outcome.assets);
         Channel.requireEqualSubAllocArray(to.outcome.locked, from.outcome.locked);
         uint256[][] memory expectedBalances = from.outcome.balances;
+        if (forfeit == 1) {
+            expectedBalances = revertForfeitBalances(expectedBalances, fromData, (actorIndex + 1) % numParts);
+        }
         if (hasWinner) {
-            uint8 loser = 1 - winner;
-            expectedBalances = new uint256[][](expectedBalances.length);
-            for (uint i = 0; i < expectedBalances.length; i++) {
-                expectedBalances[i] = new uint256[](numParts);
-                expectedBalances[i][winner] = from.outcome.balances[i][0] + from.outcome.balances[i][1];
-                expectedBalances[i][loser] = 0;
-            }
+            expectedBalances = roundBalances(expectedBalances, fromData, winner);
         }
         requireEqualUint256ArrayArray(to.outcome.balances, expectedBalances);
     }
 
-    /// @dev Asserts that a and b are equal.
-    function requireEqualAddressArray(
-        address[] memory a,
-        address[] memory b
-    )
-    internal
-    pure
+    /**
+     * @dev play sets the mark of the actor on the given field. If this ends
+     * the round, the result is recorded and the next round is started.
+     */
+    function play(bytes memory d, uint grid, uint field, uint8 actorIndex) internal pure returns (bool hasWinner, uint8 winner, bool matchOver) {
+        d[grid + field] = bytes1(actorIndex + firstPlayer);
+        d[lastMoveDataIndex] = bytes1(uint8((field + 1) >> 8));
+        d[lastMoveDataIndex + 1] = bytes1(uint8(field + 1));
+        d[actorDataIndex] = bytes1((actorIndex + 1) % numParts);
+
+        bool roundOver;
+        (roundOver, hasWinner, winner) = checkFinal(d, grid, field);
+        if (!roundOver) {
+            return (false, 0, false);
+        }
+        if (hasWinner) {
+            d[winsDataIndex + winner] = bytes1(uint8(d[winsDataIndex + winner]) + 1);
+        }
+        matchOver = isMatchOver(d);
+        if (!matchOver) {
+            uint8 nextFirstActor = (uint8(d[firstActorDataIndex]) + 1) % numParts;
+            d[roundDataIndex] = bytes1(uint8(d[roundDataIndex]) + 1);
+            d[firstActorDataIndex] = bytes1(nextFirstActor);
+            d[actorDataIndex] = bytes1(nextFirstActor);
+            for (uint i = grid; i < d.length; i++) {
+                d[i] = bytes1(notSet);
+            }
+        }
+    }
+
+    function isMatchOver(bytes memory d) internal pure returns (bool) {
+        uint8 rounds = uint8(d[roundsDataIndex]);
+        if (uint8(d[roundDataIndex]) + 1 >= rounds) {
+            return true;
+        }
+        if (uint8(d[modeDataIndex]) == modeBestOf) {
+            for (uint i = 0; i < numParts; i++) {
+                if (uint8(d[winsDataIndex + i]) > rounds / 2) {
+                    return true;
+                }
+            }
+        }
+        return false;
+    }
+
+    /// @dev Asserts that the transition is a forfeit claimed by the waiting player.
+    function requireValidForfeit(
+        Channel.State calldata from,
+        Channel.State calldata to,
+        bytes memory fromData,
+        uint8 claimer)
+    internal pure
     {
-        require(a.length == b.length, "address[]: unequal length");
-        for (uint i = 0; i < a.length; i++) {
-            require(a[i] == b[i], "address[]: unequal item");
-        }
-    }
-
-    function checkFinal(bytes memory d) internal pure returns (bool isFinal, bool hasWinner, uint8 winner) {
-        // 0 1 2
-        // 3 4 5
-        // 6 7 8
-
-        // Check winner.
-        uint8[3][8] memory winningRows = [
-        [0, 1, 2], [3, 4, 5], [6, 7, 8], // horizontal
-        [0, 3, 6], [1, 4, 7], [2, 5, 8], // vertical
-        [0, 4, 8], [2, 4, 6]             // diagonal
-        ];
-        for (uint i = 0; i < winningRows.length; i++) {
-            (bool ok, uint8 v) = sameValue(d, winningRows[i]);
-            if (ok) {
-                if (v == firstPlayer) {
-                    return (true, true, 0);
-                } else if (v == secondPlayer) {
-                    return (true, true, 1);
+        require(uint8(fromData[forfeitDataIndex]) == 0, "forfeit: already claimed");
+        bytes memory expected = bytes.concat(fromData);
+        expected[forfeitDataIndex] = bytes1(uint8(1));
+        require(keccak256(to.appData) == keccak256(expected), "forfeit: data");
+        require(!to.isFinal, "forfeit: final flag");
+        Channel.requireEqualAssetArray(to.outcome.assets, from.outcome.assets);
+        Channel.requireEqualSubAllocArray(to.outcome.locked, from.outcome.locked);
+        requireEqualUint256ArrayArray(to.outcome.balances, forfeitBalances(from.outcome.balances, fromData, claimer));
+    }
+
+    /// @dev forfeitBalances pays out each forfeited round from the other player to the claimer according to the payout scheme.
+    function forfeitBalances(uint256[][] memory balances, bytes memory d, uint8 claimer) internal pure returns (uint256[][] memory result) {
+        require(uint8(d[numStakesDataIndex]) == balances.length, "number of round stakes");
+        uint8 loser = 1 - claimer;
+        uint n = forfeitedRounds(d, claimer);
+        result = copyBalances(balances);
+        for (uint i = 0; i < balances.length; i++) {
+            (uint256 paid, uint256 raked) = roundPayment(d, i);
+            result[i][loser] = balances[i][loser] - n * paid;
+            result[i][claimer] = balances[i][claimer] + n * (paid - raked);
+            if (raked != 0) {
+                result[i][houseIndex] = balances[i][houseIndex] + n * raked;
+            }
+        }
+    }
+
+    /// @dev revertForfeitBalances reverts forfeitBalances when the next actor answers the forfeit claimed by the claimer.
+    function revertForfeitBalances(uint256[][] memory balances, bytes memory d, uint8 claimer) internal pure returns (uint256[][] memory result) {
+        require(uint8(d[numStakesDataIndex]) == balances.length, "number of round stakes");
+        uint8 loser = 1 - claimer;
+        uint n = forfeitedRounds(d, claimer);
+        result = copyBalances(balances);
+        for (uint i = 0; i < balances.length; i++) {
+            (uint256 paid, uint256 raked) = roundPayment(d, i);
+            result[i][loser] = balances[i][loser] + n * paid;
+            result[i][claimer] = balances[i][claimer] - n * (paid - raked);
+            if (raked != 0) {
+                result[i][houseIndex] = balances[i][houseIndex] - n * raked;
+            }
+        }
+    }
+
+    /**
+     * @dev forfeitedRounds returns the number of rounds the claimer wins by
+     * forfeit: the current round and every further round the match would
+     * last if the claimer won them all.
+     */
+    function forfeitedRounds(bytes memory data, uint8 claimer) internal pure returns (uint n) {
+        bytes memory d = bytes.concat(data);
+        for (n = 1; ; n++) {
+            d[winsDataIndex + claimer] = bytes1(uint8(d[winsDataIndex + claimer]) + 1);
+            if (isMatchOver(d)) {
+                return n;
+            }
+            d[roundDataIndex] = bytes1(uint8(d[roundDataIndex]) + 1);
+        }
+    }
+
+    /// @dev roundBalances pays out the round stake of each asset from the loser according to the payout scheme.
+    function roundBalances(uint256[][] memory balances, bytes memory d, uint8 winner) internal pure returns (uint256[][] memory result) {
+        require(uint8(d[numStakesDataIndex]) == balances.length, "number of round stakes");
+        uint8 loser = 1 - winner;
+        result = copyBalances(balances);
+        for (uint i = 0; i < balances.length; i++) {
+            (uint256 paid, uint256 raked) = roundPayment(d, i);
+            result[i][loser] = balances[i][loser] - paid;
+            result[i][winner] = balances[i][winner] + paid - raked;
+            if (raked != 0) {
+                result[i][houseIndex] = balances[i][houseIndex] + raked;
+            }
+        }
+    }
+
+    /// @dev roundPayment returns the amount of asset i the loser of a round pays and the part of it that goes to the house.
+    function roundPayment(bytes memory d, uint i) internal pure returns (uint256 paid, uint256 raked) {
+        uint s = schemeDataIndex(d);
+        uint8 scheme = uint8(d[s + schemeDataOffset]);
+        uint256 stake = readUint(d, stakesDataIndex + stakesDataLength * i, stakesDataLength);
+        uint256 share = readUint(d, s + shareDataOffset, shareDataLength);
+        if (scheme == fixedPrize) {
+            return (readUint(d, s + prizesDataOffset + prizesDataLength * i, prizesDataLength), 0);
+        } else if (scheme == partialStake) {
+            return (stake * share / maxShare, 0);
+        } else if (scheme == houseRake) {
+            return (stake, stake * share / maxShare);
+        }
+        return (stake, 0);
+    }
+
+    function copyBalances(uint256[][] memory balances) internal pure returns (uint256[][] memory result) {
+        result = new uint256[][](balances.length);
+        for (uint i = 0; i < balances.length; i++) {
+            result[i] = new uint256[](balances[i].length);
+            for (uint j = 0; j < balances[i].length; j++) {
+                result[i][j] = balances[i][j];
+            }
+        }
+    }
+
+    /// @dev readUint reads a big-endian unsigned integer of the given length in bytes.
+    function readUint(bytes memory d, uint offset, uint length) internal pure returns (uint256 v) {
+        for (uint i = offset; i < offset + length; i++) {
+            v = (v << 8) | uint256(uint8(d[i]));
+        }
+    }
+
+    /**
+     * @dev checkFinal checks whether the mark on the given field ends the round.
+     * Any new line must contain the latest mark, so only the lines through
+     * this field are checked.
+     */
+    function checkFinal(bytes memory d, uint grid, uint field) internal pure returns (bool isFinal, bool hasWinner, uint8 winner) {
+        uint8 v = uint8(d[grid + field]);
+        if (v != notSet) {
+            int cols = int(uint(uint8(d[colsDataIndex])));
+            int x = int(field) % cols;
+            int y = int(field) / cols;
+            int8[2][4] memory directions = [
+            [int8(1), int8(0)], [int8(0), int8(1)], // horizontal, vertical
+            [int8(1), int8(1)], [int8(1), int8(-1)] // diagonal, anti-diagonal
+            ];
+            for (uint i = 0; i < directions.length; i++) {
+                int dx = directions[i][0];
+                int dy = directions[i][1];
+                uint n = 1 + countFrom(d, grid, x, y, dx, dy, v) + countFrom(d, grid, x, y, -dx, -dy, v);
+                if (n >= uint8(d[winLengthDataIndex])) {
+                    return (true, true, v - firstPlayer);
                 }
             }
         }
 
         // Check all set.
-        for (uint i = 0; i < d.length; i++) {
-            if (uint8(d[i]) != notSet) {
+        for (uint i = grid; i < d.length; i++) {
+            if (uint8(d[i]) == notSet) {
                 return (false, false, 0);
             }
         }
         return (true, false, 0);
     }
 
-    function sameValue(bytes memory d, uint8[3] memory gridIndices) internal pure returns (bool ok, uint8 v) {
-        bytes1 first = d[gridDataIndex + gridIndices[0]];
-        for (uint i = 1; i < gridIndices.length; i++) {
-            if (d[gridDataIndex + gridIndices[i]] != first) {
-                return (false, 0);
-            }
+    /// @dev countFrom counts the consecutive fields with value v starting next to (x, y) in direction (dx, dy).
+    function countFrom(bytes memory d, uint grid, int x, int y, int dx, int dy, uint8 v) internal pure returns (uint n) {
+        int rows = int(uint(uint8(d[rowsDataIndex])));
+        int cols = int(uint(uint8(d[colsDataIndex])));
+        x += dx;
+        y += dy;
+        while (x >= 0 && x < cols && y >= 0 && y < rows && uint8(d[grid + uint(y * cols + x)]) == v) {
+            n++;
+            x += dx;
+            y += dy;
+        }
+    }
+
+    function numFields(bytes memory d) internal pure returns (uint) {
+        return uint(uint8(d[rowsDataIndex])) * uint(uint8(d[colsDataIndex]));
+    }
+
+    function numParticipants(bytes memory d) internal pure returns (uint) {
+        if (uint8(d[schemeDataIndex(d) + schemeDataOffset]) == houseRake) {
+            return numParts + 1;
         }
-        return (true, uint8(first));
+        return numParts;
     }
 
     function requireEqualUint256ArrayArray(
@@ -152,4 +344,4 @@ contract TicTacToeApp is App {
             Array.requireEqualUint256Array(a[i], b[i]);
         }
     }
-}
\ No newline at end of file
+}
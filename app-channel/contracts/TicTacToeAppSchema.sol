@@ -0,0 +1,90 @@
+// Copyright 2025 - See NOTICE file for copyright holders.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by schemagen. DO NOT EDIT.
+
+pragma solidity ^0.8.15;
+
+/**
+ * @notice TicTacToeAppSchema describes the data layout of TicTacToeApp, version 2.
+ * Multi-byte integers are big-endian. The data is encoded as follows:
+ * - data[0]: The data version.
+ * - data[1]: The index of the next actor.
+ * - data[2:10]: The deadline of the next move as Unix time in seconds.
+ * - data[10]: 1 if the waiting player claimed a forfeit that the next actor has not answered, 0 otherwise.
+ * - data[11]: The number of rows m.
+ * - data[12]: The number of columns n.
+ * - data[13]: The number of marks k in a line needed to win.
+ * - data[14]: The match mode. 0 means a fixed number of rounds, 1 means best of the number of rounds.
+ * - data[15]: The number of rounds.
+ * - data[16]: The current round, starting at 0.
+ * - data[17]: The index of the player who opened the current round.
+ * - data[18:20]: The number of rounds won by player 1 and 2.
+ * - data[20:22]: The field of the last mark plus one, or 0 if there was none.
+ * - data[22]: The number of assets.
+ * - data[23:23+32*numStakes]: The round stake per asset.
+ * - data[s1], s1 = schemeDataIndex(data): The payout scheme. 0 means winner takes all, 1 fixed prize, 2 partial stake, 3 house rake.
+ * - data[s1+1:s1+3]: The paid (partial stake) or raked (house rake) share in basis points.
+ * - data[s1+3]: The number of prizes, which is the number of assets for a fixed prize and 0 otherwise.
+ * - data[s1+4:s1+4+32*numPrizes]: The prize per asset.
+ * - data[s2:], s2 = gridDataIndex(data): The m*n fields, row by row. 0 means no mark, 1 means a mark by player 1, 2 a mark by player 2.
+ */
+abstract contract TicTacToeAppSchema {
+    uint8 constant dataVersion = 2;
+    uint constant versionDataIndex = 0;
+    uint constant actorDataIndex = 1;
+    uint constant deadlineDataIndex = 2;
+    uint constant deadlineDataLength = 8;
+    uint constant forfeitDataIndex = 10;
+    uint constant rowsDataIndex = 11;
+    uint constant colsDataIndex = 12;
+    uint constant winLengthDataIndex = 13;
+    uint constant modeDataIndex = 14;
+    uint constant roundsDataIndex = 15;
+    uint constant roundDataIndex = 16;
+    uint constant firstActorDataIndex = 17;
+    uint constant winsDataIndex = 18;
+    uint constant lastMoveDataIndex = 20;
+    uint constant lastMoveDataLength = 2;
+    uint constant numStakesDataIndex = 22;
+    uint constant stakesDataIndex = 23;
+    uint constant stakesDataLength = 32;
+    uint constant schemeDataOffset = 0;
+    uint constant shareDataOffset = 1;
+    uint constant shareDataLength = 2;
+    uint constant numPrizesDataOffset = 3;
+    uint constant prizesDataOffset = 4;
+    uint constant prizesDataLength = 32;
+    uint constant gridDataOffset = 0;
+
+    /// @dev requireDataVersion reverts if the data is not of version dataVersion.
+    function requireDataVersion(bytes memory d) internal pure {
+        require(d.length > versionDataIndex && uint8(d[versionDataIndex]) == dataVersion, "data version");
+    }
+
+    /// @dev schemeDataIndex returns the index of scheme, which follows the stakes.
+    function schemeDataIndex(bytes memory d) internal pure returns (uint) {
+        require(d.length > numStakesDataIndex, "data length");
+        return stakesDataIndex + stakesDataLength * uint(uint8(d[numStakesDataIndex]));
+    }
+
+    /// @dev gridDataIndex returns the index of grid, which follows the prizes.
+    function gridDataIndex(bytes memory d) internal pure returns (uint) {
+        uint s = schemeDataIndex(d);
+        require(d.length > s + numPrizesDataOffset, "data length");
+        return s + prizesDataOffset + prizesDataLength * uint(uint8(d[s + numPrizesDataOffset]));
+    }
+}
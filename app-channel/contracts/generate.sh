@@ -46,6 +46,11 @@ generate_bindings() {
     find $GENDIR -name "*.bin" -type f -delete
 }
 
+# Generate the data layout contracts from the schemas in package app.
+echo "Generating schema contracts..."
+(cd .. && go run ./cmd/schemagen -out contracts)
+
 generate_bindings "TicTacToeApp" "ticTacToeApp"
+generate_bindings "RockPaperScissorsApp" "rockPaperScissorsApp"
 # generate_bindings ./perun-eth-contracts/contracts/Adjudicator.sol adjudicator
 # generate_bindings ./perun-eth-contracts/contracts/AssetHolderETH.sol assetHolderETH
@@ -0,0 +1,279 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package rockPaperScissorsApp
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ChannelAllocation is an auto generated low-level Go binding around an user-defined struct.
+type ChannelAllocation struct {
+	Assets   []ChannelAsset
+	Backends []*big.Int
+	Balances [][]*big.Int
+	Locked   []ChannelSubAlloc
+}
+
+// ChannelAsset is an auto generated low-level Go binding around an user-defined struct.
+type ChannelAsset struct {
+	ChainID   *big.Int
+	EthHolder common.Address
+	CcHolder  []byte
+}
+
+// ChannelParams is an auto generated low-level Go binding around an user-defined struct.
+type ChannelParams struct {
+	ChallengeDuration *big.Int
+	Nonce             *big.Int
+	Participants      []ChannelParticipant
+	App               common.Address
+	LedgerChannel     bool
+	VirtualChannel    bool
+}
+
+// ChannelParticipant is an auto generated low-level Go binding around an user-defined struct.
+type ChannelParticipant struct {
+	EthAddress common.Address
+	CcAddress  []byte
+}
+
+// ChannelState is an auto generated low-level Go binding around an user-defined struct.
+type ChannelState struct {
+	ChannelID [32]byte
+	Version   uint64
+	Outcome   ChannelAllocation
+	AppData   []byte
+	IsFinal   bool
+}
+
+// ChannelSubAlloc is an auto generated low-level Go binding around an user-defined struct.
+type ChannelSubAlloc struct {
+	ID       [32]byte
+	Balances []*big.Int
+	IndexMap []uint16
+}
+
+// RockPaperScissorsAppMetaData contains all meta data concerning the RockPaperScissorsApp contract.
+var RockPaperScissorsAppMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"challengeDuration\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"components\":[{\"internalType\":\"address\",\"name\":\"ethAddress\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"ccAddress\",\"type\":\"bytes\"}],\"internalType\":\"structChannel.Participant[]\",\"name\":\"participants\",\"type\":\"tuple[]\"},{\"internalType\":\"address\",\"name\":\"app\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"ledgerChannel\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"virtualChannel\",\"type\":\"bool\"}],\"internalType\":\"structChannel.Params\",\"name\":\"params\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"channelID\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"version\",\"type\":\"uint64\"},{\"components\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"chainID\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"ethHolder\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"ccHolder\",\"type\":\"bytes\"}],\"internalType\":\"structChannel.Asset[]\",\"name\":\"assets\",\"type\":\"tuple[]\"},{\"internalType\":\"uint256[]\",\"name\":\"backends\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[][]\",\"name\":\"balances\",\"type\":\"uint256[][]\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"ID\",\"type\":\"bytes32\"},{\"internalType\":\"uint256[]\",\"name\":\"balances\",\"type\":\"uint256[]\"},{\"internalType\":\"uint16[]\",\"name\":\"indexMap\",\"type\":\"uint16[]\"}],\"internalType\":\"structChannel.SubAlloc[]\",\"name\":\"locked\",\"type\":\"tuple[]\"}],\"internalType\":\"structChannel.Allocation\",\"name\":\"outcome\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"appData\",\"type\":\"bytes\"},{\"internalType\":\"bool\",\"name\":\"isFinal\",\"type\":\"bool\"}],\"internalType\":\"structChannel.State\",\"name\":\"from\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"channelID\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"version\",\"type\":\"uint64\"},{\"components\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"chainID\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"ethHolder\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"ccHolder\",\"type\":\"bytes\"}],\"internalType\":\"structChannel.Asset[]\",\"name\":\"assets\",\"type\":\"tuple[]\"},{\"internalType\":\"uint256[]\",\"name\":\"backends\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[][]\",\"name\":\"balances\",\"type\":\"uint256[][]\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"ID\",\"type\":\"bytes32\"},{\"internalType\":\"uint256[]\",\"name\":\"balances\",\"type\":\"uint256[]\"},{\"internalType\":\"uint16[]\",\"name\":\"indexMap\",\"type\":\"uint16[]\"}],\"internalType\":\"structChannel.SubAlloc[]\",\"name\":\"locked\",\"type\":\"tuple[]\"}],\"internalType\":\"structChannel.Allocation\",\"name\":\"outcome\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"appData\",\"type\":\"bytes\"},{\"internalType\":\"bool\",\"name\":\"isFinal\",\"type\":\"bool\"}],\"internalType\":\"structChannel.State\",\"name\":\"to\",\"type\":\"tuple\"},{\"internalType\":\"uint256\",\"name\":\"signerIdx\",\"type\":\"uint256\"}],\"name\":\"validTransition\",\"outputs\":[],\"stateMutability\":\"pure\",\"type\":\"function\"}]",
+	Bin: "0x3415600957600080fd5b611428806100176000396000f361154060405234156100115760006000fd5b60043610156100205760006000fd5b63f7530b4160003560e01c1415156100385760006000fd5b60843610156100475760006000fd5b610064606435604435600401602435600401600435600401610c2c565b60006000f3005b60805260a052600060c05260406080510135608051013560c0525b60c05160a051565b60e05261010052600061012052604060e051013560e05101610120525b6101205161010051565b610140526101605260006101805260606101405101356101405101610180525b6101805161016051565b6101a0526101c05260006101e05260806101a05101356101e05260016101e051111561010b5760006000fd5b5b6101e0516101c051565b610200526102205260006102405260406102005101356102005101610240525b6102405161022051565b61026052610280526102a05260006102c05261026051356102805110151561018a577f696e646578000000000000000000000000000000000000000000000000000000600561140c565b6020610280510260206102605101013560206102605101016102c0525b6102c0516102a051565b6102e052610300526103205260006103405260405161036052610300516102e0516103605137610300516103605120610340525b6103405161032051565b610380526103a0526103c05260006103e0526103805135610400526103a051356104005114156102455761022c6104005160206103a051016101b1565b61023f61040051602061038051016101b1565b146103e0525b5b6103e0516103c051565b61042052610440526104605260006104805261042051356104a05261044051356104a05114156102ac5761029060206104a05102602061044051016101b1565b6102a660206104a05102602061042051016101b1565b14610480525b5b6104805161046051565b6104c0526104e052610500526104c051356104c05101610520526104e051356104e05101610540526105205135610560526105405135610560511461031e577f41737365745b5d3a20756e657175616c206c656e677468000000000000000000601761140c565b6000610580525b6105605161058051101561042e576103436105805161052051610140565b6105a0526103576105805161054051610140565b6105c0526105c051356105a0513514610392577f756e657175616c20636861696e49440000000000000000000000000000000000600f61140c565b60206105c051013560206105a0510135146103cf577f756e657175616c20657468486f6c646572000000000000000000000000000000601161140c565b6103f160406105c05101356105c0510160406105a05101356105a051016101ef565b61041d577f756e657175616c206363486f6c64657200000000000000000000000000000000601061140c565b5b6001610580510161058052610325565b5b61050051565b6105e052610600526106205260606105e05101356105e05101610640526060610600510135610600510161066052610640513561068052610660513561068051146104a2577f537562416c6c6f635b5d3a20756e657175616c206c656e677468000000000000601a61140c565b60006106a0525b610680516106a05110156105c3576104c76106a05161064051610140565b6106c0526104db6106a05161066051610140565b6106e0526106e051356106c0513514610516577f537562416c6c6f633a20756e657175616c204944000000000000000000000000601461140c565b61053860206106e05101356106e0510160206106c05101356106c05101610250565b610564577f75696e743235365b5d3a20756e657175616c206974656d000000000000000000601761140c565b61058660406106e05101356106e0510160406106c05101356106c05101610250565b6105b2577f75696e7431365b5d3a20756e657175616c206974656d00000000000000000000601661140c565b5b60016106a051016106a0526104a9565b5b61062051565b610700526107205260006107405260405161074052601f19601f61070051011661074051016040525b6107405161072051565b610760526107805260006107a05261076051356107c05261062360206107c051016105ca565b6107a0526107c0516107a051526107c0516020610760510160206107a05101375b6107a05161078051565b6107e05261080052610820526000610840526107e0515161080051101515610698577f696e646578000000000000000000000000000000000000000000000000000000600561140c565b6108005160206107e05101015160001a610840525b6108405161082051565b61086052610880526108a0526108c0526108605151610880511015156106ff577f696e646578000000000000000000000000000000000000000000000000000000600561140c565b6108a051610880516020610860510101535b6108c051565b6108e05261090052610920526109405260006109605261090051610980525b61092051610900510161098051101561077657610759610980516108e05161064e565b6109605160081b17610960525b6001610980510161098052610736565b5b6109605161094051565b6109a0526109c05260006109e0526109a0515160206109a05101206109e0525b6109e0516109c051565b610a0052610a20526000610a4052610a005135610a60526107d460206001610a605101026105ca565b610a4052610a6051610a4051526000610a80525b610a6051610a8051101561085b57610806610a8051610a0051610140565b610aa05260206001610aa051350102610ac052610825610ac0516105ca565b610ae052610ac051610aa051610ae05137610ae05160206001610a80510102610a405101525b6001610a805101610a80526107e8565b5b610a4051610a2051565b610b0052610b2052610b40526000610b6052610b005151610b20511015156108b0577f696e646578000000000000000000000000000000000000000000000000000000600561140c565b60206001610b20510102610b00510151610b60525b610b6051610b4051565b610b8052610ba052610bc052610be0526000610c00526108f5610ba051610b8051610866565b610c2052610c205151610bc051101515610931577f696e646578000000000000000000000000000000000000000000000000000000600561140c565b60206001610bc0510102610c20510151610c00525b610c0051610be051565b610c4052610c6052610c8052610ca052610cc052610974610c6051610c4051610866565b610ce052610ce05151610c80511015156109b0577f696e646578000000000000000000000000000000000000000000000000000000600561140c565b610ca05160206001610c80510102610ce05101525b610cc051565b610d0052610d2052610d4052610d005135610d6052610d205151610d605114610a16577f75696e743235365b5d5b5d3a20756e657175616c206c656e6774680000000000601b61140c565b6000610d80525b610d6051610d80511015610af557610a3b610d8051610d0051610140565b610da052610a4f610d8051610d2051610866565b610dc052610da05135610de052610dc05151610de05114610a92577f75696e743235365b5d3a20756e657175616c206c656e67746800000000000000601961140c565b6020610de051026020610dc0510120610ab76020610de051026020610da051016101b1565b14610ae4577f75696e743235365b5d3a20756e657175616c206974656d000000000000000000601761140c565b5b6001610d805101610d8052610a1d565b5b610d4051565b610e0052610e2052610e40526000610e6052610e2051610e005101610e6052610e0051610e60511015610b51577f6f766572666c6f77000000000000000000000000000000000000000000000000600861140c565b5b610e6051610e4051565b610e8052610ea052610ec0526000610ee052610ea051610e80511015610ba4577f756e646572666c6f770000000000000000000000000000000000000000000000600961140c565b610ea051610e805103610ee0525b610ee051610ec051565b610f0052610f2052610f40526000610f6052610f2051610f005102610f6052610f005115610c2157610f2051610f0051610f605104141515610c20577f6f766572666c6f77000000000000000000000000000000000000000000000000600861140c565b5b5b610f6051610f4051565b610f8052610fa052610fc052610fe052611000526002610c4e610f805161006b565b14610c7b577f6e756d626572206f66207061727469636970616e747300000000000000000000601661140c565b610c8f610c8a610fa0516100b5565b6105fd565b61102052610ca7610ca2610fc0516100b5565b6105fd565b611040526085611020515114610cdf577f64617461206c656e677468000000000000000000000000000000000000000000600b61140c565b6085611040515114610d13577f64617461206c656e677468000000000000000000000000000000000000000000600b61140c565b6001610d2360006110205161064e565b14610d50577f646174612076657273696f6e0000000000000000000000000000000000000000600c61140c565b6001610d6060006110405161064e565b14610d8d577f646174612076657273696f6e0000000000000000000000000000000000000000600c61140c565b610d9b60026110205161064e565b61106052610fe0516110605114610dd4577f6163746f72206e6f74207369676e657200000000000000000000000000000000601061140c565b600260016110605101066110805261108051610df460026110405161064e565b14610e21577f6e657874206163746f7200000000000000000000000000000000000000000000600a61140c565b610e2f60016110205161064e565b6110a0526110a0516110c0526110a0516110e0526110e05160001415610ebc576110605160200260030161110052610e6f60206111005161104051610717565b1515610e9d577f656d70747920636f6d6d69746d656e7400000000000000000000000000000000601061140c565b610eb760006000602061110051611040516110205161132b565b610fe0565b6110e05160011415610fb857610edb611060516043016110405161064e565b61112052600361112051111561112051151516610f1a577f63686f6963650000000000000000000000000000000000000000000000000000600661140c565b610f3260206110605160200260450161104051610717565b611140526111205160005361114051600152610f5c60206110605160200260030161102051610717565b602160002014610f8e577f636f6d6d69746d656e7400000000000000000000000000000000000000000000600a61140c565b610fb3602061106051602002604501600161106051604301611040516110205161132b565b610fe0565b7f7068617365000000000000000000000000000000000000000000000000000000600561140c565b611080511515610ff65760016110a051016110c0525b6110c05161100860016110405161064e565b14611035577f6e65787420706861736500000000000000000000000000000000000000000000600a61140c565b60026110c05114611048610fc0516100df565b14611075577f66696e616c20666c616700000000000000000000000000000000000000000000600a61140c565b611081610fa05161008e565b61116052611091610fc05161008e565b611180526110a561116051611180516102b7565b6110b56111605161118051610435565b6110c96110c461116051610116565b6107ab565b6111a05260016110c05114156110f0576110ef600260016110805101066111a0516111f3565b5b60026110c05114156111475761110861104051611166565b6111c0526111e0526111c051611200526112005160011415611139576111346111e0516111a0516111f3565b611146565b6111456111a05161128e565b5b5b61115f6111a05161115a61118051610116565b6109cb565b5b61100051565b611220526112405260006112605260006112805261118860436112205161064e565b6112a05261119a60446112205161064e565b6112c052600160036112c05160036112a05101030614156111c0576001611260526111e5565b600160036112a05160036112c05101030614156111e4576001611260526001611280525b5b611280516112605161124051565b6112e05261130052611320526000611340525b6112e051516113405110156112875761124161122a6001611340516112e0516108cf565b61123c6000611340516112e0516108cf565b610afc565b6113605261125d6113605161130051611340516112e051610950565b611276600061130051600103611340516112e051610950565b5b6001611340510161134052611206565b5b61132051565b611380526113a05260006113c0525b61138051516113c0511015611324576112d86112c160016113c051611380516108cf565b6112d360006113c051611380516108cf565b610afc565b6113e0526112f560026113e0510460006113c05161138051610950565b61131360026113e051046113e0510360016113c05161138051610950565b5b60016113c051016113c05261129d565b5b6113a051565b61140052611420526114405261146052611480526114a0526114c05260036114e0525b61140051516114e0511015611405576114605161144051016114e05110611440516114e051101516611500526114a05161148051016114e05110611480516114e0511015166115205261152051611500511715156113f4576113b66114e0516114205161064e565b6113c66114e0516114005161064e565b146113f3577f756e6578706563746564206368616e6765000000000000000000000000000000601161140c565b5b5b60016114e051016114e05261134e565b5b6114c051565b6308c379a060e01b600052602060045260245260445260646000fd",
+}
+
+// RockPaperScissorsAppABI is the input ABI used to generate the binding from.
+// Deprecated: Use RockPaperScissorsAppMetaData.ABI instead.
+var RockPaperScissorsAppABI = RockPaperScissorsAppMetaData.ABI
+
+// RockPaperScissorsAppBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use RockPaperScissorsAppMetaData.Bin instead.
+var RockPaperScissorsAppBin = RockPaperScissorsAppMetaData.Bin
+
+// DeployRockPaperScissorsApp deploys a new Ethereum contract, binding an instance of RockPaperScissorsApp to it.
+func DeployRockPaperScissorsApp(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *RockPaperScissorsApp, error) {
+	parsed, err := RockPaperScissorsAppMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(RockPaperScissorsAppBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &RockPaperScissorsApp{RockPaperScissorsAppCaller: RockPaperScissorsAppCaller{contract: contract}, RockPaperScissorsAppTransactor: RockPaperScissorsAppTransactor{contract: contract}, RockPaperScissorsAppFilterer: RockPaperScissorsAppFilterer{contract: contract}}, nil
+}
+
+// RockPaperScissorsApp is an auto generated Go binding around an Ethereum contract.
+type RockPaperScissorsApp struct {
+	RockPaperScissorsAppCaller     // Read-only binding to the contract
+	RockPaperScissorsAppTransactor // Write-only binding to the contract
+	RockPaperScissorsAppFilterer   // Log filterer for contract events
+}
+
+// RockPaperScissorsAppCaller is an auto generated read-only Go binding around an Ethereum contract.
+type RockPaperScissorsAppCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RockPaperScissorsAppTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type RockPaperScissorsAppTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RockPaperScissorsAppFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type RockPaperScissorsAppFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// RockPaperScissorsAppSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type RockPaperScissorsAppSession struct {
+	Contract     *RockPaperScissorsApp // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts         // Call options to use throughout this session
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// RockPaperScissorsAppCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type RockPaperScissorsAppCallerSession struct {
+	Contract *RockPaperScissorsAppCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts               // Call options to use throughout this session
+}
+
+// RockPaperScissorsAppTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type RockPaperScissorsAppTransactorSession struct {
+	Contract     *RockPaperScissorsAppTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts               // Transaction auth options to use throughout this session
+}
+
+// RockPaperScissorsAppRaw is an auto generated low-level Go binding around an Ethereum contract.
+type RockPaperScissorsAppRaw struct {
+	Contract *RockPaperScissorsApp // Generic contract binding to access the raw methods on
+}
+
+// RockPaperScissorsAppCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type RockPaperScissorsAppCallerRaw struct {
+	Contract *RockPaperScissorsAppCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// RockPaperScissorsAppTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type RockPaperScissorsAppTransactorRaw struct {
+	Contract *RockPaperScissorsAppTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewRockPaperScissorsApp creates a new instance of RockPaperScissorsApp, bound to a specific deployed contract.
+func NewRockPaperScissorsApp(address common.Address, backend bind.ContractBackend) (*RockPaperScissorsApp, error) {
+	contract, err := bindRockPaperScissorsApp(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &RockPaperScissorsApp{RockPaperScissorsAppCaller: RockPaperScissorsAppCaller{contract: contract}, RockPaperScissorsAppTransactor: RockPaperScissorsAppTransactor{contract: contract}, RockPaperScissorsAppFilterer: RockPaperScissorsAppFilterer{contract: contract}}, nil
+}
+
+// NewRockPaperScissorsAppCaller creates a new read-only instance of RockPaperScissorsApp, bound to a specific deployed contract.
+func NewRockPaperScissorsAppCaller(address common.Address, caller bind.ContractCaller) (*RockPaperScissorsAppCaller, error) {
+	contract, err := bindRockPaperScissorsApp(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RockPaperScissorsAppCaller{contract: contract}, nil
+}
+
+// NewRockPaperScissorsAppTransactor creates a new write-only instance of RockPaperScissorsApp, bound to a specific deployed contract.
+func NewRockPaperScissorsAppTransactor(address common.Address, transactor bind.ContractTransactor) (*RockPaperScissorsAppTransactor, error) {
+	contract, err := bindRockPaperScissorsApp(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RockPaperScissorsAppTransactor{contract: contract}, nil
+}
+
+// NewRockPaperScissorsAppFilterer creates a new log filterer instance of RockPaperScissorsApp, bound to a specific deployed contract.
+func NewRockPaperScissorsAppFilterer(address common.Address, filterer bind.ContractFilterer) (*RockPaperScissorsAppFilterer, error) {
+	contract, err := bindRockPaperScissorsApp(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &RockPaperScissorsAppFilterer{contract: contract}, nil
+}
+
+// bindRockPaperScissorsApp binds a generic wrapper to an already deployed contract.
+func bindRockPaperScissorsApp(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := RockPaperScissorsAppMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_RockPaperScissorsApp *RockPaperScissorsAppRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _RockPaperScissorsApp.Contract.RockPaperScissorsAppCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_RockPaperScissorsApp *RockPaperScissorsAppRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _RockPaperScissorsApp.Contract.RockPaperScissorsAppTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_RockPaperScissorsApp *RockPaperScissorsAppRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _RockPaperScissorsApp.Contract.RockPaperScissorsAppTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_RockPaperScissorsApp *RockPaperScissorsAppCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _RockPaperScissorsApp.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_RockPaperScissorsApp *RockPaperScissorsAppTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _RockPaperScissorsApp.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_RockPaperScissorsApp *RockPaperScissorsAppTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _RockPaperScissorsApp.Contract.contract.Transact(opts, method, params...)
+}
+
+// ValidTransition is a free data retrieval call binding the contract method 0xf7530b41.
+//
+// Solidity: function validTransition((uint256,uint256,(address,bytes)[],address,bool,bool) params, (bytes32,uint64,((uint256,address,bytes)[],uint256[],uint256[][],(bytes32,uint256[],uint16[])[]),bytes,bool) from, (bytes32,uint64,((uint256,address,bytes)[],uint256[],uint256[][],(bytes32,uint256[],uint16[])[]),bytes,bool) to, uint256 signerIdx) pure returns()
+func (_RockPaperScissorsApp *RockPaperScissorsAppCaller) ValidTransition(opts *bind.CallOpts, params ChannelParams, from ChannelState, to ChannelState, signerIdx *big.Int) error {
+	var out []interface{}
+	err := _RockPaperScissorsApp.contract.Call(opts, &out, "validTransition", params, from, to, signerIdx)
+
+	if err != nil {
+		return err
+	}
+
+	return err
+
+}
+
+// ValidTransition is a free data retrieval call binding the contract method 0xf7530b41.
+//
+// Solidity: function validTransition((uint256,uint256,(address,bytes)[],address,bool,bool) params, (bytes32,uint64,((uint256,address,bytes)[],uint256[],uint256[][],(bytes32,uint256[],uint16[])[]),bytes,bool) from, (bytes32,uint64,((uint256,address,bytes)[],uint256[],uint256[][],(bytes32,uint256[],uint16[])[]),bytes,bool) to, uint256 signerIdx) pure returns()
+func (_RockPaperScissorsApp *RockPaperScissorsAppSession) ValidTransition(params ChannelParams, from ChannelState, to ChannelState, signerIdx *big.Int) error {
+	return _RockPaperScissorsApp.Contract.ValidTransition(&_RockPaperScissorsApp.CallOpts, params, from, to, signerIdx)
+}
+
+// ValidTransition is a free data retrieval call binding the contract method 0xf7530b41.
+//
+// Solidity: function validTransition((uint256,uint256,(address,bytes)[],address,bool,bool) params, (bytes32,uint64,((uint256,address,bytes)[],uint256[],uint256[][],(bytes32,uint256[],uint16[])[]),bytes,bool) from, (bytes32,uint64,((uint256,address,bytes)[],uint256[],uint256[][],(bytes32,uint256[],uint16[])[]),bytes,bool) to, uint256 signerIdx) pure returns()
+func (_RockPaperScissorsApp *RockPaperScissorsAppCallerSession) ValidTransition(params ChannelParams, from ChannelState, to ChannelState, signerIdx *big.Int) error {
+	return _RockPaperScissorsApp.Contract.ValidTransition(&_RockPaperScissorsApp.CallOpts, params, from, to, signerIdx)
+}
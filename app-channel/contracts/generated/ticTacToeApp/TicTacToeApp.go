@@ -79,7 +79,7 @@ type ChannelSubAlloc struct {
 // TicTacToeAppMetaData contains all meta data concerning the TicTacToeApp contract.
 var TicTacToeAppMetaData = &bind.MetaData{
 	ABI: "[{\"inputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"challengeDuration\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"components\":[{\"internalType\":\"address\",\"name\":\"ethAddress\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"ccAddress\",\"type\":\"bytes\"}],\"internalType\":\"structChannel.Participant[]\",\"name\":\"participants\",\"type\":\"tuple[]\"},{\"internalType\":\"address\",\"name\":\"app\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"ledgerChannel\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"virtualChannel\",\"type\":\"bool\"}],\"internalType\":\"structChannel.Params\",\"name\":\"params\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"channelID\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"version\",\"type\":\"uint64\"},{\"components\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"chainID\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"ethHolder\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"ccHolder\",\"type\":\"bytes\"}],\"internalType\":\"structChannel.Asset[]\",\"name\":\"assets\",\"type\":\"tuple[]\"},{\"internalType\":\"uint256[]\",\"name\":\"backends\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[][]\",\"name\":\"balances\",\"type\":\"uint256[][]\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"ID\",\"type\":\"bytes32\"},{\"internalType\":\"uint256[]\",\"name\":\"balances\",\"type\":\"uint256[]\"},{\"internalType\":\"uint16[]\",\"name\":\"indexMap\",\"type\":\"uint16[]\"}],\"internalType\":\"structChannel.SubAlloc[]\",\"name\":\"locked\",\"type\":\"tuple[]\"}],\"internalType\":\"structChannel.Allocation\",\"name\":\"outcome\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"appData\",\"type\":\"bytes\"},{\"internalType\":\"bool\",\"name\":\"isFinal\",\"type\":\"bool\"}],\"internalType\":\"structChannel.State\",\"name\":\"from\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"channelID\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"version\",\"type\":\"uint64\"},{\"components\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"chainID\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"ethHolder\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"ccHolder\",\"type\":\"bytes\"}],\"internalType\":\"structChannel.Asset[]\",\"name\":\"assets\",\"type\":\"tuple[]\"},{\"internalType\":\"uint256[]\",\"name\":\"backends\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[][]\",\"name\":\"balances\",\"type\":\"uint256[][]\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"ID\",\"type\":\"bytes32\"},{\"internalType\":\"uint256[]\",\"name\":\"balances\",\"type\":\"uint256[]\"},{\"internalType\":\"uint16[]\",\"name\":\"indexMap\",\"type\":\"uint16[]\"}],\"internalType\":\"structChannel.SubAlloc[]\",\"name\":\"locked\",\"type\":\"tuple[]\"}],\"internalType\":\"structChannel.Allocation\",\"name\":\"outcome\",\"type\":\"tuple\"},{\"internalType\":\"bytes\",\"name\":\"appData\",\"type\":\"bytes\"},{\"internalType\":\"bool\",\"name\":\"isFinal\",\"type\":\"bool\"}],\"internalType\":\"structChannel.State\",\"name\":\"to\",\"type\":\"tuple\"},{\"internalType\":\"uint256\",\"name\":\"signerIdx\",\"type\":\"uint256\"}],\"name\":\"validTransition\",\"outputs\":[],\"stateMutability\":\"pure\",\"type\":\"function\"}]",
-	Bin: "0x6080604052348015600e575f5ffd5b506114ec8061001c5f395ff3fe608060405234801561000f575f5ffd5b5060043610610029575f3560e01c8063f7530b411461002d575b5f5ffd5b61004061003b366004610f01565b610042565b005b60026100516040860186610f98565b90501461009e5760405162461bcd60e51b81526020600482015260166024820152756e756d626572206f66207061727469636970616e747360501b60448201526064015b60405180910390fd5b5f6100ac6060850185610fdd565b5f816100ba576100ba61101f565b919091013560f81c9150600990506100d360015f611047565b6100dd9190611047565b60ff166100ed6060850185610fdd565b90501461012a5760405162461bcd60e51b815260206004820152600b60248201526a0c8c2e8c240d8cadccee8d60ab1b6044820152606401610095565b818160ff161461016f5760405162461bcd60e51b815260206004820152601060248201526f30b1ba37b9103737ba1039b4b3b732b960811b6044820152606401610095565b61017c6060840184610fdd565b5f8161018a5761018a61101f565b919091013560f81c905060026101a1836001611047565b6101ab9190611066565b60ff16146101e85760405162461bcd60e51b815260206004820152600a6024820152693732bc3a1030b1ba37b960b11b6044820152606401610095565b5f806101f5600182611047565b60ff1690505b600961020860015f611047565b6102129190611047565b60ff1681101561039957600261022b6060870187610fdd565b8381811061023b5761023b61101f565b9050013560f81c60f81b60f81c60ff1611156102865760405162461bcd60e51b815260206004820152600a602482015269677269642076616c756560b01b6044820152606401610095565b6102936060870187610fdd565b828181106102a3576102a361101f565b909101356001600160f81b03191690506102c06060870187610fdd565b838181106102d0576102d061101f565b9050013560f81c60f81b6001600160f81b03191614610391575f6102f76060880188610fdd565b838181106103075761030761101f565b9050013560f81c60f81b60f81c60ff16146103505760405162461bcd60e51b81526020600482015260096024820152686f766572777269746560b81b6044820152606401610095565b811561038c5760405162461bcd60e51b815260206004820152600b60248201526a74776f20616374696f6e7360a81b6044820152606401610095565b600191505b6001016101fb565b505f80806103e66103ad6060890189610fdd565b8080601f0160208091040260200160405190810160405280939291908181526020018383808284375f9201919091525061071292505050565b9194509250905082151561040060a0890160808a01611093565b15151461043c5760405162461bcd60e51b815260206004820152600a60248201526966696e616c20666c616760b01b6044820152606401610095565b61048461044c60408901896110b9565b6104569080610f98565b61045f91611165565b61046c60408b018b6110b9565b6104769080610f98565b61047f91611165565b6108fd565b6104d461049460408901896110b9565b6104a2906060810190610f98565b6104ab916112f8565b6104b860408b018b6110b9565b6104c6906060810190610f98565b6104cf916112f8565b6109a2565b5f6104e260408a018a6110b9565b6104f0906040810190610f98565b6104f991611428565b905082156106d9575f61050d83600161148a565b905081516001600160401b03811115610528576105286110d7565b60405190808252806020026020018201604052801561055b57816020015b60608152602001906001900390816105465790505b5091505f5b82518110156106d6576040805160028082526060820183529091602083019080368337019050508382815181106105995761059961101f565b60209081029190910101526105b160408c018c6110b9565b6105bf906040810190610f98565b828181106105cf576105cf61101f565b90506020028101906105e19190610f98565b60018181106105f2576105f261101f565b905060200201358b806040019061060991906110b9565b610617906040810190610f98565b838181106106275761062761101f565b90506020028101906106399190610f98565b5f8181106106495761064961101f565b9050602002013561065a91906114a3565b83828151811061066c5761066c61101f565b60200260200101518560ff16815181106106885761068861101f565b6020026020010181815250505f8382815181106106a7576106a761101f565b60200260200101518360ff16815181106106c3576106c361101f565b6020908102919091010152600101610560565b50505b6107066106e960408a018a6110b9565b6106f7906040810190610f98565b61070091611428565b82610a42565b50505050505050505050565b60408051610160810182525f610100820181815260016101208401819052600261014085018190529184528451606081810187526003808352600460208481018290526005858b01819052818a019590955289518085018b52600680825260078284018190526008838e018190528c8e01939093528c518088018e528b815280850196909652858d01829052868c01959095528b518087018d52978852878301849052878c019490945260808a019690965289518085018b5287815280820195909552848a0186905260a089019490945288518084018a52878152808501829052808a019590955260c088019490945287519182018852938152908101919091529384015260e082019290925281908190815b60088110156108a2575f5f610850888585600881106108465761084661101f565b6020020151610ae2565b915091508115610898575f1960ff821601610878576001805f965096509650505050506108f6565b60011960ff821601610898576001806001965096509650505050506108f6565b5050600101610825565b505f5b85518110156108e9575f60ff168682815181106108c4576108c461101f565b016020015160f81c146108e1575f5f5f94509450945050506108f6565b6001016108a5565b5060015f5f935093509350505b9193909250565b805182511461094e5760405162461bcd60e51b815260206004820152601760248201527f41737365745b5d3a20756e657175616c206c656e6774680000000000000000006044820152606401610095565b5f5b825181101561099d5761099583828151811061096e5761096e61101f565b60200260200101518383815181106109885761098861101f565b6020026020010151610bb8565b600101610950565b505050565b80518251146109f35760405162461bcd60e51b815260206004820152601a60248201527f537562416c6c6f635b5d3a20756e657175616c206c656e6774680000000000006044820152606401610095565b5f5b825181101561099d57610a3a838281518110610a1357610a1361101f565b6020026020010151838381518110610a2d57610a2d61101f565b6020026020010151610cb4565b6001016109f5565b8051825114610a935760405162461bcd60e51b815260206004820152601b60248201527f75696e743235365b5d5b5d3a20756e657175616c206c656e67746800000000006044820152606401610095565b5f5b825181101561099d57610ada838281518110610ab357610ab361101f565b6020026020010151838381518110610acd57610acd61101f565b6020026020010151610d20565b600101610a95565b5f80808484826020020151610af860015f611047565b610b029190611047565b60ff1681518110610b1557610b1561101f565b01602001516001600160f81b031916905060015b6003811015610ba6576001600160f81b0319821686868360038110610b5057610b5061101f565b6020020151610b6060015f611047565b610b6a9190611047565b60ff1681518110610b7d57610b7d61101f565b01602001516001600160f81b03191614610b9e575f5f935093505050610bb1565b600101610b29565b506001925060f81c90505b9250929050565b8051825114610bfb5760405162461bcd60e51b815260206004820152600f60248201526e1d5b995c5d585b0818da185a5b9251608a1b6044820152606401610095565b80602001516001600160a01b031682602001516001600160a01b031614610c585760405162461bcd60e51b81526020600482015260116024820152703ab732b8bab0b61032ba342437b63232b960791b6044820152606401610095565b80604001518051906020012082604001518051906020012014610cb05760405162461bcd60e51b815260206004820152601060248201526f3ab732b8bab0b61031b1a437b63232b960811b6044820152606401610095565b5050565b8051825114610cfc5760405162461bcd60e51b815260206004820152601460248201527314dd58905b1b1bd8ce881d5b995c5d585b08125160621b6044820152606401610095565b610d0e82602001518260200151610d20565b610cb082604001518260400151610e05565b8051825114610d715760405162461bcd60e51b815260206004820152601960248201527f75696e743235365b5d3a20756e657175616c206c656e677468000000000000006044820152606401610095565b5f5b825181101561099d57818181518110610d8e57610d8e61101f565b6020026020010151838281518110610da857610da861101f565b602002602001015114610dfd5760405162461bcd60e51b815260206004820152601760248201527f75696e743235365b5d3a20756e657175616c206974656d0000000000000000006044820152606401610095565b600101610d73565b8051825114610e565760405162461bcd60e51b815260206004820152601860248201527f75696e7431365b5d3a20756e657175616c206c656e67746800000000000000006044820152606401610095565b5f5b825181101561099d57818181518110610e7357610e7361101f565b602002602001015161ffff16838281518110610e9157610e9161101f565b602002602001015161ffff1614610ee35760405162461bcd60e51b815260206004820152601660248201527575696e7431365b5d3a20756e657175616c206974656d60501b6044820152606401610095565b600101610e58565b5f60a08284031215610efb575f5ffd5b50919050565b5f5f5f5f60808587031215610f14575f5ffd5b84356001600160401b03811115610f29575f5ffd5b850160c08188031215610f3a575f5ffd5b935060208501356001600160401b03811115610f54575f5ffd5b610f6087828801610eeb565b93505060408501356001600160401b03811115610f7b575f5ffd5b610f8787828801610eeb565b949793965093946060013593505050565b5f5f8335601e19843603018112610fad575f5ffd5b8301803591506001600160401b03821115610fc6575f5ffd5b6020019150600581901b3603821315610bb1575f5ffd5b5f5f8335601e19843603018112610ff2575f5ffd5b8301803591506001600160401b0382111561100b575f5ffd5b602001915036819003821315610bb1575f5ffd5b634e487b7160e01b5f52603260045260245ffd5b634e487b7160e01b5f52601160045260245ffd5b60ff818116838216019081111561106057611060611033565b92915050565b5f60ff83168061108457634e487b7160e01b5f52601260045260245ffd5b8060ff84160691505092915050565b5f602082840312156110a3575f5ffd5b813580151581146110b2575f5ffd5b9392505050565b5f8235607e198336030181126110cd575f5ffd5b9190910192915050565b634e487b7160e01b5f52604160045260245ffd5b604051606081016001600160401b038111828210171561110d5761110d6110d7565b60405290565b604051601f8201601f191681016001600160401b038111828210171561113b5761113b6110d7565b604052919050565b5f6001600160401b0382111561115b5761115b6110d7565b5060051b60200190565b5f61117761117284611143565b611113565b8381526020810190600585901b840136811115611192575f5ffd5b845b818110156112885780356001600160401b038111156111b1575f5ffd5b860160603682900312156111c3575f5ffd5b6111cb6110eb565b8135815260208201356001600160a01b03811681146111e8575f5ffd5b602082015260408201356001600160401b03811115611205575f5ffd5b919091019036601f830112611218575f5ffd5b81356001600160401b03811115611231576112316110d7565b611244601f8201601f1916602001611113565b818152366020838601011115611258575f5ffd5b816020850160208301375f6020838301015280604084015250508086525050602084019350602081019050611194565b509095945050505050565b5f82601f8301126112a2575f5ffd5b81356112b061117282611143565b8082825260208201915060208360051b8601019250858311156112d1575f5ffd5b602085015b838110156112ee5780358352602092830192016112d6565b5095945050505050565b5f61130561117284611143565b8381526020810190600585901b840136811115611320575f5ffd5b845b818110156112885780356001600160401b0381111561133f575f5ffd5b86016060368290031215611351575f5ffd5b6113596110eb565b8135815260208201356001600160401b03811115611375575f5ffd5b61138136828501611293565b60208301525060408201356001600160401b0381111561139f575f5ffd5b919091019036601f8301126113b2575f5ffd5b81356113c061117282611143565b8082825260208201915060208360051b8601019250368311156113e1575f5ffd5b6020850194505b8285101561141257843561ffff81168114611401575f5ffd5b8252602094850194909101906113e8565b6040840152505085525060209384019301611322565b5f61143561117284611143565b8381526020810190600585901b840136811115611450575f5ffd5b845b818110156112885780356001600160401b0381111561146f575f5ffd5b61147b36828901611293565b85525060209384019301611452565b60ff828116828216039081111561106057611060611033565b808201808211156110605761106061103356fea2646970667358221220c9c8e469f26d979598bb9811df8c27c60f981d09c31821da042b28130cb61da964736f6c634300081b0033",
+	Bin: "0x3415600957600080fd5b612012806100176000396000f361206060405234156100115760006000fd5b60043610156100205760006000fd5b63f7530b4160003560e01c1415156100385760006000fd5b60843610156100475760006000fd5b610064606435604435600401602435600401600435600401610c2c565b60006000f3005b60805260a052600060c05260406080510135608051013560c0525b60c05160a051565b60e05261010052600061012052604060e051013560e05101610120525b6101205161010051565b610140526101605260006101805260606101405101356101405101610180525b6101805161016051565b6101a0526101c05260006101e05260806101a05101356101e05260016101e051111561010b5760006000fd5b5b6101e0516101c051565b610200526102205260006102405260406102005101356102005101610240525b6102405161022051565b61026052610280526102a05260006102c05261026051356102805110151561018a577f696e6465780000000000000000000000000000000000000000000000000000006005611ff6565b6020610280510260206102605101013560206102605101016102c0525b6102c0516102a051565b6102e052610300526103205260006103405260405161036052610300516102e0516103605137610300516103605120610340525b6103405161032051565b610380526103a0526103c05260006103e0526103805135610400526103a051356104005114156102455761022c6104005160206103a051016101b1565b61023f61040051602061038051016101b1565b146103e0525b5b6103e0516103c051565b61042052610440526104605260006104805261042051356104a05261044051356104a05114156102ac5761029060206104a05102602061044051016101b1565b6102a660206104a05102602061042051016101b1565b14610480525b5b6104805161046051565b6104c0526104e052610500526104c051356104c05101610520526104e051356104e05101610540526105205135610560526105405135610560511461031e577f41737365745b5d3a20756e657175616c206c656e6774680000000000000000006017611ff6565b6000610580525b6105605161058051101561042e576103436105805161052051610140565b6105a0526103576105805161054051610140565b6105c0526105c051356105a0513514610392577f756e657175616c20636861696e49440000000000000000000000000000000000600f611ff6565b60206105c051013560206105a0510135146103cf577f756e657175616c20657468486f6c6465720000000000000000000000000000006011611ff6565b6103f160406105c05101356105c0510160406105a05101356105a051016101ef565b61041d577f756e657175616c206363486f6c646572000000000000000000000000000000006010611ff6565b5b6001610580510161058052610325565b5b61050051565b6105e052610600526106205260606105e05101356105e05101610640526060610600510135610600510161066052610640513561068052610660513561068051146104a2577f537562416c6c6f635b5d3a20756e657175616c206c656e677468000000000000601a611ff6565b60006106a0525b610680516106a05110156105c3576104c76106a05161064051610140565b6106c0526104db6106a05161066051610140565b6106e0526106e051356106c0513514610516577f537562416c6c6f633a20756e657175616c2049440000000000000000000000006014611ff6565b61053860206106e05101356106e0510160206106c05101356106c05101610250565b610564577f75696e743235365b5d3a20756e657175616c206974656d0000000000000000006017611ff6565b61058660406106e05101356106e0510160406106c05101356106c05101610250565b6105b2577f75696e7431365b5d3a20756e657175616c206974656d000000000000000000006016611ff6565b5b60016106a051016106a0526104a9565b5b61062051565b610700526107205260006107405260405161074052601f19601f61070051011661074051016040525b6107405161072051565b610760526107805260006107a05261076051356107c05261062360206107c051016105ca565b6107a0526107c0516107a051526107c0516020610760510160206107a05101375b6107a05161078051565b6107e05261080052610820526000610840526107e0515161080051101515610698577f696e6465780000000000000000000000000000000000000000000000000000006005611ff6565b6108005160206107e05101015160001a610840525b6108405161082051565b61086052610880526108a0526108c0526108605151610880511015156106ff577f696e6465780000000000000000000000000000000000000000000000000000006005611ff6565b6108a051610880516020610860510101535b6108c051565b6108e05261090052610920526109405260006109605261090051610980525b61092051610900510161098051101561077657610759610980516108e05161064e565b6109605160081b17610960525b6001610980510161098052610736565b5b6109605161094051565b6109a0526109c05260006109e0526109a0515160206109a05101206109e0525b6109e0516109c051565b610a0052610a20526000610a4052610a005135610a60526107d460206001610a605101026105ca565b610a4052610a6051610a4051526000610a80525b610a6051610a8051101561085b57610806610a8051610a0051610140565b610aa05260206001610aa051350102610ac052610825610ac0516105ca565b610ae052610ac051610aa051610ae05137610ae05160206001610a80510102610a405101525b6001610a805101610a80526107e8565b5b610a4051610a2051565b610b0052610b2052610b40526000610b6052610b005151610b20511015156108b0577f696e6465780000000000000000000000000000000000000000000000000000006005611ff6565b60206001610b20510102610b00510151610b60525b610b6051610b4051565b610b8052610ba052610bc052610be0526000610c00526108f5610ba051610b8051610866565b610c2052610c205151610bc051101515610931577f696e6465780000000000000000000000000000000000000000000000000000006005611ff6565b60206001610bc0510102610c20510151610c00525b610c0051610be051565b610c4052610c6052610c8052610ca052610cc052610974610c6051610c4051610866565b610ce052610ce05151610c80511015156109b0577f696e6465780000000000000000000000000000000000000000000000000000006005611ff6565b610ca05160206001610c80510102610ce05101525b610cc051565b610d0052610d2052610d4052610d005135610d6052610d205151610d605114610a16577f75696e743235365b5d5b5d3a20756e657175616c206c656e6774680000000000601b611ff6565b6000610d80525b610d6051610d80511015610af557610a3b610d8051610d0051610140565b610da052610a4f610d8051610d2051610866565b610dc052610da05135610de052610dc05151610de05114610a92577f75696e743235365b5d3a20756e657175616c206c656e677468000000000000006019611ff6565b6020610de051026020610dc0510120610ab76020610de051026020610da051016101b1565b14610ae4577f75696e743235365b5d3a20756e657175616c206974656d0000000000000000006017611ff6565b5b6001610d805101610d8052610a1d565b5b610d4051565b610e0052610e2052610e40526000610e6052610e2051610e005101610e6052610e0051610e60511015610b51577f6f766572666c6f770000000000000000000000000000000000000000000000006008611ff6565b5b610e6051610e4051565b610e8052610ea052610ec0526000610ee052610ea051610e80511015610ba4577f756e646572666c6f7700000000000000000000000000000000000000000000006009611ff6565b610ea051610e805103610ee0525b610ee051610ec051565b610f0052610f2052610f40526000610f6052610f2051610f005102610f6052610f005115610c2157610f2051610f0051610f605104141515610c20577f6f766572666c6f770000000000000000000000000000000000000000000000006008611ff6565b5b5b610f6051610f4051565b610f8052610fa052610fc052610fe05261100052610c54610c4f610fa0516100b5565b6105fd565b61102052610c6c610c67610fc0516100b5565b6105fd565b61104052610c7c611020516110e6565b610c88611040516110e6565b610c94611020516111cb565b61106052610ca461102051611289565b610cb0610f805161006b565b14610cdd577f6e756d626572206f66207061727469636970616e7473000000000000000000006016611ff6565b6002610fe05110610d10577f686f757365206d757374206e6f742061637400000000000000000000000000006012611ff6565b610d1c6110205161124f565b6110605101611020515114610d53577f64617461206c656e677468000000000000000000000000000000000000000000600b611ff6565b6110205151611040515114610d8a577f64617461206c656e677468000000000000000000000000000000000000000000600b611ff6565b610d98600a6110205161064e565b611080526001611080511115610dd0577f666f726665697420666c61670000000000000000000000000000000000000000600c611ff6565b610dde60016110205161064e565b6110a052610fe0516110a051141515610e1257610e0d610fe0516110405161102051610fc051610fa051611aac565b6110e0565b610e226008600261102051610717565b610e326008600261104051610717565b1015610e60577f646561646c696e650000000000000000000000000000000000000000000000006008611ff6565b610e706002601461104051610717565b6110c052610e806110205161124f565b6110c051111560006110c0511116610eba577f6669656c640000000000000000000000000000000000000000000000000000006005611ff6565b60016110c051036110e052610eda6110e05161106051016110205161064e565b15610f07577f6f766572777269746500000000000000000000000000000000000000000000006009611ff6565b610f1b610f16610fa0516100b5565b6105fd565b61110052610f2f6000600a611100516106b7565b610f476110a0516110e05161106051611100516112cf565b6111205261114052611160526002611180525b600a611180511015610f9757610f86610f79611180516110405161064e565b61118051611100516106b7565b5b6001611180510161118052610f5a565b610fa361104051610781565b610faf61110051610781565b14610fdc577f64617461000000000000000000000000000000000000000000000000000000006004611ff6565b61116051610fec610fc0516100df565b14611019577f66696e616c20666c616700000000000000000000000000000000000000000000600a611ff6565b611025610fa05161008e565b6111a052611035610fc05161008e565b6111c0526110496111a0516111c0516102b7565b6110596111a0516111c051610435565b61106d6110686111a051610116565b6107ab565b6111e05261108051156110a9576110a8600260016110a051010661109b611096610fa0516100b5565b6105fd565b611020516111e051611e67565b5b61112051156110c7576110c661114051611020516111e051611840565b5b6110df6111e0516110da6111c051610116565b6109cb565b5b61100051565b61120052611220526000611200515111611122577f646174612076657273696f6e0000000000000000000000000000000000000000600c611ff6565b600261113260006112005161064e565b1461115f577f646174612076657273696f6e0000000000000000000000000000000000000000600c611ff6565b5b61122051565b611240526112605260006112805260166112405151116111a8577f64617461206c656e677468000000000000000000000000000000000000000000600b611ff6565b6111b660166112405161064e565b602002601701611280525b6112805161126051565b6112a0526112c05260006112e0526111e56112a051611166565b61130052600361130051016112a0515111611222577f64617461206c656e677468000000000000000000000000000000000000000000600b611ff6565b611235600361130051016112a05161064e565b60200260046113005101016112e0525b6112e0516112c051565b611320526113405260006113605261126b600c6113205161064e565b611279600b6113205161064e565b02611360525b6113605161134051565b611380526113a05260006113c05260026113c05260036112b76112ae61138051611166565b6113805161064e565b14156112c45760036113c0525b5b6113c0516113a051565b6113e0526114005261142052611440526114605260006114805260006114a05260006114c052611311600161144051016114205161140051016113e0516106b7565b6113296001611420510160081c60146113e0516106b7565b61133e6001611420510160156113e0516106b7565b6113566002600161144051010660016113e0516106b7565b60006114e05261137061142051611400516113e05161153a565b6114e052611480526114a0526114e051151561138b57611470565b61148051156113be576113bd60016113ac6114a0516012016113e05161064e565b016114a0516012016113e0516106b7565b5b6113ca6113e051611482565b6114c0526114c051151561146f57600260016113ea60116113e05161064e565b01066115005261140f600161140360106113e05161064e565b0160106113e0516106b7565b6114216115005160116113e0516106b7565b6114336115005160016113e0516106b7565b61140051611520525b6113e0515161152051101561146e5761145d6000611520516113e0516106b7565b5b600161152051016115205261143c565b5b5b6114c0516114a0516114805161146051565b611540526115605260006115805261149e600f6115405161064e565b6115a0526115a05160016114b660106115405161064e565b011015156114c957600161158052611530565b60016114d9600e6115405161064e565b141561152f5760006115c0525b60026115c051101561152e5760026115a0510461150c6115c0516012016115405161064e565b111561151d57600161158052611530565b5b60016115c051016115c0526114e6565b5b5b6115805161156051565b6115e05261160052611620526116405260006116605260006116805260006116a0526115716116205161160051016115e05161064e565b6116c0526116c0511561166e5761158c600c6115e05161064e565b6116e0526116e0516116205106611700526116e0516116205104611720526000611740525b600461174051101561166d576115c9611740516116cb565b61176052611780526115fb6116c05161178051600003611760516000036117205161170051611600516115e051611751565b61161f6116c05161178051611760516117205161170051611600516115e051611751565b600101016117a052611635600d6115e05161064e565b6117a05110151561165c5760016116605260016116805260016116c051036116a0526116b9565b5b60016117405101611740526115b1565b5b611600516117c0525b6115e051516117c05110156116b2576116966117c0516115e05161064e565b15156116a1576116b9565b5b60016117c051016117c052611677565b6001611660525b6116a051611680516116605161164051565b6117e052611800526000611820526000611840526117e0516118605261186051600014156116fe57600161182052611742565b611860516001141561171557600161184052611742565b611860516002141561173257600161182052600161184052611742565b6001611820526001600003611840525b5b611840516118205161180051565b611880526118a0526118c0526118e05261190052611920526119405261196052600061198052611785600b6118805161064e565b6119a052611797600c6118805161064e565b6119c052611900516118c051016118c052611920516118e051016118e0525b600115611835576119a0516118e051106119c0516118c051101615156117db57611835565b611940516117fe6118c0516119c0516118e05102016118a051016118805161064e565b14151561180a57611835565b6001611980510161198052611900516118c051016118c052611920516118e051016118e0525b6117b6565b5b6119805161196051565b6119e052611a0052611a2052611a40526119e051516118636016611a005161064e565b14611890577f6e756d626572206f6620726f756e64207374616b6573000000000000000000006016611ff6565b611a2051600103611a60526000611a80525b6119e05151611a80511015611990576118c1611a8051611a0051611997565b611aa052611ac0526118f5611ac0516118f0611aa0516118eb611a2051611a80516119e0516108cf565b610afc565b610b5c565b611ae05261192d61191c611aa051611917611a6051611a80516119e0516108cf565b610b5c565b611a6051611a80516119e051610950565b611945611ae051611a2051611a80516119e051610950565b611ac0511561197f5761197e61196f611ac05161196a6002611a80516119e0516108cf565b610afc565b6002611a80516119e051610950565b5b5b6001611a805101611a80526118a2565b5b611a4051565b611b0052611b2052611b40526000611b60526000611b80526119bb611b0051611166565b611ba0526119d76020611b2051602002601701611b0051610717565b611bc0526119f060026001611ba05101611b0051610717565b611be052611a04611ba051611b005161064e565b611c0052611c005160011415611a3a57611a316020611b20516020026004611ba0510101611b0051610717565b611b6052611a9d565b611c005160021415611a6357612710611a59611be051611bc051610bbc565b04611b6052611a9d565b611c005160031415611a9457611bc051611b6052612710611a8a611be051611bc051610bbc565b04611b8052611a9d565b611bc051611b60525b5b611b8051611b6051611b4051565b611c2052611c4052611c6052611c8052611ca052611cc052611ad2600a611c605161064e565b15611aff577f666f72666569743a20616c726561647920636c61696d656400000000000000006018611ff6565b611b13611b0e611c20516100b5565b6105fd565b611ce052611b276001600a611ce0516106b7565b611b33611ce051610781565b611b3f611c8051610781565b14611b6c577f666f72666569743a206461746100000000000000000000000000000000000000600d611ff6565b611b78611c40516100df565b15611ba5577f666f72666569743a2066696e616c20666c6167000000000000000000000000006013611ff6565b611bb1611c205161008e565b611d0052611bc1611c405161008e565b611d2052611bd5611d0051611d20516102b7565b611be5611d0051611d2051610435565b611bf9611bf4611d0051610116565b6107ab565b611d4052611c25611ca051611c18611c13611c20516100b5565b6105fd565b611c6051611d4051611cd8565b611c3d611d4051611c38611d2051610116565b6109cb565b5b611cc051565b611d6052611d8052611da0526000611dc0525b600115611ccd576001611dc05101611dc052611c926001611c81611d8051601201611d605161064e565b01611d8051601201611d60516106b7565b611c9e611d6051611482565b15611ca857611ccd565b611cc76001611cbb6010611d605161064e565b016010611d60516106b7565b5b611c57565b5b611dc051611da051565b611de052611e0052611e2052611e4052611e6052611de05151611cff6016611e005161064e565b14611d2c577f6e756d626572206f6620726f756e64207374616b6573000000000000000000006016611ff6565b611d3c611e4051611e2051611c44565b611e8052611e4051600103611ea0526000611ec0525b611de05151611ec0511015611e6057611d71611ec051611e0051611997565b611ee052611f0052611d89611ee051611e8051610bbc565b611ee052611d9d611f0051611e8051610bbc565b611f0052611dd5611dc4611ee051611dbf611ea051611ec051611de0516108cf565b610b5c565b611ea051611ec051611de051610950565b611e15611e04611deb611f0051611ee051610b5c565b611dff611e4051611ec051611de0516108cf565b610afc565b611e4051611ec051611de051610950565b611f005115611e4f57611e4e611e3f611f0051611e3a6002611ec051611de0516108cf565b610afc565b6002611ec051611de051610950565b5b5b6001611ec05101611ec052611d52565b5b611e6051565b611f2052611f4052611f6052611f8052611fa052611f205151611e8e6016611f405161064e565b14611ebb577f6e756d626572206f6620726f756e64207374616b6573000000000000000000006016611ff6565b611ecb611f8051611f6051611c44565b611fc052611f8051600103611fe0526000612000525b611f205151612000511015611fef57611f0061200051611f4051611997565b6120205261204052611f1861202051611fc051610bbc565b61202052611f2c61204051611fc051610bbc565b61204052611f64611f5361202051611f4e611fe05161200051611f20516108cf565b610afc565b611fe05161200051611f2051610950565b611fa4611f93611f7a6120405161202051610b5c565b611f8e611f805161200051611f20516108cf565b610b5c565b611f805161200051611f2051610950565b6120405115611fde57611fdd611fce61204051611fc9600261200051611f20516108cf565b610b5c565b600261200051611f2051610950565b5b5b6001612000510161200052611ee1565b5b611fa051565b6308c379a060e01b600052602060045260245260445260646000fd",
 }
 
 // TicTacToeAppABI is the input ABI used to generate the binding from.
@@ -0,0 +1,164 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	ethchannel "github.com/perun-network/perun-eth-backend/channel"
+	swallet "github.com/perun-network/perun-eth-backend/wallet/simple"
+	"github.com/pkg/errors"
+
+	"perun.network/perun-examples/app-channel/client"
+	"perun.network/perun-examples/app-channel/contracts/generated/rockPaperScissorsApp"
+	"perun.network/perun-examples/app-channel/contracts/generated/ticTacToeApp"
+	"perun.network/perun-examples/shared/registry"
+)
+
+// The names of the contracts in the registry.
+const (
+	AdjudicatorName          = "adjudicator"
+	AssetHolderETHName       = "assetHolderETH"
+	TicTacToeAppName         = "ticTacToeApp"
+	RockPaperScissorsAppName = "rockPaperScissorsApp"
+)
+
+// appGasLimit must be sufficient for deploying the app contracts.
+const appGasLimit = 3000000
+
+// Contracts are the contracts of the app channel on a chain.
+type Contracts struct {
+	Adjudicator          common.Address
+	AssetHolder          common.Address // The ETH asset holder.
+	App                  common.Address // The tic-tac-toe app.
+	RockPaperScissorsApp common.Address // The rock-paper-scissors app.
+}
+
+// Deploy deploys the contracts of the app channel on the chain of the
+// backend and records them in the registry. Contracts that are recorded and
+// deployed are reused. A contract is deployed anew if its code is missing,
+// e.g., after a development chain was restarted, or if a contract it depends
+// on was deployed anew.
+func Deploy(ctx context.Context, cb ethchannel.ContractBackend, deployer accounts.Account, r *registry.Registry) (Contracts, error) {
+	adj, newAdj, err := r.Ensure(ctx, cb, AdjudicatorName, false, func() (common.Address, error) {
+		return ethchannel.DeployAdjudicator(ctx, cb, deployer)
+	})
+	if err != nil {
+		return Contracts{}, err
+	}
+	ah, _, err := r.Ensure(ctx, cb, AssetHolderETHName, newAdj, func() (common.Address, error) {
+		return ethchannel.DeployETHAssetholder(ctx, cb, adj.Address, deployer)
+	})
+	if err != nil {
+		return Contracts{}, err
+	}
+	app, _, err := r.Ensure(ctx, cb, TicTacToeAppName, false, func() (common.Address, error) {
+		return deployApp(ctx, cb, deployer, ticTacToeApp.DeployTicTacToeApp)
+	})
+	if err != nil {
+		return Contracts{}, err
+	}
+	rps, _, err := r.Ensure(ctx, cb, RockPaperScissorsAppName, false, func() (common.Address, error) {
+		return deployApp(ctx, cb, deployer, rockPaperScissorsApp.DeployRockPaperScissorsApp)
+	})
+	if err != nil {
+		return Contracts{}, err
+	}
+	return Contracts{Adjudicator: adj.Address, AssetHolder: ah.Address, App: app.Address, RockPaperScissorsApp: rps.Address}, nil
+}
+
+// deployApp deploys an app contract with the deploy function of its
+// generated bindings and waits until it is deployed.
+func deployApp[T any](
+	ctx context.Context,
+	cb ethchannel.ContractBackend,
+	deployer accounts.Account,
+	deploy func(*bind.TransactOpts, bind.ContractBackend) (common.Address, *types.Transaction, T, error),
+) (common.Address, error) {
+	tops, err := cb.NewTransactor(ctx, appGasLimit, deployer)
+	if err != nil {
+		return common.Address{}, errors.WithMessage(err, "creating transactor")
+	}
+	_, tx, _, err := deploy(tops, cb)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return bind.WaitDeployed(ctx, cb, tx)
+}
+
+// Lookup returns the contracts recorded for the chain and verifies that
+// their code is deployed.
+func Lookup(ctx context.Context, backend bind.ContractBackend, chainID *big.Int, r *registry.Registry) (c Contracts, err error) {
+	if c.Adjudicator, err = r.Lookup(ctx, backend, chainID, AdjudicatorName); err != nil {
+		return c, err
+	}
+	if c.AssetHolder, err = r.Lookup(ctx, backend, chainID, AssetHolderETHName); err != nil {
+		return c, err
+	}
+	if c.App, err = r.Lookup(ctx, backend, chainID, TicTacToeAppName); err != nil {
+		return c, err
+	}
+	if c.RockPaperScissorsApp, err = r.Lookup(ctx, backend, chainID, RockPaperScissorsAppName); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// DeployFile deploys the contracts on the specified ledger using the account
+// of the given private key and records them in the registry file at path.
+// The registry is saved also if a deployment fails.
+func DeployFile(nodeURL string, chainID uint64, privateKey, path string) (Contracts, error) {
+	k, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return Contracts{}, errors.WithMessage(err, "parsing private key")
+	}
+	cb, err := client.CreateContractBackend(nodeURL, chainID, swallet.NewWallet(k))
+	if err != nil {
+		return Contracts{}, errors.WithMessage(err, "creating contract backend")
+	}
+	acc := accounts.Account{Address: crypto.PubkeyToAddress(k.PublicKey)}
+
+	r, err := registry.Load(path)
+	if err != nil {
+		return Contracts{}, err
+	}
+	c, err := Deploy(context.TODO(), cb, acc, r)
+	if serr := r.Save(path); serr != nil && err == nil {
+		err = serr
+	}
+	return c, err
+}
+
+// LoadFile loads the contracts of the specified ledger from the registry
+// file at path and verifies that they are deployed.
+func LoadFile(nodeURL string, chainID uint64, path string) (Contracts, error) {
+	r, err := registry.Load(path)
+	if err != nil {
+		return Contracts{}, err
+	}
+	ethClient, err := ethclient.Dial(nodeURL)
+	if err != nil {
+		return Contracts{}, errors.WithMessage(err, "connecting to node")
+	}
+	defer ethClient.Close()
+	return Lookup(context.TODO(), ethClient, new(big.Int).SetUint64(chainID), r)
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package differential
+
+import (
+	"math/big"
+	"math/rand"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethchannel "github.com/perun-network/perun-eth-backend/channel"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/wallet"
+)
+
+// chainID is the chain ID of the generated assets.
+var chainID = big.NewInt(1337)
+
+// NewParams returns the parameters of a ledger channel running the app with
+// numParts random participants.
+func NewParams(rng *rand.Rand, app channel.App, challengeDuration uint64, numParts int) *channel.Params {
+	parts := make([]map[wallet.BackendID]wallet.Address, numParts)
+	for i := range parts {
+		parts[i] = map[wallet.BackendID]wallet.Address{ethwallet.BackendID: ethwallet.AsWalletAddr(randomAddress(rng))}
+	}
+	nonce := new(big.Int).SetUint64(rng.Uint64() | 1)
+	return channel.NewParamsUnsafe(challengeDuration, parts, app, nonce, true, false, channel.ZeroAux)
+}
+
+// NewState returns an initial state of a channel with the given parameters
+// holding one Ethereum asset per entry of bals.
+func NewState(rng *rand.Rand, params *channel.Params, data channel.Data, bals ...[]channel.Bal) *channel.State {
+	assets := make([]channel.Asset, len(bals))
+	backends := make([]wallet.BackendID, len(bals))
+	for i := range assets {
+		assets[i] = ethchannel.NewAsset(chainID, randomAddress(rng))
+		backends[i] = ethwallet.BackendID
+	}
+	alloc := channel.NewAllocation(len(params.Parts), backends, assets...)
+	for i, b := range bals {
+		alloc.Balances[i] = b
+	}
+	return &channel.State{
+		ID:         params.ID(),
+		App:        params.App,
+		Allocation: *alloc,
+		Data:       data,
+	}
+}
+
+func randomAddress(rng *rand.Rand) (addr common.Address) {
+	rng.Read(addr[:])
+	return addr
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package differential
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	ethchannel "github.com/perun-network/perun-eth-backend/channel"
+	"github.com/pkg/errors"
+	"perun.network/go-perun/channel"
+)
+
+// blockGasLimit is the gas available to a call. A transition that needs more
+// gas cannot be enforced on-chain.
+const blockGasLimit = 30_000_000
+
+// Contract is an app contract deployed on an in-memory EVM.
+type Contract struct {
+	cfg  *runtime.Config
+	abi  *abi.ABI
+	addr common.Address
+}
+
+// DeployContract deploys the contract described by the generated binding
+// metadata on a fresh in-memory EVM.
+func DeployContract(meta *bind.MetaData) (*Contract, error) {
+	contractABI, err := meta.GetAbi()
+	if err != nil {
+		return nil, errors.WithMessage(err, "parsing ABI")
+	}
+
+	cfg := &runtime.Config{GasLimit: blockGasLimit}
+	_, addr, _, err := runtime.Create(common.FromHex(meta.Bin), cfg)
+	if err != nil {
+		return nil, errors.WithMessage(err, "deploying contract")
+	}
+	return &Contract{cfg: cfg, abi: contractABI, addr: addr}, nil
+}
+
+// ValidTransition calls validTransition on the contract. It returns an error
+// containing the revert reason if the contract rejects the transition.
+func (c *Contract) ValidTransition(params *channel.Params, from, to *channel.State, idx channel.Index) error {
+	input, err := c.abi.Pack("validTransition",
+		ethchannel.ToEthParams(params),
+		ethchannel.ToEthState(from),
+		ethchannel.ToEthState(to),
+		new(big.Int).SetUint64(uint64(idx)))
+	if err != nil {
+		return errors.WithMessage(err, "packing arguments")
+	}
+
+	ret, _, err := runtime.Call(c.addr, input, c.cfg)
+	if errors.Is(err, vm.ErrExecutionReverted) {
+		if reason, err := abi.UnpackRevert(ret); err == nil {
+			return fmt.Errorf("reverted: %s", reason)
+		}
+		return fmt.Errorf("reverted")
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package differential checks that the Go implementation of a channel app and
+// its app contract agree on which state transitions are valid.
+//
+// The off-chain logic in Go and the on-chain logic in Solidity are written
+// separately. If they disagree, a state that was accepted off-chain may not be
+// enforceable on-chain, or the other way round. The Harness runs generated
+// transitions through both and reports every disagreement.
+package differential
+
+import (
+	"fmt"
+	"math/rand"
+
+	"perun.network/go-perun/channel"
+)
+
+// Transition is a state transition performed by the participant Actor.
+type Transition struct {
+	Params   *channel.Params
+	From, To *channel.State
+	Actor    channel.Index
+}
+
+// Generator generates a transition. It returns nil if it did not produce a
+// transition, e.g., because a mutated state could not be decoded.
+type Generator func(rng *rand.Rand) *Transition
+
+// Disagreement is a transition on which the app and the contract disagree.
+// A panic in the app is always reported as a disagreement, as the app must
+// reject invalid transitions gracefully.
+type Disagreement struct {
+	*Transition
+	AppErr      error // The error returned by the app, nil if accepted.
+	ContractErr error // The error returned by the contract, nil if accepted.
+}
+
+func (d *Disagreement) Error() string {
+	return fmt.Sprintf("app: %v, contract: %v, transition by %d from\n%v\nto\n%v",
+		d.AppErr, d.ContractErr, d.Actor, d.From, d.To)
+}
+
+// Harness compares an app with its deployed app contract.
+type Harness struct {
+	App      channel.StateApp
+	Contract *Contract
+}
+
+// Check runs the transition through the app and the contract and returns a
+// Disagreement if only one of them accepts it.
+func (h *Harness) Check(t *Transition) *Disagreement {
+	panicked, appErr := h.appValidTransition(t)
+	contractErr := h.Contract.ValidTransition(t.Params, t.From, t.To, t.Actor)
+	if panicked || (appErr == nil) != (contractErr == nil) {
+		return &Disagreement{Transition: t, AppErr: appErr, ContractErr: contractErr}
+	}
+	return nil
+}
+
+// Run checks n transitions produced by gen and returns all disagreements.
+func (h *Harness) Run(rng *rand.Rand, n int, gen Generator) (ds []*Disagreement) {
+	for i := 0; i < n; i++ {
+		t := gen(rng)
+		if t == nil {
+			continue
+		}
+		if d := h.Check(t); d != nil {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
+
+// appValidTransition calls ValidTransition on the app and recovers from
+// panics.
+func (h *Harness) appValidTransition(t *Transition) (panicked bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked, err = true, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return false, h.App.ValidTransition(t.Params, t.From, t.To, t.Actor)
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package differential_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/perun-network/perun-eth-backend/bindings/trivialapp"
+	ethchannel "github.com/perun-network/perun-eth-backend/channel"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+	"perun.network/perun-examples/app-channel/differential"
+)
+
+// TestHarness compares the mock app, whose behavior is given by its data,
+// with the trivial app contract, which accepts every transition.
+func TestHarness(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	contract, err := differential.DeployContract(trivialapp.TrivialappMetaData)
+	require.NoError(t, err)
+
+	mockApp := channel.NewMockApp(&ethchannel.AppID{Address: ethwallet.AsWalletAddr(common.Address{1})})
+	h := differential.Harness{App: mockApp, Contract: contract}
+	params := differential.NewParams(rng, mockApp, 60, 2)
+	bals := []channel.Bal{big.NewInt(10), big.NewInt(10)}
+
+	for op, disagree := range map[channel.MockOp]bool{
+		channel.OpValid:         false,
+		channel.OpErr:           true,
+		channel.OpTransitionErr: true,
+		channel.OpPanic:         true,
+	} {
+		from := differential.NewState(rng, params, channel.NewMockOp(op), bals)
+		to := from.Clone()
+		to.Version++
+		d := h.Check(&differential.Transition{Params: params, From: from, To: to, Actor: 0})
+		require.Equal(t, disagree, d != nil, "op %d: %v", op, d)
+	}
+}
@@ -33,20 +33,24 @@ const (
 	chainID  = 1337
 
 	// Private keys.
-	keyDeployer = "79ea8f62d97bc0591a4224c1725fca6b00de5b2cea286fe2e0bb35c5e76be46e"
-	keyAlice    = "1af2e950272dd403de7a5760d41c6e44d92b6d02797e51810795ff03cc2cda4f"
-	keyBob      = "f63d7d8e930bccd74e93cf5662fde2c28fd8be95edb70c73f1bdd863d07f412e"
+	keyAlice = "1af2e950272dd403de7a5760d41c6e44d92b6d02797e51810795ff03cc2cda4f"
+	keyBob   = "f63d7d8e930bccd74e93cf5662fde2c28fd8be95edb70c73f1bdd863d07f412e"
+
+	transcriptFile = "transcript.json"
 )
 
 // main runs a demo of the game client. It assumes that a blockchain node is
-// available at `chainURL` and that the accounts corresponding to the specified
-// secret keys are provided with sufficient funds.
+// available at `chainURL`, that the contracts were deployed with `cmd/deploy`
+// and that the accounts corresponding to the specified secret keys are
+// provided with sufficient funds.
 func main() {
-	// Deploy contracts.
-	log.Println("Deploying contracts.")
-	adjudicator, assetHolder, appAddress := deployContracts(chainURL, chainID, keyDeployer)
-	asset := *ethwallet.AsWalletAddr(assetHolder)
-	app := app.NewTicTacToeApp(ethwallet.AsWalletAddr(appAddress))
+	// Load the deployed contracts.
+	log.Println("Loading contracts.")
+	contracts := loadContracts(chainURL, chainID)
+	adjudicator := contracts.Adjudicator
+	asset := *ethwallet.AsWalletAddr(contracts.AssetHolder)
+	ticTacToeApp := app.NewTicTacToeApp(ethwallet.AsWalletAddr(contracts.App))
+	rpsApp := app.NewRockPaperScissorsApp(ethwallet.AsWalletAddr(contracts.RockPaperScissorsApp))
 
 	// Setup bus.
 	aliceWireAcc := p2p.NewRandomAccount(rand.New(rand.NewSource(time.Now().UnixNano())))
@@ -59,8 +63,10 @@ func main() {
 	// Setup clients.
 	log.Println("Setting up clients.")
 	stake := client.EthToWei(big.NewFloat(5))
-	alice := setupGameClient(aliceBus, chainURL, adjudicator, asset, keyAlice, app, stake, aliceWireAcc.Address())
-	bob := setupGameClient(bobBus, chainURL, adjudicator, asset, keyBob, app, stake, bobWireAcc.Address())
+	ticTacToe := client.NewTicTacToeGame(ticTacToeApp, stake, app.DefaultBoardLimits)
+	rps := client.NewRockPaperScissorsGame(rpsApp, stake)
+	alice := setupGameClient(aliceBus, chainURL, adjudicator, asset, keyAlice, aliceWireAcc.Address(), ticTacToe, rps)
+	bob := setupGameClient(bobBus, chainURL, adjudicator, asset, keyBob, bobWireAcc.Address(), ticTacToe, rps)
 
 	// Print balances before transactions.
 	l := newBalanceLogger(chainURL)
@@ -68,8 +74,8 @@ func main() {
 
 	// Open app channel and play.
 	log.Println("Opening channel.")
-	appAlice := alice.OpenAppChannel(bob.WireAddress())
-	appBob := bob.AcceptedChannel()
+	appAlice := alice.OpenTicTacToeChannel(bob.WireAddress(), ticTacToe, app.DefaultBoardConfig, app.SingleGame, app.WinnerTakesAllPayout)
+	appBob := bob.AcceptedChannel().(*client.TicTacToeChannel)
 
 	log.Println("Start playing.")
 	log.Println("Alice's turn.")
@@ -104,6 +110,12 @@ func main() {
 	// Print balances after transactions.
 	l.LogBalances(alice, bob)
 
+	// Export the game for spectators.
+	if err := alice.ExportTranscript(appAlice, transcriptFile); err != nil {
+		panic(err)
+	}
+	log.Printf("Transcript exported to %s. Verify it with: go run ./cmd/verify %s", transcriptFile, transcriptFile)
+
 	// Cleanup.
 	alice.Shutdown()
 	bob.Shutdown()
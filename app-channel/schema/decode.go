@@ -0,0 +1,103 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Value is a decoded field.
+type Value struct {
+	Field
+	Index int      // The index of the first byte of the field in the data.
+	Elems [][]byte // The encoded elements.
+}
+
+// Uint returns element i as an unsigned integer.
+func (v Value) Uint(i int) *big.Int {
+	return new(big.Int).SetBytes(v.Elems[i])
+}
+
+func (v Value) String() string {
+	elems := make([]string, len(v.Elems))
+	for i, e := range v.Elems {
+		if v.Type == Bytes32 {
+			elems[i] = fmt.Sprintf("0x%x", e)
+		} else {
+			elems[i] = v.Uint(i).String()
+		}
+	}
+	return fmt.Sprintf("%s: %s", v.Name, strings.Join(elems, ", "))
+}
+
+// Decode splits the data into the fields of the schema. It fails if the
+// version does not match, if the data is too short or if bytes are left over.
+func (s *Schema) Decode(data []byte) ([]Value, error) {
+	if err := s.ReadVersion(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	pos := VersionIndex + 1
+	values := make([]Value, 0, len(s.Fields))
+	counts := make(map[string]int)
+	for _, f := range s.Fields {
+		n := 1
+		switch {
+		case f.Count > 0:
+			n = f.Count
+		case f.CountField != "":
+			n = counts[f.CountField]
+		case f.Rest:
+			if (len(data)-pos)%f.Type.Size() != 0 {
+				return nil, fmt.Errorf("%s: %d remaining bytes are not a multiple of %d", f.Name, len(data)-pos, f.Type.Size())
+			}
+			n = (len(data) - pos) / f.Type.Size()
+		}
+
+		v := Value{Field: f, Index: pos, Elems: make([][]byte, n)}
+		for i := range v.Elems {
+			end := pos + f.Type.Size()
+			if end > len(data) {
+				return nil, fmt.Errorf("%s: data too short: %d bytes", f.Name, len(data))
+			}
+			v.Elems[i], pos = data[pos:end], end
+		}
+		if f.Type == Uint8 && n == 1 {
+			counts[f.Name] = int(v.Elems[0][0])
+		}
+		values = append(values, v)
+	}
+	if pos != len(data) {
+		return nil, fmt.Errorf("%d trailing bytes", len(data)-pos)
+	}
+	return values, nil
+}
+
+// Describe returns a description of the data with one field per line.
+func (s *Schema) Describe(data []byte) (string, error) {
+	values, err := s.Decode(data)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s data version %d\n", s.Name, s.Version)
+	for _, v := range values {
+		fmt.Fprintf(&b, "[%d] %v\n", v.Index, v)
+	}
+	return b.String(), nil
+}
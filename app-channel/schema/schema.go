@@ -0,0 +1,217 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema describes the binary layout of app data. The encoded data
+// starts with a version byte, followed by the fields of the schema in order.
+// Multi-byte integers are big-endian.
+//
+// A schema is the single description of a layout: the Solidity constants and
+// index functions of the app contracts are generated from it, the Go
+// encodings are tested against it and encoded data can be described with it.
+// Changing a layout requires a new schema version, so that data of another
+// version is rejected by both the Go app and the contract.
+package schema
+
+import (
+	"fmt"
+	"io"
+)
+
+// Type is the type of a field element.
+type Type uint8
+
+// Field element types.
+const (
+	Uint8 Type = iota
+	Uint16
+	Uint64
+	Uint256
+	Bytes32
+)
+
+// Size returns the encoded size of the type in bytes.
+func (t Type) Size() int {
+	switch t {
+	case Uint8:
+		return 1
+	case Uint16:
+		return 2
+	case Uint64:
+		return 8
+	case Uint256, Bytes32:
+		return 32
+	default:
+		panic(fmt.Sprintf("unknown type: %d", t))
+	}
+}
+
+func (t Type) String() string {
+	switch t {
+	case Uint8:
+		return "uint8"
+	case Uint16:
+		return "uint16"
+	case Uint64:
+		return "uint64"
+	case Uint256:
+		return "uint256"
+	case Bytes32:
+		return "bytes32"
+	default:
+		return fmt.Sprintf("unknown type %d", uint8(t))
+	}
+}
+
+// Field is a field of the data. By default, a field holds one element. A
+// field holds Count elements if Count is set, as many elements as the earlier
+// uint8 field CountField holds if CountField is set, or all remaining bytes if
+// Rest is set.
+type Field struct {
+	Name       string
+	Type       Type
+	Count      int
+	CountField string
+	Rest       bool
+	Doc        string
+}
+
+// variable returns whether the number of elements of the field depends on
+// the data.
+func (f Field) variable() bool {
+	return f.CountField != "" || f.Rest
+}
+
+// size returns the encoded size of a field with a fixed number of elements.
+func (f Field) size() int {
+	if f.Count > 0 {
+		return f.Count * f.Type.Size()
+	}
+	return f.Type.Size()
+}
+
+// Schema is the layout of the data of an app.
+type Schema struct {
+	Name    string // The name of the app contract.
+	Version uint8  // The version of the layout, stored in the first byte.
+	Fields  []Field
+}
+
+// VersionIndex is the index of the version byte.
+const VersionIndex = 0
+
+// Validate checks that the field names are unique, that count fields precede
+// the fields they count and that only the last field takes the rest.
+func (s *Schema) Validate() error {
+	if s.Version == 0 {
+		return fmt.Errorf("%s: version must not be 0", s.Name)
+	}
+	fields := make(map[string]Field, len(s.Fields))
+	for i, f := range s.Fields {
+		if f.Name == "" || f.Name == "version" {
+			return fmt.Errorf("%s: invalid name of field %d: %q", s.Name, i, f.Name)
+		}
+		if _, ok := fields[f.Name]; ok {
+			return fmt.Errorf("%s: duplicate field %s", s.Name, f.Name)
+		}
+		if f.Type > Bytes32 {
+			return fmt.Errorf("%s: field %s: unknown type %d", s.Name, f.Name, f.Type)
+		}
+		n := 0
+		for _, set := range []bool{f.Count > 0, f.CountField != "", f.Rest} {
+			if set {
+				n++
+			}
+		}
+		if n > 1 || f.Count < 0 {
+			return fmt.Errorf("%s: field %s: invalid number of elements", s.Name, f.Name)
+		}
+		if f.CountField != "" {
+			c, ok := fields[f.CountField]
+			if !ok || c.Type != Uint8 || c.Count > 0 || c.variable() {
+				return fmt.Errorf("%s: field %s: count field %s must be an earlier uint8", s.Name, f.Name, f.CountField)
+			}
+		}
+		if f.Rest && i != len(s.Fields)-1 {
+			return fmt.Errorf("%s: field %s: only the last field may take the rest", s.Name, f.Name)
+		}
+		fields[f.Name] = f
+	}
+	return nil
+}
+
+// WriteVersion writes the version byte.
+func (s *Schema) WriteVersion(w io.Writer) error {
+	_, err := w.Write([]byte{s.Version})
+	return err
+}
+
+// ReadVersion reads the version byte and checks that it is the version of the
+// schema.
+func (s *Schema) ReadVersion(r io.Reader) error {
+	var v [1]byte
+	if _, err := io.ReadFull(r, v[:]); err != nil {
+		return err
+	}
+	if v[0] != s.Version {
+		return fmt.Errorf("unsupported %s data version: %d, expected %d", s.Name, v[0], s.Version)
+	}
+	return nil
+}
+
+// segment is a run of fields whose offsets are fixed relative to the start
+// of the segment. The first segment starts at the version byte. Every other
+// segment starts after a field with a variable number of elements.
+type segment struct {
+	after  *Field // The variable field preceding the segment, nil for the first segment.
+	fields []Field
+	offset []int // The offset of each field relative to the segment start.
+}
+
+// segments splits the fields into segments.
+func (s *Schema) segments() []segment {
+	segs := []segment{{}}
+	pos := VersionIndex + 1
+	for i := range s.Fields {
+		f := s.Fields[i]
+		seg := &segs[len(segs)-1]
+		seg.fields = append(seg.fields, f)
+		seg.offset = append(seg.offset, pos)
+		if f.variable() {
+			if f.Rest {
+				break
+			}
+			segs = append(segs, segment{after: &s.Fields[i]})
+			pos = 0
+			continue
+		}
+		pos += f.size()
+	}
+	if last := segs[len(segs)-1]; len(last.fields) == 0 {
+		segs = segs[:len(segs)-1]
+	}
+	return segs
+}
+
+// fixedLength returns the length of the data if all fields have a fixed
+// number of elements.
+func (s *Schema) fixedLength() (int, bool) {
+	n := VersionIndex + 1
+	for _, f := range s.Fields {
+		if f.variable() {
+			return 0, false
+		}
+		n += f.size()
+	}
+	return n, true
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"perun.network/perun-examples/app-channel/schema"
+)
+
+var testSchema = schema.Schema{
+	Name:    "TestApp",
+	Version: 2,
+	Fields: []schema.Field{
+		{Name: "flag", Type: schema.Uint8},
+		{Name: "amount", Type: schema.Uint16},
+		{Name: "n", Type: schema.Uint8},
+		{Name: "items", Type: schema.Uint16, CountField: "n"},
+		{Name: "m", Type: schema.Uint8},
+		{Name: "hashes", Type: schema.Bytes32, CountField: "m"},
+		{Name: "rest", Type: schema.Uint8, Rest: true},
+	},
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, testSchema.Validate())
+
+	for name, fields := range map[string][]schema.Field{
+		"duplicate":      {{Name: "a"}, {Name: "a"}},
+		"reserved name":  {{Name: "version"}},
+		"unknown count":  {{Name: "a", CountField: "n"}},
+		"late count":     {{Name: "a", CountField: "n"}, {Name: "n"}},
+		"non-byte count": {{Name: "n", Type: schema.Uint16}, {Name: "a", CountField: "n"}},
+		"rest not last":  {{Name: "a", Rest: true}, {Name: "b"}},
+		"count and rest": {{Name: "a", Count: 2, Rest: true}},
+	} {
+		s := schema.Schema{Name: "Invalid", Version: 1, Fields: fields}
+		require.Error(t, s.Validate(), name)
+	}
+	require.Error(t, (&schema.Schema{Name: "Unversioned"}).Validate())
+}
+
+func TestDecode(t *testing.T) {
+	data := []byte{2, 1, 0x01, 0x02, 2, 0, 3, 0, 4, 1}
+	hash := make([]byte, 32)
+	hash[31] = 5
+	data = append(data, hash...)
+	data = append(data, 6, 7)
+
+	values, err := testSchema.Decode(data)
+	require.NoError(t, err)
+	require.Len(t, values, len(testSchema.Fields))
+	require.Equal(t, "amount: 258", values[1].String())
+	require.Equal(t, "items: 3, 4", values[3].String())
+	require.Equal(t, 9, values[4].Index)
+	require.Equal(t, int64(5), values[5].Uint(0).Int64())
+	require.Equal(t, "rest: 6, 7", values[6].String())
+
+	_, err = testSchema.Decode(append([]byte{1}, data[1:]...))
+	require.ErrorContains(t, err, "unsupported TestApp data version: 1, expected 2")
+	_, err = testSchema.Decode(data[:20])
+	require.ErrorContains(t, err, "data too short")
+
+	fixed := schema.Schema{Name: "Fixed", Version: 1, Fields: []schema.Field{{Name: "a", Type: schema.Uint8}}}
+	_, err = fixed.Decode([]byte{1, 0, 0})
+	require.ErrorContains(t, err, "1 trailing bytes")
+}
+
+func TestSolidity(t *testing.T) {
+	src, err := testSchema.Solidity()
+	require.NoError(t, err)
+	for _, s := range []string{
+		"abstract contract TestAppSchema {",
+		"uint8 constant dataVersion = 2;",
+		"uint constant amountDataIndex = 2;",
+		"uint constant amountDataLength = 2;",
+		"uint constant itemsDataIndex = 5;",
+		"uint constant mDataOffset = 0;",
+		"uint constant hashesDataOffset = 1;",
+		"return itemsDataIndex + itemsDataLength * uint(uint8(d[nDataIndex]));",
+		"uint s = mDataIndex(d);",
+		"return s + hashesDataOffset + hashesDataLength * uint(uint8(d[s + mDataOffset]));",
+		" * - data[s2:], s2 = restDataIndex(data)",
+	} {
+		require.Contains(t, src, s)
+	}
+	require.NotContains(t, src, "appDataLength")
+}
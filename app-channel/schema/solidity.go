@@ -0,0 +1,180 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+const solidityHeader = `// Copyright 2025 - See NOTICE file for copyright holders.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by schemagen. DO NOT EDIT.
+
+pragma solidity ^0.8.15;
+`
+
+// ContractName returns the name of the generated Solidity contract.
+func (s *Schema) ContractName() string {
+	return s.Name + "Schema"
+}
+
+// Solidity returns the source of an abstract Solidity contract with the
+// layout of the schema, from which the app contract inherits. For each field
+// of the first segment, it defines the constant <name>DataIndex. The fields
+// after a field with a variable number of elements are located by the
+// function <first field>DataIndex(data) and the constants <name>DataOffset
+// relative to it. For multi-byte types, <name>DataLength is the size of an
+// element.
+func (s *Schema) Solidity() (string, error) {
+	if err := s.Validate(); err != nil {
+		return "", err
+	}
+	segs := s.segments()
+	var b strings.Builder
+	b.WriteString(solidityHeader)
+	b.WriteString("\n/**\n")
+	fmt.Fprintf(&b, " * @notice %s describes the data layout of %s, version %d.\n", s.ContractName(), s.Name, s.Version)
+	b.WriteString(" * Multi-byte integers are big-endian. The data is encoded as follows:\n")
+	fmt.Fprintf(&b, " * - data[%d]: The data version.\n", VersionIndex)
+	for k, seg := range segs {
+		for i, f := range seg.fields {
+			fmt.Fprintf(&b, " * - %s", fieldRange(k, seg.offset[i], f))
+			if k > 0 && i == 0 {
+				fmt.Fprintf(&b, ", s%d = %sDataIndex(data)", k, f.Name)
+			}
+			if f.Doc != "" {
+				fmt.Fprintf(&b, ": %s", f.Doc)
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString(" */\n")
+	fmt.Fprintf(&b, "abstract contract %s {\n", s.ContractName())
+
+	fmt.Fprintf(&b, "    uint8 constant dataVersion = %d;\n", s.Version)
+	fmt.Fprintf(&b, "    uint constant versionDataIndex = %d;\n", VersionIndex)
+	for k, seg := range segs {
+		suffix := "DataIndex"
+		if k > 0 {
+			suffix = "DataOffset"
+		}
+		for i, f := range seg.fields {
+			fmt.Fprintf(&b, "    uint constant %s%s = %d;\n", f.Name, suffix, seg.offset[i])
+			if f.Type.Size() > 1 {
+				fmt.Fprintf(&b, "    uint constant %sDataLength = %d;\n", f.Name, f.Type.Size())
+			}
+		}
+	}
+	if n, ok := s.fixedLength(); ok {
+		fmt.Fprintf(&b, "    uint constant appDataLength = %d;\n", n)
+	}
+
+	b.WriteString("\n    /// @dev requireDataVersion reverts if the data is not of version dataVersion.\n")
+	b.WriteString("    function requireDataVersion(bytes memory d) internal pure {\n")
+	b.WriteString("        require(d.length > versionDataIndex && uint8(d[versionDataIndex]) == dataVersion, \"data version\");\n")
+	b.WriteString("    }\n")
+
+	for k := 1; k < len(segs); k++ {
+		s.writeSegmentIndex(&b, segs, k)
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// writeSegmentIndex writes the function that computes the start of segment k
+// from the number of elements of the preceding variable field.
+func (s *Schema) writeSegmentIndex(b *strings.Builder, segs []segment, k int) {
+	v := segs[k].after
+	prev := k - 1
+	count := s.segmentOf(segs, v.CountField)
+
+	fmt.Fprintf(b, "\n    /// @dev %sDataIndex returns the index of %s, which follows the %s.\n", segs[k].fields[0].Name, segs[k].fields[0].Name, v.Name)
+	fmt.Fprintf(b, "    function %sDataIndex(bytes memory d) internal pure returns (uint) {\n", segs[k].fields[0].Name)
+	if prev > 0 {
+		fmt.Fprintf(b, "        uint s = %sDataIndex(d);\n", segs[prev].fields[0].Name)
+	}
+	countIdx := fieldIndex(segs, count, prev, v.CountField)
+	fmt.Fprintf(b, "        require(d.length > %s, \"data length\");\n", countIdx)
+	n := fmt.Sprintf("uint(uint8(d[%s]))", countIdx)
+	if v.Type.Size() > 1 {
+		n = fmt.Sprintf("%sDataLength * %s", v.Name, n)
+	}
+	fmt.Fprintf(b, "        return %s + %s;\n", fieldIndex(segs, prev, prev, v.Name), n)
+	b.WriteString("    }\n")
+}
+
+// segmentOf returns the segment containing the named field.
+func (s *Schema) segmentOf(segs []segment, name string) int {
+	for k, seg := range segs {
+		for _, f := range seg.fields {
+			if f.Name == name {
+				return k
+			}
+		}
+	}
+	panic("unknown field: " + name)
+}
+
+// fieldIndex returns the Solidity expression for the index of the named field
+// in segment k, inside the function whose local variable s holds the start of
+// segment local.
+func fieldIndex(segs []segment, k, local int, name string) string {
+	switch {
+	case k == 0:
+		return name + "DataIndex"
+	case k == local:
+		return "s + " + name + "DataOffset"
+	default:
+		return fmt.Sprintf("%sDataIndex(d) + %sDataOffset", segs[k].fields[0].Name, name)
+	}
+}
+
+// fieldRange returns the byte range of a field for the layout description.
+func fieldRange(k, offset int, f Field) string {
+	start := fmt.Sprint(offset)
+	if k > 0 {
+		start = fmt.Sprintf("s%d", k)
+		if offset > 0 {
+			start += fmt.Sprintf("+%d", offset)
+		}
+	}
+	switch {
+	case f.Rest:
+		return fmt.Sprintf("data[%s:]", start)
+	case f.CountField != "":
+		return fmt.Sprintf("data[%s:%s+%d*%s]", start, start, f.Type.Size(), f.CountField)
+	case f.size() == 1:
+		return fmt.Sprintf("data[%s]", start)
+	case k == 0:
+		return fmt.Sprintf("data[%d:%d]", offset, offset+f.size())
+	default:
+		return fmt.Sprintf("data[%s:s%d+%d]", start, k, offset+f.size())
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transcript
+
+import (
+	"context"
+	"sync"
+
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/channel/persistence"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+)
+
+// Recorder records the transcripts of all channels of a client. It is used
+// as the client's persister and does not restore channels.
+type Recorder struct {
+	persistence.PersistRestorer // Restores nothing.
+
+	mtx         sync.Mutex
+	transcripts map[channel.ID]*Transcript
+	staged      map[channel.ID]channel.Transaction // The latest staging transaction.
+}
+
+var _ persistence.PersistRestorer = (*Recorder)(nil)
+
+// NewRecorder creates a new recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		PersistRestorer: persistence.NonPersistRestorer,
+		transcripts:     make(map[channel.ID]*Transcript),
+		staged:          make(map[channel.ID]channel.Transaction),
+	}
+}
+
+// Transcript returns a copy of the transcript of the channel.
+func (r *Recorder) Transcript(id channel.ID) (*Transcript, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	t, ok := r.transcripts[id]
+	if !ok {
+		return nil, false
+	}
+	txs := make([]channel.Transaction, len(t.Txs))
+	for i, tx := range t.Txs {
+		txs[i] = tx.Clone()
+	}
+	return &Transcript{Params: t.Params.Clone(), Txs: txs}, true
+}
+
+// ChannelCreated starts the transcript with the signed initial state.
+func (r *Recorder) ChannelCreated(_ context.Context, source channel.Source, _ []map[wallet.BackendID]wire.Address, _ *channel.ID) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.transcripts[source.ID()] = &Transcript{
+		Params: source.Params().Clone(),
+		Txs:    []channel.Transaction{source.CurrentTX().Clone()},
+	}
+	return nil
+}
+
+// ChannelRemoved keeps the transcript, as it is needed after settlement.
+func (r *Recorder) ChannelRemoved(context.Context, channel.ID) error {
+	return nil
+}
+
+// Staged remembers the staging transaction.
+func (r *Recorder) Staged(_ context.Context, source channel.Source) error {
+	r.stage(source)
+	return nil
+}
+
+// SigAdded remembers the staging transaction with the new signature.
+func (r *Recorder) SigAdded(_ context.Context, source channel.Source, _ channel.Index) error {
+	r.stage(source)
+	return nil
+}
+
+func (r *Recorder) stage(source channel.Source) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.staged[source.ID()] = source.StagingTX().Clone()
+}
+
+// Enabled appends the new current state to the transcript. A state that was
+// progressed on-chain has no signatures in the current transaction; if we
+// signed it ourselves, the signatures of the staging transaction are kept.
+// A state progressed by the peer stays unsigned, as the adjudicator event
+// does not carry the peer's signature, and the transcript does not verify
+// beyond it.
+func (r *Recorder) Enabled(_ context.Context, source channel.Source) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	t, ok := r.transcripts[source.ID()]
+	if !ok {
+		return nil
+	}
+	tx := source.CurrentTX().Clone()
+	if staged, ok := r.staged[source.ID()]; ok && staged.State != nil && staged.State.Equal(tx.State) == nil &&
+		numSigs(staged.Sigs) > numSigs(tx.Sigs) {
+		tx.Sigs = staged.Sigs
+	}
+
+	// Keep the better signed copy if the latest state is enabled again, e.g.,
+	// when a state we progressed is confirmed on-chain.
+	last := &t.Txs[len(t.Txs)-1]
+	if last.Version == tx.Version && last.State.Equal(tx.State) == nil {
+		if numSigs(tx.Sigs) > numSigs(last.Sigs) {
+			*last = tx
+		}
+		return nil
+	}
+	t.Txs = append(t.Txs, tx)
+	return nil
+}
+
+// numSigs returns the number of set signatures.
+func numSigs(sigs []wallet.Sig) (n int) {
+	for _, sig := range sigs {
+		if sig != nil {
+			n++
+		}
+	}
+	return n
+}
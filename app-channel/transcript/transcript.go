@@ -0,0 +1,152 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transcript records the states of app channels together with their
+// signatures, so that the course of a game can be verified after the fact.
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethchannel "github.com/perun-network/perun-eth-backend/channel"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"github.com/pkg/errors"
+	"perun.network/go-perun/channel"
+	"perun.network/perun-examples/app-channel/app"
+)
+
+// Game names as stored in transcript files.
+const (
+	TicTacToe         = "tic-tac-toe"
+	RockPaperScissors = "rock-paper-scissors"
+)
+
+// Transcript is the sequence of states of an app channel. Each transaction
+// holds the signatures of the participants who signed the state. A state
+// without signatures was progressed on-chain by a dispute.
+type Transcript struct {
+	Params *channel.Params
+	Txs    []channel.Transaction
+}
+
+// file is the JSON representation of a transcript. The game and the app
+// address are stored in plain text, so that the app can be registered before
+// decoding the parameters and states.
+type file struct {
+	Game   string        `json:"game"`
+	App    string        `json:"app"`
+	Params hexutil.Bytes `json:"params"`
+	States []fileState   `json:"states"`
+}
+
+type fileState struct {
+	Version uint64        `json:"version"`
+	Tx      hexutil.Bytes `json:"tx"`
+}
+
+// WriteFile exports the transcript to the file at path.
+func (t *Transcript) WriteFile(path string) error {
+	def, ok := t.Params.App.Def().(*ethchannel.AppID)
+	if !ok {
+		return fmt.Errorf("invalid app definition: %T", t.Params.App.Def())
+	}
+	game, err := gameName(t.Params.App)
+	if err != nil {
+		return err
+	}
+
+	f := file{Game: game, App: ethwallet.AsEthAddr(def.Address).Hex()}
+	var buf bytes.Buffer
+	if err := t.Params.Encode(&buf); err != nil {
+		return errors.WithMessage(err, "encoding params")
+	}
+	f.Params = buf.Bytes()
+	for _, tx := range t.Txs {
+		var buf bytes.Buffer
+		if err := tx.Encode(&buf); err != nil {
+			return errors.WithMessagef(err, "encoding state %d", tx.Version)
+		}
+		f.States = append(f.States, fileState{Version: tx.Version, Tx: buf.Bytes()})
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return errors.WithMessage(err, "encoding transcript")
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// ReadFile imports a transcript from the file at path. It registers the app
+// of the transcript.
+func ReadFile(path string) (*Transcript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, errors.WithMessage(err, "decoding transcript")
+	}
+
+	if !common.IsHexAddress(f.App) {
+		return nil, fmt.Errorf("invalid app address: %s", f.App)
+	}
+	a, err := newApp(f.Game, ethwallet.AsWalletAddr(common.HexToAddress(f.App)))
+	if err != nil {
+		return nil, err
+	}
+	channel.RegisterApp(a)
+
+	t := &Transcript{Params: new(channel.Params)}
+	if err := t.Params.Decode(bytes.NewReader(f.Params)); err != nil {
+		return nil, errors.WithMessage(err, "decoding params")
+	}
+	for _, s := range f.States {
+		var tx channel.Transaction
+		if err := tx.Decode(bytes.NewReader(s.Tx)); err != nil {
+			return nil, errors.WithMessagef(err, "decoding state %d", s.Version)
+		}
+		t.Txs = append(t.Txs, tx)
+	}
+	return t, nil
+}
+
+// gameName returns the name of the game the app implements.
+func gameName(a channel.App) (string, error) {
+	switch a.(type) {
+	case *app.TicTacToeApp:
+		return TicTacToe, nil
+	case *app.RockPaperScissorsApp:
+		return RockPaperScissors, nil
+	default:
+		return "", fmt.Errorf("unknown app type: %T", a)
+	}
+}
+
+// newApp creates the app of the named game at the given address.
+func newApp(game string, addr *ethwallet.Address) (channel.App, error) {
+	switch game {
+	case TicTacToe:
+		return app.NewTicTacToeApp(addr), nil
+	case RockPaperScissors:
+		return app.NewRockPaperScissorsApp(addr), nil
+	default:
+		return nil, fmt.Errorf("unknown game: %s", game)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transcript_test
+
+import (
+	"math/big"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"github.com/perun-network/perun-eth-backend/wallet/simple"
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/wallet"
+	"perun.network/perun-examples/app-channel/app"
+	"perun.network/perun-examples/app-channel/differential"
+	"perun.network/perun-examples/app-channel/transcript"
+)
+
+// TestTranscript verifies a game with a forced last move, exports and imports
+// it, and checks that tampering is detected.
+func TestTranscript(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	a := app.NewTicTacToeApp(ethwallet.AsWalletAddr(common.Address{1}))
+	channel.RegisterApp(a)
+
+	w := simple.NewWallet()
+	accs := []wallet.Account{w.NewRandomAccount(rng), w.NewRandomAccount(rng)}
+	parts := make([]map[wallet.BackendID]wallet.Address, len(accs))
+	for i, acc := range accs {
+		parts[i] = map[wallet.BackendID]wallet.Address{ethwallet.BackendID: acc.Address()}
+	}
+	params := channel.NewParamsUnsafe(60, parts, a, big.NewInt(1), true, false, channel.ZeroAux)
+
+	stake := big.NewInt(10)
+	data := a.InitData(0, app.DefaultBoardConfig, app.SingleGame, []channel.Bal{stake}, app.WinnerTakesAllPayout)
+	state := differential.NewState(rng, params, data, []channel.Bal{stake, new(big.Int).Set(stake)})
+
+	tr := &transcript.Transcript{Params: params}
+	add := func(s *channel.State, signers ...int) {
+		tx := channel.Transaction{State: s.Clone(), Sigs: make([]wallet.Sig, len(accs))}
+		for _, i := range signers {
+			sig, err := channel.Sign(accs[i], s, ethwallet.BackendID)
+			require.NoError(t, err)
+			tx.Sigs[i] = sig
+		}
+		tr.Txs = append(tr.Txs, tx)
+	}
+	add(state, 0, 1)
+	for i, move := range [][2]int{{0, 0}, {1, 1}} {
+		state = state.Clone()
+		state.Version++
+		require.NoError(t, a.Set(state, move[0], move[1], channel.Index(i)))
+		add(state, 0, 1)
+	}
+	state = state.Clone()
+	state.Version++
+	require.NoError(t, a.Set(state, 2, 2, 0))
+	add(state, 0) // Progressed on-chain by its actor.
+
+	steps, err := tr.Verify()
+	require.NoError(t, err)
+	require.Len(t, steps, 3)
+	require.Equal(t, channel.Index(1), steps[1].Actor)
+	require.True(t, steps[2].Progressed)
+
+	path := filepath.Join(t.TempDir(), "transcript.json")
+	require.NoError(t, tr.WriteFile(path))
+	imported, err := transcript.ReadFile(path)
+	require.NoError(t, err)
+	_, err = imported.Verify()
+	require.NoError(t, err)
+
+	// A signed state with a changed balance is rejected.
+	imported.Txs[1].Balances[0][0] = big.NewInt(0)
+	_, err = imported.Verify()
+	require.Error(t, err)
+}
+
+// TestTranscriptForgedState checks that states that are not signed by their
+// actor are rejected, even if they are valid transitions.
+func TestTranscriptForgedState(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	a := app.NewTicTacToeApp(ethwallet.AsWalletAddr(common.Address{1}))
+	channel.RegisterApp(a)
+
+	w := simple.NewWallet()
+	accs := []wallet.Account{w.NewRandomAccount(rng), w.NewRandomAccount(rng)}
+	parts := make([]map[wallet.BackendID]wallet.Address, len(accs))
+	for i, acc := range accs {
+		parts[i] = map[wallet.BackendID]wallet.Address{ethwallet.BackendID: acc.Address()}
+	}
+	params := channel.NewParamsUnsafe(60, parts, a, big.NewInt(1), true, false, channel.ZeroAux)
+
+	stake := big.NewInt(10)
+	data := a.InitData(0, app.DefaultBoardConfig, app.SingleGame, []channel.Bal{stake}, app.WinnerTakesAllPayout)
+	init := differential.NewState(rng, params, data, []channel.Bal{stake, new(big.Int).Set(stake)})
+	tx := func(s *channel.State, signers ...int) channel.Transaction {
+		tx := channel.Transaction{State: s.Clone(), Sigs: make([]wallet.Sig, len(accs))}
+		for _, i := range signers {
+			sig, err := channel.Sign(accs[i], s, ethwallet.BackendID)
+			require.NoError(t, err)
+			tx.Sigs[i] = sig
+		}
+		return tx
+	}
+
+	// Player 1 forges a forfeit claim against player 0, who is the next
+	// actor.
+	forged := init.Clone()
+	forged.Version++
+	require.NoError(t, a.Forfeit(forged, 1))
+
+	tr := &transcript.Transcript{Params: params, Txs: []channel.Transaction{tx(init, 0, 1), tx(forged)}}
+	_, err := tr.Verify()
+	require.ErrorContains(t, err, "unsigned state")
+
+	// A move signed only by the other player is rejected as well.
+	move := init.Clone()
+	move.Version++
+	require.NoError(t, a.Set(move, 0, 0, 0))
+	tr.Txs[1] = tx(move, 1)
+	_, err = tr.Verify()
+	require.ErrorContains(t, err, "no valid transition by signers [1]")
+
+	tr.Txs[1] = tx(move, 0)
+	steps, err := tr.Verify()
+	require.NoError(t, err)
+	require.True(t, steps[0].Progressed)
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transcript
+
+import (
+	"fmt"
+
+	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
+	"github.com/pkg/errors"
+	"perun.network/go-perun/channel"
+)
+
+// Step is a verified transition of a transcript.
+type Step struct {
+	From, To   *channel.State
+	Actor      channel.Index   // The participant whose move led to the state.
+	Signers    []channel.Index // The participants who signed the state.
+	Progressed bool            // Whether the state was progressed on-chain without all signatures.
+}
+
+// Verify checks the transcript: all signatures must be valid, the initial
+// state must be signed by all participants and each further state must be a
+// valid transition of the app by one of its signers. A state signed only by
+// its actor was progressed on-chain. A state without signatures is rejected,
+// as anyone could have forged it. Verify returns the verified transitions.
+func (t *Transcript) Verify() ([]Step, error) {
+	a, ok := t.Params.App.(channel.StateApp)
+	if !ok {
+		return nil, fmt.Errorf("no state app: %T", t.Params.App)
+	}
+	if len(t.Txs) == 0 {
+		return nil, fmt.Errorf("empty transcript")
+	}
+
+	init := t.Txs[0]
+	signers, err := t.verifySigs(init)
+	if err != nil {
+		return nil, errors.WithMessage(err, "initial state")
+	}
+	if init.Version != 0 || len(signers) != len(t.Params.Parts) {
+		return nil, fmt.Errorf("initial state: version %d signed by %v", init.Version, signers)
+	}
+	if err := a.ValidInit(t.Params, init.State); err != nil {
+		return nil, errors.WithMessage(err, "initial state")
+	}
+
+	steps := make([]Step, 0, len(t.Txs)-1)
+	for i := 1; i < len(t.Txs); i++ {
+		from, to := t.Txs[i-1].State, t.Txs[i]
+		step, err := t.verifyTransition(a, from, to)
+		if err != nil {
+			return steps, errors.WithMessagef(err, "state %d", to.Version)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// verifyTransition checks the transition from one state to the next
+// transaction and finds the actor among the signers. The actor must have
+// signed the state.
+func (t *Transcript) verifyTransition(a channel.StateApp, from *channel.State, to channel.Transaction) (Step, error) {
+	signers, err := t.verifySigs(to)
+	if err != nil {
+		return Step{}, err
+	}
+	if len(signers) == 0 {
+		return Step{}, fmt.Errorf("unsigned state")
+	}
+	if from.IsFinal {
+		return Step{}, fmt.Errorf("transition from final state")
+	}
+	if to.Version != from.Version+1 {
+		return Step{}, fmt.Errorf("invalid version: expected %d, got %d", from.Version+1, to.Version)
+	}
+	if err := channel.AssertAssetsEqual(from.Assets, to.Assets); err != nil {
+		return Step{}, errors.WithMessage(err, "assets")
+	}
+	fromSum, toSum := from.Balances.Sum(), to.Balances.Sum()
+	for i := range fromSum {
+		if fromSum[i].Cmp(toSum[i]) != 0 {
+			return Step{}, fmt.Errorf("balance sum of asset %d changed: %v to %v", i, fromSum[i], toSum[i])
+		}
+	}
+
+	for _, actor := range signers {
+		if err = a.ValidTransition(t.Params, from, to.State, actor); err == nil {
+			progressed := len(signers) < len(t.Params.Parts)
+			return Step{From: from, To: to.State, Actor: actor, Signers: signers, Progressed: progressed}, nil
+		}
+	}
+	return Step{}, errors.WithMessagef(err, "no valid transition by signers %v", signers)
+}
+
+// verifySigs checks the set signatures of the transaction and returns the
+// signers.
+func (t *Transcript) verifySigs(tx channel.Transaction) (signers []channel.Index, err error) {
+	if tx.State == nil {
+		return nil, fmt.Errorf("missing state")
+	}
+	if tx.ID != t.Params.ID() {
+		return nil, fmt.Errorf("invalid channel ID: %x", tx.ID)
+	}
+	if len(tx.Sigs) != len(t.Params.Parts) {
+		return nil, fmt.Errorf("invalid number of signatures: %d", len(tx.Sigs))
+	}
+	for i, sig := range tx.Sigs {
+		if sig == nil {
+			continue
+		}
+		ok, err := channel.Verify(t.Params.Parts[i][ethwallet.BackendID], tx.State, sig)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "verifying signature %d", i)
+		} else if !ok {
+			return nil, fmt.Errorf("invalid signature of participant %d", i)
+		}
+		signers = append(signers, channel.Index(i))
+	}
+	return signers, nil
+}
@@ -19,69 +19,29 @@ import (
 	"log"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-
-	"perun.network/perun-examples/app-channel/app"
-	"perun.network/perun-examples/app-channel/contracts/generated/ticTacToeApp"
-
-	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
-	ethchannel "github.com/perun-network/perun-eth-backend/channel"
 	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
 	swallet "github.com/perun-network/perun-eth-backend/wallet/simple"
-	"perun.network/go-perun/channel"
 	"perun.network/go-perun/wallet"
 	"perun.network/go-perun/wire"
 	"perun.network/go-perun/wire/net"
 	p2p "perun.network/go-perun/wire/net/libp2p"
 	perunio "perun.network/go-perun/wire/perunio/serializer"
 	"perun.network/perun-examples/app-channel/client"
+	"perun.network/perun-examples/app-channel/deployment"
+	"perun.network/perun-examples/shared/registry"
 )
 
-// deployContracts deploys the contracts on the specified ledger.
-func deployContracts(nodeURL string, chainID uint64, privateKey string) (adj, ah, app common.Address) {
-	k, err := crypto.HexToECDSA(privateKey)
-	if err != nil {
-		panic(err)
-	}
-	w := swallet.NewWallet(k)
-	cb, err := client.CreateContractBackend(nodeURL, chainID, w)
+// loadContracts loads the contracts of the specified ledger from the
+// deployment registry and verifies that they are deployed.
+func loadContracts(nodeURL string, chainID uint64) deployment.Contracts {
+	c, err := deployment.LoadFile(nodeURL, chainID, registry.DefaultFile)
 	if err != nil {
-		panic(err)
+		log.Fatalf("Loading contracts: %v. Deploy them with: go run ./cmd/deploy", err)
 	}
-	acc := accounts.Account{Address: crypto.PubkeyToAddress(k.PublicKey)}
-
-	// Deploy adjudicator.
-	adj, err = ethchannel.DeployAdjudicator(context.TODO(), cb, acc)
-	if err != nil {
-		panic(err)
-	}
-
-	// Deploy asset holder.
-	ah, err = ethchannel.DeployETHAssetholder(context.TODO(), cb, adj, acc)
-	if err != nil {
-		panic(err)
-	}
-
-	const gasLimit = 2200000 // Must be sufficient for deploying TicTacToeApp.sol.
-	tops, err := cb.NewTransactor(context.TODO(), gasLimit, acc)
-	if err != nil {
-		panic(err)
-	}
-	// Deploy TicTacToe App.
-	app, tx, _, err := ticTacToeApp.DeployTicTacToeApp(tops, cb)
-	if err != nil {
-		panic(err)
-	}
-
-	_, err = bind.WaitDeployed(context.TODO(), cb, tx)
-	if err != nil {
-		panic(err)
-	}
-
-	return adj, ah, app
+	return c
 }
 
 // setupGameClient sets up a new client with the given parameters.
@@ -91,9 +51,8 @@ func setupGameClient(
 	adjudicator common.Address,
 	asset ethwallet.Address,
 	privateKey string,
-	app *app.TicTacToeApp,
-	stake channel.Bal,
 	wireAddr wire.Address,
+	games ...client.Game,
 ) *client.AppClient {
 	// Create wallet and account.
 	k, err := crypto.HexToECDSA(privateKey)
@@ -115,8 +74,7 @@ func setupGameClient(
 		chainID,
 		adjudicator,
 		asset,
-		app,
-		stake,
+		games...,
 	)
 	if err != nil {
 		panic(err)
@@ -0,0 +1,41 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+)
+
+// swapBus is the wire bus of the Perun client. The bus allows only one
+// subscriber per address, so swapBus subscribes a relay in place of the Perun
+// client, which passes the messages of the quote protocol to the quote
+// receiver and all other messages to the Perun client.
+type swapBus struct {
+	wire.Bus
+	quotes wire.Consumer
+}
+
+func (b *swapBus) SubscribeClient(c wire.Consumer, addr map[wallet.BackendID]wire.Address) error {
+	relay := wire.NewRelay()
+	if err := relay.Subscribe(b.quotes, isQuoteMsg); err != nil {
+		return err
+	}
+	if err := relay.Subscribe(c, func(e *wire.Envelope) bool { return !isQuoteMsg(e) }); err != nil {
+		return err
+	}
+	c.OnCloseAlways(func() { relay.Close() }) //nolint:errcheck
+	return b.Bus.SubscribeClient(relay, addr)
+}
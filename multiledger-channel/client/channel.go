@@ -24,38 +24,95 @@ import (
 // SwapChannel is a wrapper for a Perun channel for the swap use case.
 type SwapChannel struct {
 	ch     *client.Channel
-	assets [2]channel.Asset
+	assets []channel.Asset
+	client *SwapClient // The client holds the agreed quotes, shared with the update handler.
 }
 
 // newSwapChannel creates a new channel for swaps.
-func newSwapChannel(ch *client.Channel, currencies [2]channel.Asset) *SwapChannel {
+func newSwapChannel(ch *client.Channel, currencies []channel.Asset, c *SwapClient) *SwapChannel {
 	return &SwapChannel{
 		ch:     ch,
 		assets: currencies,
+		client: c,
 	}
 }
 
-// PerformSwap performs a swap by "swapping" the balances of the two
-// participants for both assets.
+// AgreeQuote records the quote negotiated with the peer. Both participants
+// must agree on the quote before either of them performs the swap.
+func (c SwapChannel) AgreeQuote(q Quote) error {
+	if _, err := q.apply(c.ch.State().Balances); err != nil {
+		return err
+	}
+	c.client.quotes.set(c.ch.ID(), q)
+	return nil
+}
+
+// PerformSwap performs the swap of the agreed quote.
 func (c SwapChannel) PerformSwap() {
-	err := c.ch.Update(context.TODO(), func(state *channel.State) { // We use context.TODO to keep the code simple.
-		// We simply swap the balances for the two assets.
-		state.Balances = channel.Balances{
-			{state.Balances[0][1], state.Balances[0][0]},
-			{state.Balances[1][1], state.Balances[1][0]},
-		}
+	terms, ok := c.client.quotes.get(c.ch.ID())
+	q, isQuote := terms.(Quote)
+	if !ok || !isQuote {
+		panic("no quote agreed")
+	}
+	if err := c.update(q, q); err != nil {
+		panic(err) // We panic on error to keep the code simple.
+	}
+	c.client.quotes.remove(c.ch.ID())
+}
 
-		// Set the state to final because we do not expect any other updates
-		// than this swap.
-		state.IsFinal = true
-	})
+// Swap sells the requested amount of an asset for another asset of the
+// channel. It requests a quote from the peer over the wire bus, checks that
+// the quoted rate is within the slippage bounds and accepts the quote by
+// proposing the swap as a channel update. The channel stays open for further
+// swaps.
+func (c SwapChannel) Swap(req SwapRequest) (RateQuote, error) {
+	if err := req.validate(len(c.assets)); err != nil {
+		return RateQuote{}, err
+	}
+	rq, err := c.client.requestQuote(c.ch, req)
 	if err != nil {
-		panic(err) // We panic on error to keep the code simple.
+		return RateQuote{}, err
 	}
+	if err := c.update(rq.Quote(len(c.assets), req.Amount), rq); err != nil {
+		return RateQuote{}, err
+	}
+	return rq, nil
+}
+
+// update applies the quote to the channel state. The update is validated
+// against the terms before it is proposed, in the same way as the peer
+// validates it.
+func (c SwapChannel) update(q Quote, terms swapTerms) error {
+	cur := c.ch.State()
+	next := cur.Clone()
+	bals, err := q.apply(cur.Balances)
+	if err != nil {
+		return err
+	}
+	next.Balances = bals
+	next.IsFinal = q.Final
+	if err := terms.validate(cur, next); err != nil {
+		return err
+	}
+
+	return c.ch.Update(context.TODO(), func(state *channel.State) { // We use context.TODO to keep the code simple.
+		state.Balances = next.Balances
+		state.IsFinal = next.IsFinal
+	})
 }
 
 // Settle settles the channel and withdraws the funds.
 func (c SwapChannel) Settle() {
+	// Finalize the channel to enable fast settlement.
+	if !c.ch.State().IsFinal {
+		err := c.ch.Update(context.TODO(), func(state *channel.State) {
+			state.IsFinal = true
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
 	// Settle concludes the channel and withdraws the funds.
 	err := c.ch.Settle(context.TODO(), false)
 	if err != nil {
@@ -64,4 +121,5 @@ func (c SwapChannel) Settle() {
 
 	// Close frees up channel resources.
 	c.ch.Close()
+	c.client.removeChannel(c.ch.ID())
 }
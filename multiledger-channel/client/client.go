@@ -17,6 +17,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	ethchannel "github.com/perun-network/perun-eth-backend/channel"
 	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
@@ -34,26 +35,56 @@ import (
 	"github.com/pkg/errors"
 )
 
-const (
-	txFinalityDepth = 1 // Number of blocks required to confirm a transaction.
-)
-
-// ChainConfig is used to hold all information needed about a specific chain.
+// ChainConfig is used to hold all information needed about a specific chain
+// and the token that is swapped on it. Several configs may refer to the same
+// chain to swap several of its tokens, but they must share the adjudicator.
 type ChainConfig struct {
 	ChainID     ethchannel.ChainID
 	ChainURL    string
 	Token       common.Address // The address of the deployed ERC20 token.
 	Adjudicator common.Address // The address of the deployed Adjudicator contract.
 	AssetHolder common.Address // The address of the deployed AssetHolder contract.
+
+	// The settings of the chain, as set by chainconfig.Load, which fills in
+	// the chainconfig defaults.
+	DepositGasLimit     uint64
+	AdjudicatorGasLimit uint64
+	FinalityDepth       uint64 // The number of blocks that confirm a transaction.
+}
+
+// validate checks that the config names the contracts and the settings.
+func (c ChainConfig) validate() error {
+	switch {
+	case c.Token == (common.Address{}):
+		return fmt.Errorf("chain %v: missing token address", c.ChainID)
+	case c.Adjudicator == (common.Address{}):
+		return fmt.Errorf("chain %v: missing adjudicator address", c.ChainID)
+	case c.AssetHolder == (common.Address{}):
+		return fmt.Errorf("chain %v: missing asset holder address", c.ChainID)
+	case c.DepositGasLimit == 0 || c.AdjudicatorGasLimit == 0:
+		return fmt.Errorf("chain %v: missing gas limit", c.ChainID)
+	case c.FinalityDepth == 0:
+		return fmt.Errorf("chain %v: missing finality depth", c.ChainID)
+	}
+	return nil
 }
 
 // SwapClient is a channel client for swaps.
 type SwapClient struct {
 	perunClient *client.Client                      // The core Perun client.
 	account     map[wallet.BackendID]wallet.Address // The account we use for on-chain and off-chain transactions.
-	currencies  [2]channel.Asset                    // The currencies of the different chains we support.
+	currencies  []channel.Asset                     // The currencies of the different chains we support, one per chain config.
+	quotes      *quoteBook                          // The quotes agreed on per channel.
 	channels    chan *SwapChannel                   // Accepted payment channels.
 	waddresss   map[wallet.BackendID]wire.Address   // The wire address of the client, used for off-chain communication.
+	bus         wire.Bus                            // The bus used to send quote messages.
+	quoteRecv   *wire.Receiver                      // The receiver of quote messages.
+
+	mtx          sync.Mutex
+	swapChannels map[channel.ID]*SwapChannel  // The open channels of the client.
+	rates        map[[2]int]Rate              // The rates of the client as maker, per sold and bought asset.
+	pending      map[channel.ID]*pendingQuote // The quote requests waiting for a response.
+	policy       ProposalPolicy               // The policy for incoming channel proposals.
 }
 
 // SetupSwapClient creates a new swap client.
@@ -61,7 +92,7 @@ func SetupSwapClient(
 	bus wire.Bus, // bus is used of off-chain communication.
 	w *swallet.Wallet, // w is the wallet used for signing transactions.
 	acc common.Address, // acc is the address of the account to be used for signing transactions.
-	chains [2]ChainConfig, // chains represent the two chains the client should be able to use.
+	chains []ChainConfig, // chains represent the chains and tokens the client should be able to use.
 	waddress wire.Address, // waddress is the wire address of the client, used for off-chain communication.
 ) (*SwapClient, error) {
 	// The multi-funder and multi-adjudicator will be registered with a funder /
@@ -69,16 +100,41 @@ func SetupSwapClient(
 	multiFunder := multi.NewFunder()
 	multiAdjudicator := multi.NewAdjudicator()
 
-	var assets [2]channel.Asset
+	if len(chains) == 0 {
+		return nil, fmt.Errorf("no chains configured")
+	}
+	assets := make([]channel.Asset, len(chains))
 	for i, chain := range chains {
+		if err := chain.validate(); err != nil {
+			return nil, err
+		}
 		assets[i] = ethchannel.NewAsset(chain.ChainID.Int, chain.AssetHolder)
 	}
 
+	// Group the chain configs by chain, so that each chain gets one funder and
+	// one adjudicator.
+	var ledgers [][]int // The indices of the chain configs of each chain.
 	for i, chain := range chains {
+		j := 0
+		for ; j < len(ledgers); j++ {
+			if chains[ledgers[j][0]].ChainID.Cmp(chain.ChainID.Int) == 0 {
+				break
+			}
+		}
+		if j == len(ledgers) {
+			ledgers = append(ledgers, nil)
+		} else if chains[ledgers[j][0]].Adjudicator != chain.Adjudicator {
+			return nil, fmt.Errorf("chain %v: configs %d and %d use different adjudicators", chain.ChainID, ledgers[j][0], i)
+		}
+		ledgers[j] = append(ledgers[j], i)
+	}
+
+	for _, ledger := range ledgers {
+		chain := chains[ledger[0]]
 		// Create Ethereum client and contract backend.
-		cb, err := CreateContractBackend(chain.ChainURL, chain.ChainID.Int, w)
+		cb, err := CreateContractBackend(chain.ChainURL, chain.ChainID.Int, w, chain.FinalityDepth)
 		if err != nil {
-			return nil, fmt.Errorf("creating contract backend: %w", err)
+			return nil, fmt.Errorf("chain %v: creating contract backend: %w", chain.ChainID, err)
 		}
 
 		// Validate contracts.
@@ -86,29 +142,36 @@ func SetupSwapClient(
 		if err != nil {
 			return nil, fmt.Errorf("validating adjudicator: %w", err)
 		}
-		err = ethchannel.ValidateAssetHolderERC20(context.TODO(), cb, chain.AssetHolder, chain.Adjudicator, chain.Token)
-		if err != nil {
-			return nil, fmt.Errorf("validating adjudicator: %w", err)
+		for _, i := range ledger {
+			err = ethchannel.ValidateAssetHolderERC20(context.TODO(), cb, chains[i].AssetHolder, chains[i].Adjudicator, chains[i].Token)
+			if err != nil {
+				return nil, fmt.Errorf("validating asset holder: %w", err)
+			}
 		}
 
 		// Setup funder.
 		funder := ethchannel.NewFunder(cb)
-		// Register the asset on the funder.
-		dep := ethchannel.NewERC20Depositor(chain.Token, 100000)
 		ethAcc := accounts.Account{Address: acc}
-		funder.RegisterAsset(*assets[i].(*ethchannel.Asset), dep, ethAcc)
-		// We have to register the asset of the other chain too, but use a
-		// NoOpDepositor there since this funder can ignore it.
-		funder.RegisterAsset(*assets[1-i].(*ethchannel.Asset), ethchannel.NewNoOpDepositor(), ethAcc)
+		for i, asset := range assets {
+			// Register the assets of this chain on the funder. We have to
+			// register the assets of the other chains too, but use a
+			// NoOpDepositor there since this funder can ignore them.
+			var dep ethchannel.Depositor = ethchannel.NewNoOpDepositor()
+			if chains[i].ChainID.Cmp(chain.ChainID.Int) == 0 {
+				dep = ethchannel.NewERC20Depositor(chains[i].Token, chains[i].DepositGasLimit)
+			}
+			funder.RegisterAsset(*asset.(*ethchannel.Asset), dep, ethAcc)
+		}
 
-		// assetID is the ID of the asset on the chain, which is used to
+		// assetID is the ID of the chain, which is used to dispatch funding
+		// and disputes to the funder and adjudicator of the chain.
 		assetID := ethchannel.MakeLedgerBackendID(chain.ChainID.Int)
 
 		// Register the funder on the multi-funder.
 		multiFunder.RegisterFunder(assetID, funder)
 
 		// Setup adjudicator.
-		adj := ethchannel.NewAdjudicator(cb, chain.Adjudicator, acc, ethAcc, 10000000)
+		adj := ethchannel.NewAdjudicator(cb, chain.Adjudicator, acc, ethAcc, chain.AdjudicatorGasLimit)
 		// Register the adjudicator on the multi-adjudicator.
 		multiAdjudicator.RegisterAdjudicator(assetID, adj)
 	}
@@ -123,7 +186,10 @@ func SetupSwapClient(
 	walletAddr := ethwallet.AsWalletAddr(acc)
 	addresses := map[wallet.BackendID]wire.Address{1: waddress}
 	ethWallet := map[wallet.BackendID]wallet.Wallet{1: w}
-	perunClient, err := client.New(addresses, bus, multiFunder, multiAdjudicator, ethWallet, watcher)
+	// Quote messages are routed to a separate receiver, so that the client
+	// can handle them besides the channel protocol messages.
+	quoteRecv := wire.NewReceiver()
+	perunClient, err := client.New(addresses, &swapBus{Bus: bus, quotes: quoteRecv}, multiFunder, multiAdjudicator, ethWallet, watcher)
 	if err != nil {
 		return nil, errors.WithMessage(err, "creating client")
 	}
@@ -136,23 +202,47 @@ func SetupSwapClient(
 		perunClient: perunClient,
 		account:     account,
 		currencies:  assets,
+		quotes:      newQuoteBook(),
 		channels:    make(chan *SwapChannel, 1),
 		waddresss:   addresses,
+		bus:         bus,
+		quoteRecv:   quoteRecv,
+
+		swapChannels: make(map[channel.ID]*SwapChannel),
+		rates:        make(map[[2]int]Rate),
+		pending:      make(map[channel.ID]*pendingQuote),
 	}
 	go perunClient.Handle(c, c)
+	go c.handleQuoteMsgs()
 
 	return c, nil
 }
 
-// OpenChannel opens a new channel with the specified peer and funding.
+// OpenChannel opens a new channel with the specified peer and funding. The
+// balances hold the funding of both participants for each of the client's
+// assets, in the order of the chain configs.
 func (c *SwapClient) OpenChannel(peer map[wallet.BackendID]wire.Address, balances channel.Balances) *SwapChannel {
+	ch, err := c.ProposeChannel(peer, balances)
+	if err != nil {
+		panic(err)
+	}
+	return ch
+}
+
+// ProposeChannel is like OpenChannel, but returns an error if the peer
+// rejects the proposal or the funding fails.
+func (c *SwapClient) ProposeChannel(peer map[wallet.BackendID]wire.Address, balances channel.Balances) (*SwapChannel, error) {
 	// We define the channel participants. The proposer has always index 0. Here
 	// we use the on-chain addresses as off-chain addresses, but we could also
 	// use different ones.
 	participants := []map[wallet.BackendID]wire.Address{c.waddresss, peer}
 
 	// We create an initial allocation which defines the starting balances.
-	initAlloc := channel.NewAllocation(2, []wallet.BackendID{1, 1}, c.currencies[0], c.currencies[1])
+	backends := make([]wallet.BackendID, len(c.currencies))
+	for i := range backends {
+		backends[i] = 1
+	}
+	initAlloc := channel.NewAllocation(2, backends, c.currencies...)
 	initAlloc.Balances = balances
 
 	// Prepare the channel proposal by defining the channel parameters.
@@ -164,19 +254,49 @@ func (c *SwapClient) OpenChannel(peer map[wallet.BackendID]wire.Address, balance
 		participants,
 	)
 	if err != nil {
-		panic(err)
+		return nil, errors.WithMessage(err, "creating channel proposal")
 	}
 
 	// Send the proposal.
 	ch, err := c.perunClient.ProposeChannel(context.TODO(), proposal)
 	if err != nil {
-		panic(err)
+		return nil, errors.WithMessage(err, "proposing channel")
 	}
 
 	// Start the on-chain event watcher. It automatically handles disputes.
 	c.startWatching(ch)
 
-	return newSwapChannel(ch, c.currencies)
+	return c.addChannel(ch), nil
+}
+
+// ProposalPolicy decides whether the client accepts a channel proposed by the
+// peer with the given initial balances. It returns the reason for rejecting
+// the proposal as error.
+type ProposalPolicy func(proposer map[wallet.BackendID]wire.Address, balances channel.Balances) error
+
+// SetProposalPolicy sets the policy for incoming channel proposals. Without a
+// policy, the client accepts every proposal with the expected assets.
+func (c *SwapClient) SetProposalPolicy(p ProposalPolicy) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.policy = p
+}
+
+// addChannel registers the channel, so that the client can quote swaps in it.
+func (c *SwapClient) addChannel(ch *client.Channel) *SwapChannel {
+	sc := newSwapChannel(ch, c.currencies, c)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.swapChannels[ch.ID()] = sc
+	return sc
+}
+
+// removeChannel unregisters the channel and drops its quote.
+func (c *SwapClient) removeChannel(id channel.ID) {
+	c.mtx.Lock()
+	delete(c.swapChannels, id)
+	c.mtx.Unlock()
+	c.quotes.remove(id)
 }
 
 // startWatching starts the dispute watcher for the specified channel.
@@ -196,5 +316,6 @@ func (c *SwapClient) AcceptedChannel() *SwapChannel {
 
 // Shutdown gracefully shuts down the client.
 func (c *SwapClient) Shutdown() {
+	c.quoteRecv.Close() //nolint:errcheck // The receiver is only closed here.
 	c.perunClient.Close()
 }
@@ -18,7 +18,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"math/big"
 
 	"perun.network/go-perun/channel"
 	"perun.network/go-perun/client"
@@ -38,17 +37,25 @@ func (c *SwapClient) HandleProposal(p client.ChannelProposal, r *client.Proposal
 			return nil, fmt.Errorf("Invalid number of participants: %d", lcp.NumPeers())
 		}
 
-		// Check that the channel has the expected assets and funding balances.
-		const assetIdx, peerIdx = 0, 1
-		if err := channel.AssertAssetsEqual(lcp.InitBals.Assets, c.currencies[:]); err != nil {
+		// Check that the channel has the expected assets.
+		if err := channel.AssertAssetsEqual(lcp.InitBals.Assets, c.currencies); err != nil {
 			return nil, fmt.Errorf("Invalid assets: %v\n", err)
-		} else if lcp.FundingAgreement[assetIdx][peerIdx].Cmp(big.NewInt(0)) != 0 {
-			return nil, fmt.Errorf("Invalid funding balance")
+		}
+
+		// Check the proposal against the policy of the client.
+		c.mtx.Lock()
+		policy := c.policy
+		c.mtx.Unlock()
+		if policy != nil {
+			if err := policy(lcp.Peers[0], lcp.InitBals.Balances); err != nil {
+				return nil, err
+			}
 		}
 		return lcp, nil
 	}()
 	if err != nil {
 		r.Reject(context.TODO(), err.Error()) //nolint:errcheck // It's OK if rejection fails.
+		return
 	}
 
 	// Create a channel accept message and send it.
@@ -66,42 +73,15 @@ func (c *SwapClient) HandleProposal(p client.ChannelProposal, r *client.Proposal
 	c.startWatching(ch)
 
 	// Store channel.
-	c.channels <- newSwapChannel(ch, c.currencies)
+	c.channels <- c.addChannel(ch)
 }
 
 // HandleUpdate is the callback for incoming channel updates.
 func (c *SwapClient) HandleUpdate(cur *channel.State, next client.ChannelUpdate, r *client.UpdateResponder) {
-	// We accept every update that increases our balance.
-	err := func() error {
-		err := channel.AssertAssetsEqual(cur.Assets, next.State.Assets)
-		if err != nil {
-			return fmt.Errorf("Invalid assets: %v", err)
-		}
-
-		// Check the swap of the two currencies.
-		for _, currency := range c.currencies {
-			myIdx := 1 - next.ActorIdx // This works because we are in a two-party channel.
-			// Get our and the peer's current balance.
-			ourCurBal := cur.Balance(myIdx, currency)
-			peerCurBal := cur.Balance(next.ActorIdx, currency)
-			// Get our and the peer's balance of the next proposed state.
-			ourNextBal := next.State.Balance(myIdx, currency)
-			peerNextBal := next.State.Balance(next.ActorIdx, currency)
-
-			// Check that the balances are "swapped".
-			if !(ourCurBal.Cmp(peerNextBal) == 0 && ourNextBal.Cmp(peerCurBal) == 0) {
-				return fmt.Errorf("invalid swap of balances")
-			}
-		}
-
-		if !next.State.IsFinal {
-			return fmt.Errorf("swap update must be final")
-		}
-
-		return nil
-	}()
+	err := c.checkUpdate(cur, next.State)
 	if err != nil {
 		r.Reject(context.TODO(), err.Error()) //nolint:errcheck // It's OK if rejection fails.
+		return
 	}
 
 	// Send the acceptance message.
@@ -109,6 +89,22 @@ func (c *SwapClient) HandleUpdate(cur *channel.State, next client.ChannelUpdate,
 	if err != nil {
 		panic(err)
 	}
+	c.quotes.remove(cur.ID)
+}
+
+// checkUpdate checks an incoming update. We accept the update if it only
+// finalizes the channel for settlement or if it performs the swap of the
+// quote we agreed on. A quote that the peer never uses does not block the
+// settlement.
+func (c *SwapClient) checkUpdate(cur, next *channel.State) error {
+	if isFinalization(cur, next) {
+		return nil
+	}
+	q, ok := c.quotes.get(cur.ID)
+	if ok {
+		return q.validate(cur, next)
+	}
+	return fmt.Errorf("no quote agreed")
 }
 
 // HandleAdjudicatorEvent is the callback for smart contract events.
@@ -0,0 +1,99 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io"
+	"math/big"
+
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/wire"
+	"perun.network/go-perun/wire/perunio"
+)
+
+// Message types of the quote protocol. They are sent over the same wire bus
+// as the messages of the Perun client.
+const (
+	QuoteRequestMsgType wire.Type = 200 + iota
+	QuoteMsgType
+)
+
+func init() {
+	wire.RegisterExternalDecoder(QuoteRequestMsgType, func(r io.Reader) (wire.Msg, error) {
+		var m QuoteRequestMsg
+		return &m, m.Decode(r)
+	}, "QuoteRequest")
+	wire.RegisterExternalDecoder(QuoteMsgType, func(r io.Reader) (wire.Msg, error) {
+		var m QuoteMsg
+		return &m, m.Decode(r)
+	}, "Quote")
+}
+
+// QuoteRequestMsg requests a quote for selling Amount of asset Sell for asset
+// Buy in a channel. The maker rejects the request if its rate is below
+// MinRate.
+type QuoteRequestMsg struct {
+	ChannelID channel.ID
+	Sell, Buy uint16
+	Amount    *big.Int
+	MinRate   Rate
+}
+
+// Type returns the message type.
+func (*QuoteRequestMsg) Type() wire.Type { return QuoteRequestMsgType }
+
+// Encode encodes the message.
+func (m *QuoteRequestMsg) Encode(w io.Writer) error {
+	return perunio.Encode(w, m.ChannelID, m.Sell, m.Buy, m.Amount, m.MinRate.Num, m.MinRate.Den)
+}
+
+// Decode decodes the message.
+func (m *QuoteRequestMsg) Decode(r io.Reader) error {
+	return perunio.Decode(r, &m.ChannelID, &m.Sell, &m.Buy, &m.Amount, &m.MinRate.Num, &m.MinRate.Den)
+}
+
+// QuoteMsg answers a QuoteRequestMsg. If Reason is set, the maker rejects the
+// request. Otherwise, the maker offers Rate until Expiry, given as Unix time
+// in seconds.
+type QuoteMsg struct {
+	ChannelID channel.ID
+	Rate      Rate
+	Expiry    int64
+	Reason    string
+}
+
+// Type returns the message type.
+func (*QuoteMsg) Type() wire.Type { return QuoteMsgType }
+
+// Encode encodes the message.
+func (m *QuoteMsg) Encode(w io.Writer) error {
+	rate := m.Rate
+	if m.Reason != "" {
+		rate = NewRate(0, 0) // A rejection has no rate.
+	}
+	return perunio.Encode(w, m.ChannelID, rate.Num, rate.Den, m.Expiry, m.Reason)
+}
+
+// Decode decodes the message.
+func (m *QuoteMsg) Decode(r io.Reader) error {
+	return perunio.Decode(r, &m.ChannelID, &m.Rate.Num, &m.Rate.Den, &m.Expiry, &m.Reason)
+}
+
+// isQuoteMsg returns whether the envelope holds a message of the quote
+// protocol.
+func isQuoteMsg(e *wire.Envelope) bool {
+	t := e.Msg.Type()
+	return t == QuoteRequestMsgType || t == QuoteMsgType
+}
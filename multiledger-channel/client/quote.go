@@ -0,0 +1,231 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/client"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+)
+
+const (
+	// QuoteValidity is how long a quote of the maker is valid.
+	QuoteValidity = 30 * time.Second
+	// quoteTimeout is how long the taker waits for a quote.
+	quoteTimeout = 10 * time.Second
+)
+
+// SwapRequest describes a partial swap of the taker.
+type SwapRequest struct {
+	Sell, Buy int      // The indices of the sold and the bought asset in the channel.
+	Amount    *big.Int // The amount of the sold asset.
+	Rate      Rate     // The expected rate.
+	Slippage  uint16   // The accepted deviation below Rate in basis points.
+}
+
+// validate checks the request for a channel with the given number of assets.
+func (r SwapRequest) validate(numAssets int) error {
+	if r.Sell < 0 || r.Sell >= numAssets || r.Buy < 0 || r.Buy >= numAssets || r.Sell == r.Buy {
+		return fmt.Errorf("invalid asset pair: %d, %d", r.Sell, r.Buy)
+	}
+	if r.Amount == nil || r.Amount.Sign() <= 0 {
+		return fmt.Errorf("invalid amount: %v", r.Amount)
+	}
+	if r.Slippage > MaxSlippage {
+		return fmt.Errorf("slippage exceeds %d basis points: %d", MaxSlippage, r.Slippage)
+	}
+	return r.Rate.Validate()
+}
+
+// SetRate sets the rate at which the client, as maker, buys asset sell for
+// asset buy in its swap channels. The client quotes this rate to the swap
+// requests of its peers.
+func (c *SwapClient) SetRate(sell, buy int, r Rate) error {
+	if sell < 0 || sell >= len(c.currencies) || buy < 0 || buy >= len(c.currencies) || sell == buy {
+		return fmt.Errorf("invalid asset pair: %d, %d", sell, buy)
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.rates[[2]int{sell, buy}] = r
+	return nil
+}
+
+// pendingQuote is a quote request waiting for the response of the peer of
+// the channel.
+type pendingQuote struct {
+	peer map[wallet.BackendID]wire.Address
+	resp chan *QuoteMsg
+}
+
+// requestQuote requests a quote for the swap from the peer of the channel
+// and checks that the quoted rate is within the slippage bounds.
+func (c *SwapClient) requestQuote(ch *client.Channel, req SwapRequest) (RateQuote, error) {
+	peer := ch.Peers()[1-ch.Idx()]
+	resp := make(chan *QuoteMsg, 1)
+	c.mtx.Lock()
+	if _, ok := c.pending[ch.ID()]; ok {
+		c.mtx.Unlock()
+		return RateQuote{}, fmt.Errorf("quote request already pending")
+	}
+	c.pending[ch.ID()] = &pendingQuote{peer: peer, resp: resp}
+	c.mtx.Unlock()
+	defer func() {
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+		delete(c.pending, ch.ID())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), quoteTimeout)
+	defer cancel()
+	minRate := req.Rate.WithSlippage(req.Slippage)
+	err := c.bus.Publish(ctx, &wire.Envelope{
+		Sender:    c.waddresss,
+		Recipient: peer,
+		Msg: &QuoteRequestMsg{
+			ChannelID: ch.ID(),
+			Sell:      uint16(req.Sell),
+			Buy:       uint16(req.Buy),
+			Amount:    req.Amount,
+			MinRate:   minRate,
+		},
+	})
+	if err != nil {
+		return RateQuote{}, errors.WithMessage(err, "sending quote request")
+	}
+
+	var msg *QuoteMsg
+	select {
+	case msg = <-resp:
+	case <-ctx.Done():
+		return RateQuote{}, errors.WithMessage(ctx.Err(), "waiting for quote")
+	}
+	if msg.Reason != "" {
+		return RateQuote{}, fmt.Errorf("quote rejected: %s", msg.Reason)
+	}
+	if err := msg.Rate.Validate(); err != nil {
+		return RateQuote{}, err
+	}
+	if msg.Rate.Cmp(minRate) < 0 {
+		return RateQuote{}, fmt.Errorf("quoted rate %v exceeds slippage bound %v", msg.Rate, minRate)
+	}
+	return RateQuote{
+		Taker:  ch.Idx(),
+		Sell:   req.Sell,
+		Buy:    req.Buy,
+		Amount: req.Amount,
+		Rate:   msg.Rate,
+		Expiry: time.Unix(msg.Expiry, 0),
+	}, nil
+}
+
+// handleQuoteMsgs handles the messages of the quote protocol until the
+// client shuts down.
+func (c *SwapClient) handleQuoteMsgs() {
+	for {
+		e, err := c.quoteRecv.Next(context.Background())
+		if err != nil {
+			return // The receiver was closed.
+		}
+
+		switch msg := e.Msg.(type) {
+		case *QuoteRequestMsg:
+			reply := c.handleQuoteRequest(msg)
+			ctx, cancel := context.WithTimeout(context.Background(), quoteTimeout)
+			err := c.bus.Publish(ctx, &wire.Envelope{Sender: c.waddresss, Recipient: e.Sender, Msg: reply})
+			cancel()
+			if err != nil {
+				log.Printf("Sending quote: %v", err)
+			}
+		case *QuoteMsg:
+			c.handleQuote(e.Sender, msg)
+		}
+	}
+}
+
+// handleQuote forwards the quote to the pending request of its channel. A
+// quote of anyone but the peer of the channel is dropped.
+func (c *SwapClient) handleQuote(sender map[wallet.BackendID]wire.Address, msg *QuoteMsg) {
+	c.mtx.Lock()
+	p, ok := c.pending[msg.ChannelID]
+	c.mtx.Unlock()
+	if !ok {
+		return
+	}
+	if !channel.EqualWireMaps(sender, p.peer) {
+		log.Printf("Dropping quote for channel %x from %v, who is not the peer", msg.ChannelID, sender)
+		return
+	}
+	select {
+	case p.resp <- msg:
+	default: // A quote was already received.
+	}
+}
+
+// handleQuoteRequest quotes the rate of the client for the request. The
+// quote is recorded, so that HandleUpdate accepts the swap at this rate.
+func (c *SwapClient) handleQuoteRequest(req *QuoteRequestMsg) *QuoteMsg {
+	q, err := c.quote(req)
+	if err != nil {
+		return &QuoteMsg{ChannelID: req.ChannelID, Reason: err.Error()}
+	}
+	c.quotes.set(req.ChannelID, q)
+	return &QuoteMsg{ChannelID: req.ChannelID, Rate: q.Rate, Expiry: q.Expiry.Unix()}
+}
+
+// quote returns the quote of the client for the request.
+func (c *SwapClient) quote(req *QuoteRequestMsg) (RateQuote, error) {
+	c.mtx.Lock()
+	ch, ok := c.swapChannels[req.ChannelID]
+	rate, hasRate := c.rates[[2]int{int(req.Sell), int(req.Buy)}]
+	c.mtx.Unlock()
+	if !ok {
+		return RateQuote{}, fmt.Errorf("unknown channel")
+	}
+	sreq := SwapRequest{Sell: int(req.Sell), Buy: int(req.Buy), Amount: req.Amount, Rate: req.MinRate}
+	if err := sreq.validate(len(ch.assets)); err != nil {
+		return RateQuote{}, err
+	}
+	if !hasRate {
+		return RateQuote{}, fmt.Errorf("no rate for asset %d to %d", req.Sell, req.Buy)
+	}
+	if rate.Cmp(req.MinRate) < 0 {
+		return RateQuote{}, fmt.Errorf("rate %v below minimum %v", rate, req.MinRate)
+	}
+
+	q := RateQuote{
+		Taker:  1 - ch.ch.Idx(),
+		Sell:   sreq.Sell,
+		Buy:    sreq.Buy,
+		Amount: req.Amount,
+		Rate:   rate,
+		Expiry: time.Now().Add(QuoteValidity).Truncate(time.Second),
+	}
+	// Both participants must be able to afford the full swap.
+	if _, err := q.Quote(len(ch.assets), q.Amount).apply(ch.ch.State().Balances); err != nil {
+		return RateQuote{}, err
+	}
+	return q, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"perun.network/go-perun/channel"
+)
+
+// MaxSlippage is a slippage of 100% in basis points.
+const MaxSlippage = 10000
+
+// Rate is an exchange rate: Num units of the bought asset are paid for Den
+// units of the sold asset.
+type Rate struct {
+	Num, Den *big.Int
+}
+
+// NewRate returns the rate of num units of the bought asset per den units of
+// the sold asset.
+func NewRate(num, den int64) Rate {
+	return Rate{Num: big.NewInt(num), Den: big.NewInt(den)}
+}
+
+func (r Rate) String() string {
+	return fmt.Sprintf("%v:%v", r.Num, r.Den)
+}
+
+// Validate checks that the rate is positive.
+func (r Rate) Validate() error {
+	if r.Num == nil || r.Den == nil || r.Num.Sign() <= 0 || r.Den.Sign() <= 0 {
+		return fmt.Errorf("invalid rate: %v", r)
+	}
+	return nil
+}
+
+// Mul returns the amount of the bought asset paid for v units of the sold
+// asset, rounded down.
+func (r Rate) Mul(v *big.Int) *big.Int {
+	p := new(big.Int).Mul(v, r.Num)
+	return p.Quo(p, r.Den)
+}
+
+// Cmp compares the rate with o and returns -1, 0 or 1 if the rate is less
+// than, equal to or greater than o.
+func (r Rate) Cmp(o Rate) int {
+	return new(big.Int).Mul(r.Num, o.Den).Cmp(new(big.Int).Mul(o.Num, r.Den))
+}
+
+// WithSlippage returns the rate reduced by the slippage in basis points.
+func (r Rate) WithSlippage(bps uint16) Rate {
+	if bps > MaxSlippage {
+		bps = MaxSlippage
+	}
+	return Rate{
+		Num: new(big.Int).Mul(r.Num, big.NewInt(int64(MaxSlippage-bps))),
+		Den: new(big.Int).Mul(r.Den, big.NewInt(MaxSlippage)),
+	}
+}
+
+// RateQuote is a quote of the maker of a swap channel: the taker may sell up
+// to Amount of asset Sell and receives Rate times the sold amount of asset
+// Buy from the maker. The quote may be executed partially and expires at
+// Expiry.
+type RateQuote struct {
+	Taker     channel.Index
+	Sell, Buy int
+	Amount    *big.Int
+	Rate      Rate
+	Expiry    time.Time
+}
+
+// Quote returns the exchange vector of a swap of the given amount at the
+// quoted rate.
+func (q RateQuote) Quote(numAssets int, amount *big.Int) Quote {
+	ex := Quote{Exchange: make([]*big.Int, numAssets)}
+	for i := range ex.Exchange {
+		ex.Exchange[i] = new(big.Int)
+	}
+	// The exchange vector holds what participant 0 pays participant 1.
+	ex.Exchange[q.Sell].Set(amount)
+	ex.Exchange[q.Buy].Neg(q.Rate.Mul(amount))
+	if q.Taker == 1 {
+		ex.Exchange[q.Sell].Neg(ex.Exchange[q.Sell])
+		ex.Exchange[q.Buy].Neg(ex.Exchange[q.Buy])
+	}
+	return ex
+}
+
+// validate checks that the update from cur to next sells at most Amount of
+// asset Sell for asset Buy at the quoted rate, i.e., that the delta on Buy
+// is the rate times the delta on Sell, and that no other asset changes.
+func (q RateQuote) validate(cur, next *channel.State) error {
+	if time.Now().After(q.Expiry) {
+		return fmt.Errorf("quote expired at %v", q.Expiry)
+	}
+	if err := channel.AssertAssetsEqual(cur.Assets, next.Assets); err != nil {
+		return fmt.Errorf("invalid assets: %w", err)
+	}
+	if next.IsFinal {
+		return fmt.Errorf("partial swap must not be final")
+	}
+
+	maker := 1 - q.Taker
+	sold := new(big.Int).Sub(cur.Balances[q.Sell][q.Taker], next.Balances[q.Sell][q.Taker])
+	if sold.Sign() <= 0 || sold.Cmp(q.Amount) > 0 {
+		return fmt.Errorf("sold amount %v not in (0, %v]", sold, q.Amount)
+	}
+	// The update must be the swap of the sold amount at the quoted rate.
+	expected, err := q.Quote(len(cur.Balances), sold).apply(cur.Balances)
+	if err != nil {
+		return fmt.Errorf("invalid swap: %w", err)
+	}
+	if err := expected.AssertEqual(next.Balances); err != nil {
+		paid := new(big.Int).Sub(cur.Balances[q.Buy][maker], next.Balances[q.Buy][maker])
+		return fmt.Errorf("paid %v for %v at rate %v, expected %v: %w", paid, sold, q.Rate, q.Rate.Mul(sold), err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"perun.network/go-perun/channel"
+)
+
+// Quote is a swap negotiated by both participants of a swap channel. For each
+// asset of the channel, Exchange holds the amount that participant 0 pays to
+// participant 1. A negative amount is paid by participant 1 to participant 0.
+// If Final is set, the swap concludes the channel.
+type Quote struct {
+	Exchange []*big.Int
+	Final    bool
+}
+
+// swapTerms are the terms of a swap that both participants agreed on. The
+// participant performing the swap and the peer validate the update against
+// them.
+type swapTerms interface {
+	validate(cur, next *channel.State) error
+}
+
+// NewFullSwapQuote returns the quote that swaps the complete balances of the
+// two participants, i.e., each participant receives the other's funds, and
+// concludes the channel.
+func NewFullSwapQuote(bals channel.Balances) Quote {
+	q := Quote{Exchange: make([]*big.Int, len(bals)), Final: true}
+	for i, bal := range bals {
+		q.Exchange[i] = new(big.Int).Sub(bal[0], bal[1])
+	}
+	return q
+}
+
+// apply returns the balances after the exchange. It fails if a participant
+// does not hold enough of an asset.
+func (q Quote) apply(bals channel.Balances) (channel.Balances, error) {
+	if len(q.Exchange) != len(bals) {
+		return nil, fmt.Errorf("quote for %d assets, channel has %d", len(q.Exchange), len(bals))
+	}
+	next := bals.Clone()
+	for i, amount := range q.Exchange {
+		if len(bals[i]) != 2 {
+			return nil, fmt.Errorf("asset %d: expected 2 participants, got %d", i, len(bals[i]))
+		}
+		next[i][0] = new(big.Int).Sub(bals[i][0], amount)
+		next[i][1] = new(big.Int).Add(bals[i][1], amount)
+		for p, bal := range next[i] {
+			if bal.Sign() < 0 {
+				return nil, fmt.Errorf("asset %d: insufficient balance of participant %d", i, p)
+			}
+		}
+	}
+	return next, nil
+}
+
+// validate checks that next is the state that results from applying the
+// quote to cur.
+func (q Quote) validate(cur, next *channel.State) error {
+	if err := channel.AssertAssetsEqual(cur.Assets, next.Assets); err != nil {
+		return fmt.Errorf("invalid assets: %w", err)
+	}
+	expected, err := q.apply(cur.Balances)
+	if err != nil {
+		return fmt.Errorf("invalid quote: %w", err)
+	}
+	if err := expected.AssertEqual(next.Balances); err != nil {
+		return fmt.Errorf("balances do not match quote: %w", err)
+	}
+	if next.IsFinal != q.Final {
+		return fmt.Errorf("final flag: expected %t", q.Final)
+	}
+	return nil
+}
+
+// isFinalization returns whether the update from cur to next only finalizes
+// the channel, which allows to settle it without a dispute.
+func isFinalization(cur, next *channel.State) bool {
+	return next.IsFinal && !cur.IsFinal &&
+		channel.AssertAssetsEqual(cur.Assets, next.Assets) == nil &&
+		cur.Balances.AssertEqual(next.Balances) == nil &&
+		len(cur.Locked) == 0 && len(next.Locked) == 0
+}
+
+// quoteBook holds the swap terms agreed on per channel. New terms replace
+// the previous ones.
+type quoteBook struct {
+	mtx    sync.Mutex
+	quotes map[channel.ID]swapTerms
+}
+
+func newQuoteBook() *quoteBook {
+	return &quoteBook{quotes: make(map[channel.ID]swapTerms)}
+}
+
+func (b *quoteBook) set(id channel.ID, q swapTerms) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.quotes[id] = q
+}
+
+func (b *quoteBook) get(id channel.ID) (swapTerms, bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	q, ok := b.quotes[id]
+	return q, ok
+}
+
+// remove removes the terms of a performed swap, so that they are not applied
+// twice.
+func (b *quoteBook) remove(id channel.ID) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	delete(b.quotes, id)
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethchannel "github.com/perun-network/perun-eth-backend/channel"
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+	"perun.network/go-perun/wire/net/simple"
+)
+
+// newTestState returns a state with three assets on three chains.
+func newTestState() *channel.State {
+	assets := make([]channel.Asset, 3)
+	for i := range assets {
+		assets[i] = ethchannel.NewAsset(big.NewInt(int64(1337+i)), common.Address{byte(i)})
+	}
+	s := &channel.State{App: channel.NoApp(), Allocation: *channel.NewAllocation(2, []wallet.BackendID{1, 1, 1}, assets...), Data: channel.NoData()}
+	s.Balances = channel.Balances{
+		{big.NewInt(20), big.NewInt(0)},
+		{big.NewInt(0), big.NewInt(50)},
+		{big.NewInt(7), big.NewInt(3)},
+	}
+	return s
+}
+
+func TestValidateSwap(t *testing.T) {
+	cur := newTestState()
+
+	q := Quote{Exchange: []*big.Int{big.NewInt(15), big.NewInt(-50), big.NewInt(-1)}, Final: true}
+	next := cur.Clone()
+	bals, err := q.apply(cur.Balances)
+	require.NoError(t, err)
+	next.Balances, next.IsFinal = bals, true
+	require.NoError(t, q.validate(cur, next))
+	require.Equal(t, int64(5), next.Balances[0][0].Int64())
+	require.Equal(t, int64(50), next.Balances[1][0].Int64())
+	require.Equal(t, int64(2), next.Balances[2][1].Int64())
+
+	// The full swap exchanges the complete balances.
+	full := NewFullSwapQuote(cur.Balances)
+	require.Equal(t, []*big.Int{big.NewInt(20), big.NewInt(-50), big.NewInt(4)}, full.Exchange)
+
+	// The peer rejects deviations from the quote.
+	other := Quote{Exchange: []*big.Int{big.NewInt(15), big.NewInt(-50), big.NewInt(0)}, Final: true}
+	require.ErrorContains(t, other.validate(cur, next), "balances do not match quote")
+	next.IsFinal = false
+	require.ErrorContains(t, q.validate(cur, next), "final flag: expected true")
+
+	_, err = Quote{Exchange: []*big.Int{big.NewInt(21), big.NewInt(0), big.NewInt(0)}}.apply(cur.Balances)
+	require.ErrorContains(t, err, "insufficient balance of participant 0")
+	_, err = Quote{Exchange: []*big.Int{big.NewInt(1)}}.apply(cur.Balances)
+	require.ErrorContains(t, err, "quote for 1 assets, channel has 3")
+}
+
+func TestRateQuote(t *testing.T) {
+	cur := newTestState()
+	// Participant 0 sells up to 10 of asset 0 for 5 of asset 1 per 2 units.
+	q := RateQuote{Taker: 0, Sell: 0, Buy: 1, Amount: big.NewInt(10), Rate: NewRate(5, 2), Expiry: time.Now().Add(time.Minute)}
+
+	swap := func(sold, paid int64) *channel.State {
+		next := cur.Clone()
+		next.Balances[0][0].Sub(next.Balances[0][0], big.NewInt(sold))
+		next.Balances[0][1].Add(next.Balances[0][1], big.NewInt(sold))
+		next.Balances[1][1].Sub(next.Balances[1][1], big.NewInt(paid))
+		next.Balances[1][0].Add(next.Balances[1][0], big.NewInt(paid))
+		return next
+	}
+	// The quote may be executed partially and the payment is rounded down.
+	require.NoError(t, q.validate(cur, swap(10, 25)))
+	require.NoError(t, q.validate(cur, swap(3, 7)))
+
+	require.ErrorContains(t, q.validate(cur, swap(3, 6)), "paid 6 for 3 at rate 5:2, expected 7")
+	require.ErrorContains(t, q.validate(cur, swap(11, 27)), "sold amount 11 not in (0, 10]")
+	require.ErrorContains(t, q.validate(cur, swap(0, 0)), "sold amount 0 not in (0, 10]")
+	final := swap(2, 5)
+	final.IsFinal = true
+	require.ErrorContains(t, q.validate(cur, final), "partial swap must not be final")
+	q.Expiry = time.Now().Add(-time.Second)
+	require.ErrorContains(t, q.validate(cur, swap(2, 5)), "quote expired")
+
+	// Slippage lowers the accepted rate.
+	require.Equal(t, 0, NewRate(99, 50).Cmp(NewRate(2, 1).WithSlippage(100)))
+	require.Equal(t, -1, NewRate(98, 50).Cmp(NewRate(2, 1).WithSlippage(100)))
+}
+
+func TestQuoteMsgs(t *testing.T) {
+	req := &QuoteRequestMsg{ChannelID: channel.ID{1, 2}, Sell: 2, Buy: 1, Amount: big.NewInt(42), MinRate: NewRate(3, 4)}
+	var buf bytes.Buffer
+	require.NoError(t, req.Encode(&buf))
+	var decReq QuoteRequestMsg
+	require.NoError(t, decReq.Decode(&buf))
+	require.Equal(t, req, &decReq)
+
+	// A rejection carries no rate.
+	for _, msg := range []*QuoteMsg{
+		{ChannelID: channel.ID{3}, Rate: NewRate(1, 2), Expiry: 1700000000},
+		{ChannelID: channel.ID{3}, Rate: NewRate(0, 0), Reason: "no rate"},
+	} {
+		buf.Reset()
+		require.NoError(t, msg.Encode(&buf))
+		var dec QuoteMsg
+		require.NoError(t, dec.Decode(&buf))
+		require.Equal(t, msg, &dec)
+	}
+}
+
+func TestCheckUpdate(t *testing.T) {
+	c := &SwapClient{quotes: newQuoteBook()}
+	cur := newTestState()
+	final := cur.Clone()
+	final.IsFinal = true
+	swap := cur.Clone()
+	swap.Balances[0] = []*big.Int{big.NewInt(19), big.NewInt(1)}
+
+	require.NoError(t, c.checkUpdate(cur, final))
+	require.ErrorContains(t, c.checkUpdate(cur, swap), "no quote agreed")
+
+	// A quote that the peer never uses does not block the settlement.
+	c.quotes.set(cur.ID, Quote{Exchange: []*big.Int{big.NewInt(15), big.NewInt(-50), big.NewInt(-1)}, Final: true})
+	require.NoError(t, c.checkUpdate(cur, final))
+	require.Error(t, c.checkUpdate(cur, swap))
+}
+
+func TestHandleQuote(t *testing.T) {
+	peer := map[wallet.BackendID]wire.Address{1: simple.NewAddress("peer")}
+	other := map[wallet.BackendID]wire.Address{1: simple.NewAddress("other")}
+	resp := make(chan *QuoteMsg, 1)
+	c := &SwapClient{pending: map[channel.ID]*pendingQuote{{1}: {peer: peer, resp: resp}}}
+	msg := &QuoteMsg{ChannelID: channel.ID{1}, Rate: NewRate(1, 2)}
+
+	// Only the peer of the channel may answer the request.
+	c.handleQuote(other, msg)
+	require.Empty(t, resp)
+	c.handleQuote(peer, msg)
+	require.Equal(t, msg, <-resp)
+}
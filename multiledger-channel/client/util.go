@@ -15,7 +15,10 @@
 package client
 
 import (
+	"context"
+	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -26,23 +29,35 @@ import (
 
 	"perun.network/go-perun/wallet"
 	"perun.network/go-perun/wire"
+	"perun.network/perun-examples/shared/chainconfig"
 )
 
-// CreateContractBackend creates a new contract backend.
+// dialTimeout is how long connecting to a node may take.
+const dialTimeout = 10 * time.Second
+
+// CreateContractBackend creates a new contract backend. It fails if the node
+// does not report the expected chain ID.
 func CreateContractBackend(
 	nodeURL string,
 	chainID *big.Int,
 	w *swallet.Wallet,
+	finalityDepth uint64,
 ) (ethchannel.ContractBackend, error) {
 	signer := types.LatestSignerForChainID(chainID)
 	transactor := swallet.NewTransactor(w, signer)
 
-	ethClient, err := ethclient.Dial(nodeURL)
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+	ethClient, err := ethclient.DialContext(ctx, nodeURL)
 	if err != nil {
 		return ethchannel.ContractBackend{}, err
 	}
+	if err := chainconfig.CheckChainID(ctx, ethClient, chainID); err != nil {
+		ethClient.Close()
+		return ethchannel.ContractBackend{}, fmt.Errorf("node at %s: %w", nodeURL, err)
+	}
 
-	return ethchannel.NewContractBackend(ethClient, ethchannel.MakeChainID(chainID), transactor, txFinalityDepth), nil
+	return ethchannel.NewContractBackend(ethClient, ethchannel.MakeChainID(chainID), transactor, finalityDepth), nil
 }
 
 // WalletAddress returns the wallet address of the client.
@@ -50,6 +65,11 @@ func (c *SwapClient) WalletAddress() common.Address {
 	return common.Address(*c.account[1].(*ethwallet.Address))
 }
 
+// NumAssets returns the number of assets in the channels of the client.
+func (c *SwapClient) NumAssets() int {
+	return len(c.currencies)
+}
+
 // WireAddress returns the wire address of the client.
 func (c *SwapClient) WireAddress() map[wallet.BackendID]wire.Address {
 	return c.waddresss
@@ -0,0 +1,105 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command deploy deploys the contracts of the swap channel on each chain and
+// records them in the deployment registry. Contracts that are recorded and
+// still deployed are reused, so the command can be run repeatedly.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	swallet "github.com/perun-network/perun-eth-backend/wallet/simple"
+	"github.com/pkg/errors"
+	"perun.network/perun-examples/multiledger-channel/client"
+	"perun.network/perun-examples/multiledger-channel/deployment"
+	"perun.network/perun-examples/shared/chainconfig"
+	"perun.network/perun-examples/shared/registry"
+)
+
+const (
+	// keyDeployer is the private key of the deployer account of the demo.
+	keyDeployer = "79ea8f62d97bc0591a4224c1725fca6b00de5b2cea286fe2e0bb35c5e76be46e"
+	// demoHolders are the accounts of Alice and Bob.
+	demoHolders = "0x56FD289cEe714a5E471c418436EFA63E780D7a87,0x6536425BE95A6661F6C6f68D709B6BE152785Df6"
+)
+
+func main() {
+	config := flag.String("config", "chains.yaml", "path of the chain configuration file")
+	key := flag.String("key", keyDeployer, "private key of the deployer")
+	holders := flag.String("holders", demoHolders, "comma-separated accounts that a new token mints -amount tokens to")
+	amount := flag.Int64("amount", 100, "number of tokens a new token mints to each holder")
+	registryFile := flag.String("registry", registry.DefaultFile, "path of the deployment registry")
+	flag.Parse()
+
+	var holderAddrs []common.Address
+	for _, h := range strings.Split(*holders, ",") {
+		if !common.IsHexAddress(h) {
+			log.Fatalf("Invalid holder address: %q", h)
+		}
+		holderAddrs = append(holderAddrs, common.HexToAddress(h))
+	}
+
+	cfg, err := chainconfig.Load(*config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	r, err := registry.Load(*registryFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, chain := range cfg.Chains {
+		err = deploy(chain, *key, r, holderAddrs, big.NewInt(*amount))
+		if err != nil {
+			break
+		}
+	}
+	// Save the registry also if a deployment failed, so that the deployed
+	// contracts are reused.
+	if serr := r.Save(*registryFile); serr != nil && err == nil {
+		err = serr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Recorded in %s.", *registryFile)
+}
+
+// deploy deploys the contracts that are missing on the chain.
+func deploy(chain chainconfig.Chain, key string, r *registry.Registry, holders []common.Address, amount *big.Int) error {
+	chainID := chain.ID()
+	k, err := crypto.HexToECDSA(key)
+	if err != nil {
+		return errors.WithMessage(err, "parsing private key")
+	}
+	cb, err := client.CreateContractBackend(chain.RPCURL, chainID, swallet.NewWallet(k), chain.FinalityDepth)
+	if err != nil {
+		return errors.WithMessagef(err, "creating contract backend for chain %s", chain.Name)
+	}
+	acc := accounts.Account{Address: crypto.PubkeyToAddress(k.PublicKey)}
+
+	c, err := deployment.Deploy(context.TODO(), cb, acc, r, holders, amount)
+	if err != nil {
+		return errors.WithMessagef(err, "chain %s", chain.Name)
+	}
+	log.Printf("Chain %s (%v): token: %v, adjudicator: %v, asset holder: %v.", chain.Name, chainID, c.Token, c.Adjudicator, c.AssetHolder)
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethchannel "github.com/perun-network/perun-eth-backend/channel"
+	"perun.network/perun-examples/shared/registry"
+)
+
+// The names of the contracts in the registry.
+const (
+	TokenName            = "perunToken"
+	AdjudicatorName      = "adjudicator"
+	AssetHolderERC20Name = "assetHolderERC20"
+)
+
+// Contracts are the contracts of the swap channel on a chain.
+type Contracts struct {
+	Token       common.Address // The ERC20 PerunToken.
+	Adjudicator common.Address
+	AssetHolder common.Address // The ERC20 asset holder of the token.
+}
+
+// Deploy deploys the contracts of the swap channel on the chain of the
+// backend and records them in the registry. A new token mints amount tokens
+// to each of the holders. Contracts that are recorded and deployed are
+// reused. A contract is deployed anew if its code is missing, e.g., after a
+// development chain was restarted, or if a contract it depends on was
+// deployed anew.
+func Deploy(ctx context.Context, cb ethchannel.ContractBackend, deployer accounts.Account, r *registry.Registry, holders []common.Address, amount *big.Int) (Contracts, error) {
+	token, newToken, err := r.Ensure(ctx, cb, TokenName, false, func() (common.Address, error) {
+		return ethchannel.DeployPerunToken(ctx, cb, deployer, holders, amount)
+	})
+	if err != nil {
+		return Contracts{}, err
+	}
+	adj, newAdj, err := r.Ensure(ctx, cb, AdjudicatorName, false, func() (common.Address, error) {
+		return ethchannel.DeployAdjudicator(ctx, cb, deployer)
+	})
+	if err != nil {
+		return Contracts{}, err
+	}
+	ah, _, err := r.Ensure(ctx, cb, AssetHolderERC20Name, newToken || newAdj, func() (common.Address, error) {
+		return ethchannel.DeployERC20Assetholder(ctx, cb, adj.Address, token.Address, deployer)
+	})
+	if err != nil {
+		return Contracts{}, err
+	}
+	return Contracts{Token: token.Address, Adjudicator: adj.Address, AssetHolder: ah.Address}, nil
+}
+
+// Lookup returns the contracts recorded for the chain and verifies that
+// their code is deployed.
+func Lookup(ctx context.Context, backend bind.ContractBackend, chainID *big.Int, r *registry.Registry) (c Contracts, err error) {
+	if c.Token, err = r.Lookup(ctx, backend, chainID, TokenName); err != nil {
+		return c, err
+	}
+	if c.Adjudicator, err = r.Lookup(ctx, backend, chainID, AdjudicatorName); err != nil {
+		return c, err
+	}
+	if c.AssetHolder, err = r.Lookup(ctx, backend, chainID, AssetHolderERC20Name); err != nil {
+		return c, err
+	}
+	return c, nil
+}
@@ -8,7 +8,10 @@ require (
 	github.com/ethereum/go-ethereum v1.15.11
 	github.com/perun-network/perun-eth-backend v0.6.0
 	github.com/pkg/errors v0.9.1
+	github.com/stretchr/testify v1.10.0
 	perun.network/go-perun v0.15.0
+	perun.network/perun-examples/shared/chainconfig v0.0.0
+	perun.network/perun-examples/shared/registry v0.0.0
 )
 
 require (
@@ -113,7 +116,6 @@ require (
 	github.com/shirou/gopsutil v3.21.4-0.20210419000835-c7a38de76ee5+incompatible // indirect
 	github.com/sirupsen/logrus v1.9.3 // indirect
 	github.com/spaolacci/murmur3 v1.1.0 // indirect
-	github.com/stretchr/testify v1.10.0 // indirect
 	github.com/supranational/blst v0.3.14 // indirect
 	github.com/tklauser/go-sysconf v0.3.12 // indirect
 	github.com/tklauser/numcpus v0.6.1 // indirect
@@ -137,3 +139,8 @@ require (
 	polycry.pt/poly-go v0.0.0-20220301085937-fb9d71b45a37 // indirect
 	rsc.io/tmplfunc v0.0.3 // indirect
 )
+
+replace (
+	perun.network/perun-examples/shared/chainconfig => ../shared/chainconfig
+	perun.network/perun-examples/shared/registry => ../shared/registry
+)
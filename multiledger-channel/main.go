@@ -20,52 +20,36 @@ import (
 	"math/rand"
 	"time"
 
-	echannel "github.com/perun-network/perun-eth-backend/channel"
 	ewallet "github.com/perun-network/perun-eth-backend/wallet"
 	p2p "perun.network/go-perun/wire/net/libp2p"
 
-	"perun.network/go-perun/channel"
 	"perun.network/go-perun/wallet"
 	"perun.network/go-perun/wire"
 	"perun.network/perun-examples/multiledger-channel/client"
+	"perun.network/perun-examples/multiledger-channel/rfq"
 )
 
 const (
-	// The URLs and IDs of the two chains.
-	chainAURL = "ws://127.0.0.1:8545"
-	chainAID  = 1337
-	chainBURL = "ws://127.0.0.1:8546"
-	chainBID  = 1338
+	// The chain configuration file.
+	chainsFile = "chains.yaml"
 
 	// Private keys.
-	keyDeployer = "79ea8f62d97bc0591a4224c1725fca6b00de5b2cea286fe2e0bb35c5e76be46e"
-	keyAlice    = "1af2e950272dd403de7a5760d41c6e44d92b6d02797e51810795ff03cc2cda4f"
-	keyBob      = "f63d7d8e930bccd74e93cf5662fde2c28fd8be95edb70c73f1bdd863d07f412e"
-
-	initialTokenAmount = 100
+	keyAlice = "1af2e950272dd403de7a5760d41c6e44d92b6d02797e51810795ff03cc2cda4f"
+	keyBob   = "f63d7d8e930bccd74e93cf5662fde2c28fd8be95edb70c73f1bdd863d07f412e"
 )
 
-// main runs a demo of a multi-ledger channel. It assumes that two blockchain
-// nodes are available at `chainAURL` and `chainBURL` and that the accounts
-// corresponding to the specified secret keys are provided with sufficient funds.
+// main runs a demo of a multi-ledger channel. It assumes that the two chains
+// configured in `chainsFile` are available, that the contracts were
+// deployed on both with `cmd/deploy` and that the accounts corresponding to
+// the specified secret keys are provided with sufficient funds.
 func main() {
-	// Deploy contracts.
-	log.Println("Deploying contracts.")
-
-	chainA := client.ChainConfig{
-		ChainID:  echannel.MakeChainID(big.NewInt(chainAID)),
-		ChainURL: chainAURL,
-	}
-	chainB := client.ChainConfig{
-		ChainID:  echannel.MakeChainID(big.NewInt(chainBID)),
-		ChainURL: chainBURL,
-	}
+	// Load the chain configurations and the deployed contracts.
+	log.Println("Loading chains and contracts.")
 
-	chains := [2]client.ChainConfig{chainA, chainB}
-
-	// deployContracts will set the contract addresses of the chain
-	// configurations after it deployed them.
-	deployContracts(chains[:])
+	// The channel holds one asset per configured chain. More chains can be
+	// added to the file; the offer and the swaps below refer to assets by
+	// index.
+	chains := loadChains(chainsFile)
 
 	// Setup bus.
 	aliceWireAcc := p2p.NewRandomAccount(rand.New(rand.NewSource(time.Now().UnixNano())))
@@ -81,25 +65,47 @@ func main() {
 	bob := setupPaymentClient(bobBus, keyBob, chains, bobWireAcc.Address())
 
 	// Print balances before transactions.
-	l := newBalanceLogger(chains[0], chains[1])
+	l := newBalanceLogger(chains)
 	l.LogBalances(alice.WalletAddress(), bob.WalletAddress())
 
-	// Open channel, transact, close.
-	log.Println("Opening channel and depositing funds.")
-
-	var alicePRNChainA int64 = 20 // Alice puts 20 PRN from chain A in the channel.
-	var bobPRNChainB int64 = 50   // Bob puts 50 PRN from chain B in the channel.
-	// The balances that each party puts in the channel.
-	balances := channel.Balances{
-		{big.NewInt(alicePRNChainA), big.NewInt(0)},
-		{big.NewInt(0), big.NewInt(bobPRNChainB)},
+	// Bob makes a market at an RFQ service: he buys up to 20 PRN on chain A
+	// and pays 2 PRN on chain B per PRN on chain A.
+	svc := rfq.NewMemoryService()
+	rate := client.NewRate(2, 1)
+	maker := rfq.NewMaker(bob, svc)
+	if _, err := maker.Publish(rfq.Offer{Sell: 0, Buy: 1, Rate: rate, Size: big.NewInt(20)}); err != nil {
+		panic(err)
 	}
 
-	chAlice := alice.OpenChannel(bob.WireAddress(), balances)
+	// Alice requests a quote for selling 10 PRN on chain A. The match opens a
+	// channel in which Alice deposits 10 PRN on chain A and Bob deposits 20
+	// PRN on chain B.
+	log.Println("Matching offer, opening channel and depositing funds.")
+	taker := rfq.NewTaker(alice, svc)
+	chAlice, match, err := taker.Take(rfq.Request{Sell: 0, Buy: 1, Amount: big.NewInt(10), MinRate: rate})
+	if err != nil {
+		panic(err)
+	}
 	chBob := bob.AcceptedChannel()
 
-	log.Println("Performing the swap...")
-	chAlice.PerformSwap()
+	// Alice sells the matched amount in two swaps of 5 PRN on chain A. She
+	// requests a quote from Bob for each swap and accepts a rate of up to 1%
+	// below the one of the offer.
+	half := new(big.Int).Quo(match.Amount, big.NewInt(2))
+	for i := 0; i < 2; i++ {
+		log.Println("Performing a swap...")
+		q, err := chAlice.Swap(client.SwapRequest{
+			Sell:     match.Offer.Sell,
+			Buy:      match.Offer.Buy,
+			Amount:   half,
+			Rate:     match.Offer.Rate,
+			Slippage: 100,
+		})
+		if err != nil {
+			panic(err)
+		}
+		log.Printf("Swapped %v PRN on chain A at rate %v.", q.Amount, q.Rate)
+	}
 
 	log.Println("Settling channel.")
 	chAlice.Settle() // Conclude and withdraw.
@@ -0,0 +1,29 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"perun.network/perun-examples/shared/chainconfig"
+)
+
+// TestChainsFile checks that the shipped chain configuration parses.
+func TestChainsFile(t *testing.T) {
+	cfg, err := chainconfig.Load(chainsFile)
+	require.NoError(t, err)
+	require.Len(t, cfg.Chains, 2)
+}
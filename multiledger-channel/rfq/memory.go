@@ -0,0 +1,157 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rfq
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+)
+
+// MemoryService is a Service that holds the offers and matches in memory. It
+// is meant for tests and for makers and takers in the same process.
+type MemoryService struct {
+	mtx       sync.Mutex
+	nextOffer OfferID
+	nextMatch MatchID
+	offers    map[OfferID]*Offer
+	matches   map[MatchID]Match
+	confirmed map[MatchID]bool // The matches whose channel the maker accepted.
+}
+
+var _ Service = (*MemoryService)(nil)
+
+// NewMemoryService creates an empty in-memory service.
+func NewMemoryService() *MemoryService {
+	return &MemoryService{
+		offers:    make(map[OfferID]*Offer),
+		matches:   make(map[MatchID]Match),
+		confirmed: make(map[MatchID]bool),
+	}
+}
+
+// Publish publishes the offer and returns its ID.
+func (s *MemoryService) Publish(o Offer) (OfferID, error) {
+	if err := o.validate(); err != nil {
+		return 0, err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.nextOffer++
+	o.ID = s.nextOffer
+	o.Size = new(big.Int).Set(o.Size)
+	s.offers[o.ID] = &o
+	return o.ID, nil
+}
+
+// Withdraw removes the offer.
+func (s *MemoryService) Withdraw(id OfferID) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.offers[id]; !ok {
+		return fmt.Errorf("unknown offer: %d", id)
+	}
+	delete(s.offers, id)
+	return nil
+}
+
+// Request matches the request with the offer of the best rate. Of several
+// offers with the best rate, the earliest is matched.
+func (s *MemoryService) Request(req Request) (Match, error) {
+	if err := req.validate(); err != nil {
+		return Match{}, err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var best *Offer
+	for _, o := range s.offers {
+		if o.Sell != req.Sell || o.Buy != req.Buy || o.Size.Cmp(req.Amount) < 0 ||
+			o.Rate.Cmp(req.MinRate) < 0 || equalAddrs(o.Maker, req.Taker) {
+			continue
+		}
+		if best == nil {
+			best = o
+		} else if c := o.Rate.Cmp(best.Rate); c > 0 || (c == 0 && o.ID < best.ID) {
+			best = o
+		}
+	}
+	if best == nil {
+		return Match{}, fmt.Errorf("no offer for %v of asset %d to %d at rate %v", req.Amount, req.Sell, req.Buy, req.MinRate)
+	}
+
+	// Reserve the amount. A filled offer stays published until the maker
+	// withdraws it, so that cancelled matches can return their amount.
+	best.Size.Sub(best.Size, req.Amount)
+	offer := *best
+	offer.Size = new(big.Int).Set(best.Size)
+	s.nextMatch++
+	m := Match{ID: s.nextMatch, Offer: offer, Taker: req.Taker, Amount: new(big.Int).Set(req.Amount)}
+	s.matches[m.ID] = m
+	return m, nil
+}
+
+// Confirm returns the pending match of the proposed channel and marks it as
+// confirmed.
+func (s *MemoryService) Confirm(maker, taker map[wallet.BackendID]wire.Address, bals channel.Balances) (Match, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for id, m := range s.matches {
+		if s.confirmed[id] || !equalAddrs(m.Offer.Maker, maker) || !equalAddrs(m.Taker, taker) {
+			continue
+		}
+		if m.Balances(len(bals)).AssertEqual(bals) != nil {
+			continue
+		}
+		s.confirmed[id] = true
+		return m, nil
+	}
+	return Match{}, fmt.Errorf("no match for the proposed balances")
+}
+
+// Complete removes the match of an opened channel.
+func (s *MemoryService) Complete(id MatchID) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.matches[id]; !ok {
+		return fmt.Errorf("unknown match: %d", id)
+	}
+	if !s.confirmed[id] {
+		return fmt.Errorf("match not confirmed: %d", id)
+	}
+	delete(s.matches, id)
+	delete(s.confirmed, id)
+	return nil
+}
+
+// Cancel removes the match and returns its amount to the offer.
+func (s *MemoryService) Cancel(id MatchID) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	m, ok := s.matches[id]
+	if !ok {
+		return fmt.Errorf("unknown match: %d", id)
+	}
+	delete(s.matches, id)
+	delete(s.confirmed, id)
+	if o, ok := s.offers[m.Offer.ID]; ok {
+		o.Size.Add(o.Size, m.Amount)
+	}
+	return nil
+}
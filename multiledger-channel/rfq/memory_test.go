@@ -0,0 +1,124 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rfq
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+	"perun.network/go-perun/wire/net/simple"
+	"perun.network/perun-examples/multiledger-channel/client"
+)
+
+func addr(host string) map[wallet.BackendID]wire.Address {
+	return map[wallet.BackendID]wire.Address{1: simple.NewAddress(host)}
+}
+
+func TestMemoryService(t *testing.T) {
+	svc := NewMemoryService()
+	alice, bob, carol := addr("alice"), addr("bob"), addr("carol")
+
+	// Bob and Carol buy asset 0 for asset 1, Carol at the better rate.
+	bobOffer, err := svc.Publish(Offer{Maker: bob, Sell: 0, Buy: 1, Rate: client.NewRate(2, 1), Size: big.NewInt(100)})
+	require.NoError(t, err)
+	carolOffer, err := svc.Publish(Offer{Maker: carol, Sell: 0, Buy: 1, Rate: client.NewRate(5, 2), Size: big.NewInt(10)})
+	require.NoError(t, err)
+	_, err = svc.Publish(Offer{Maker: carol, Sell: 0, Buy: 0, Rate: client.NewRate(1, 1), Size: big.NewInt(10)})
+	require.ErrorContains(t, err, "invalid asset pair")
+
+	req := Request{Taker: alice, Sell: 0, Buy: 1, Amount: big.NewInt(8), MinRate: client.NewRate(2, 1)}
+	m, err := svc.Request(req)
+	require.NoError(t, err)
+	require.Equal(t, carolOffer, m.Offer.ID)
+	require.Equal(t, int64(2), m.Offer.Size.Int64())
+	bals := m.Balances(3)
+	require.Equal(t, int64(8), bals[0][0].Int64())
+	require.Equal(t, int64(20), bals[1][1].Int64())
+	require.Zero(t, bals[2][0].Sign()+bals[2][1].Sign())
+
+	// Carol's offer has too little size left, so Bob's is matched next.
+	m2, err := svc.Request(req)
+	require.NoError(t, err)
+	require.Equal(t, bobOffer, m2.Offer.ID)
+	_, err = svc.Request(Request{Taker: alice, Sell: 0, Buy: 1, Amount: big.NewInt(8), MinRate: client.NewRate(3, 1)})
+	require.ErrorContains(t, err, "no offer")
+
+	// The maker confirms only the proposal of the match.
+	_, err = svc.Confirm(bob, alice, bals)
+	require.ErrorContains(t, err, "no match")
+	confirmed, err := svc.Confirm(carol, alice, bals)
+	require.NoError(t, err)
+	require.Equal(t, m.ID, confirmed.ID)
+	_, err = svc.Confirm(carol, alice, bals)
+	require.ErrorContains(t, err, "no match")
+	require.ErrorContains(t, svc.Complete(m2.ID), "not confirmed")
+	require.NoError(t, svc.Complete(m.ID))
+	require.ErrorContains(t, svc.Cancel(m.ID), "unknown match")
+
+	// Cancelling a match returns its amount to the offer.
+	require.NoError(t, svc.Cancel(m2.ID))
+	fill := Request{Taker: alice, Sell: 0, Buy: 1, Amount: big.NewInt(100), MinRate: client.NewRate(2, 1)}
+	_, err = svc.Request(fill)
+	require.NoError(t, err)
+
+	// Withdrawn offers are not matched.
+	require.NoError(t, svc.Withdraw(carolOffer))
+	require.Error(t, svc.Withdraw(carolOffer))
+	_, err = svc.Request(Request{Taker: alice, Sell: 0, Buy: 1, Amount: big.NewInt(1), MinRate: client.NewRate(1, 1)})
+	require.ErrorContains(t, err, "no offer")
+}
+
+// failingClient is a taker client whose channel proposals are accepted by the
+// maker but fail to open.
+type failingClient struct {
+	svc   Service
+	maker map[wallet.BackendID]wire.Address
+	taker map[wallet.BackendID]wire.Address
+}
+
+func (c *failingClient) NumAssets() int { return 2 }
+
+func (c *failingClient) WireAddress() map[wallet.BackendID]wire.Address { return c.taker }
+
+func (c *failingClient) ProposeChannel(peer map[wallet.BackendID]wire.Address, bals channel.Balances) (*client.SwapChannel, error) {
+	if _, err := c.svc.Confirm(c.maker, c.taker, bals); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("funding failed")
+}
+
+func TestTakeOpenFails(t *testing.T) {
+	svc := NewMemoryService()
+	alice, bob := addr("alice"), addr("bob")
+	_, err := svc.Publish(Offer{Maker: bob, Sell: 0, Buy: 1, Rate: client.NewRate(2, 1), Size: big.NewInt(100)})
+	require.NoError(t, err)
+
+	taker := &Taker{client: &failingClient{svc: svc, maker: bob, taker: alice}, svc: svc}
+	req := Request{Sell: 0, Buy: 1, Amount: big.NewInt(100), MinRate: client.NewRate(2, 1)}
+	_, _, err = taker.Take(req)
+	require.ErrorContains(t, err, "funding failed")
+	require.NotContains(t, err.Error(), "cancelling match")
+
+	// The failed open returned the reserved amount to the offer.
+	req.Taker = alice
+	m, err := svc.Request(req)
+	require.NoError(t, err)
+	require.Zero(t, m.Offer.Size.Sign())
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rfq
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+	"perun.network/perun-examples/multiledger-channel/client"
+)
+
+// Maker publishes the offers of a swap client at a service. The client
+// accepts only the channels of matches of its offers and quotes the rates of
+// its offers for the swaps in them.
+type Maker struct {
+	client *client.SwapClient
+	svc    Service
+
+	mtx    sync.Mutex
+	offers map[[2]int]OfferID // The published offers per asset pair.
+}
+
+// NewMaker creates a maker for the client. It sets the proposal policy of the
+// client.
+func NewMaker(c *client.SwapClient, svc Service) *Maker {
+	m := &Maker{client: c, svc: svc, offers: make(map[[2]int]OfferID)}
+	c.SetProposalPolicy(m.checkProposal)
+	return m
+}
+
+// Publish publishes an offer for the asset pair. The client quotes one rate
+// per asset pair, so a maker publishes at most one offer per pair.
+func (m *Maker) Publish(o Offer) (OfferID, error) {
+	if o.Sell >= m.client.NumAssets() || o.Buy >= m.client.NumAssets() {
+		return 0, fmt.Errorf("invalid asset pair: %d, %d", o.Sell, o.Buy)
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	pair := [2]int{o.Sell, o.Buy}
+	if _, ok := m.offers[pair]; ok {
+		return 0, fmt.Errorf("offer for asset %d to %d already published", o.Sell, o.Buy)
+	}
+
+	o.Maker = m.client.WireAddress()
+	if err := m.client.SetRate(o.Sell, o.Buy, o.Rate); err != nil {
+		return 0, err
+	}
+	id, err := m.svc.Publish(o)
+	if err != nil {
+		return 0, errors.WithMessage(err, "publishing offer")
+	}
+	m.offers[pair] = id
+	return id, nil
+}
+
+// Withdraw withdraws the offer for the asset pair.
+func (m *Maker) Withdraw(sell, buy int) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	pair := [2]int{sell, buy}
+	id, ok := m.offers[pair]
+	if !ok {
+		return fmt.Errorf("no offer for asset %d to %d", sell, buy)
+	}
+	if err := m.svc.Withdraw(id); err != nil {
+		return errors.WithMessage(err, "withdrawing offer")
+	}
+	delete(m.offers, pair)
+	return nil
+}
+
+// checkProposal accepts a proposed channel only if it is funded as a pending
+// match of an offer of the maker.
+func (m *Maker) checkProposal(proposer map[wallet.BackendID]wire.Address, bals channel.Balances) error {
+	_, err := m.svc.Confirm(m.client.WireAddress(), proposer, bals)
+	return err
+}
+
+// Taker requests quotes for a swap client from a service.
+type Taker struct {
+	client takerClient
+	svc    Service
+}
+
+// takerClient is the part of the swap client that the taker uses.
+type takerClient interface {
+	NumAssets() int
+	WireAddress() map[wallet.BackendID]wire.Address
+	ProposeChannel(peer map[wallet.BackendID]wire.Address, balances channel.Balances) (*client.SwapChannel, error)
+}
+
+// NewTaker creates a taker for the client.
+func NewTaker(c *client.SwapClient, svc Service) *Taker {
+	return &Taker{client: c, svc: svc}
+}
+
+// Take matches the request with an offer and opens a channel with the maker
+// of the offer, funded with the balances of the match. The caller performs
+// the swap with SwapChannel.Swap at the rate of the offer. If the channel
+// cannot be opened, the match is cancelled, which returns its amount to the
+// offer. If the match cannot be completed after the channel is opened, the
+// channel is returned with the error.
+func (t *Taker) Take(req Request) (*client.SwapChannel, Match, error) {
+	if req.Sell >= t.client.NumAssets() || req.Buy >= t.client.NumAssets() {
+		return nil, Match{}, fmt.Errorf("invalid asset pair: %d, %d", req.Sell, req.Buy)
+	}
+	req.Taker = t.client.WireAddress()
+	match, err := t.svc.Request(req)
+	if err != nil {
+		return nil, Match{}, errors.WithMessage(err, "requesting quote")
+	}
+
+	ch, err := t.client.ProposeChannel(match.Offer.Maker, match.Balances(t.client.NumAssets()))
+	if err != nil {
+		if cerr := t.svc.Cancel(match.ID); cerr != nil {
+			return nil, Match{}, errors.WithMessagef(err, "opening channel (cancelling match: %v)", cerr)
+		}
+		return nil, Match{}, errors.WithMessage(err, "opening channel")
+	}
+	if err := t.svc.Complete(match.ID); err != nil {
+		return ch, match, errors.WithMessage(err, "completing match")
+	}
+	return ch, match, nil
+}
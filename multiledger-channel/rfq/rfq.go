@@ -0,0 +1,145 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rfq implements the request-for-quote flow that brings together the
+// counterparties of cross-chain swaps. Makers publish standing offers at a
+// Service and takers request quotes from it. A match opens a swap channel
+// that is funded with exactly the matched amounts.
+package rfq
+
+import (
+	"fmt"
+	"math/big"
+
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+	"perun.network/perun-examples/multiledger-channel/client"
+)
+
+type (
+	// OfferID identifies an offer at a service.
+	OfferID uint64
+	// MatchID identifies a match at a service.
+	MatchID uint64
+)
+
+// Offer is a standing offer of a maker to buy up to Size units of asset Sell
+// and to pay Rate units of asset Buy per unit. The asset indices refer to the
+// assets of the swap clients, and the direction is the one of the taker, as
+// in client.SwapClient.SetRate.
+type Offer struct {
+	ID        OfferID
+	Maker     map[wallet.BackendID]wire.Address
+	Sell, Buy int
+	Rate      client.Rate
+	Size      *big.Int
+}
+
+// Request is the request of a taker to sell Amount units of asset Sell for
+// asset Buy at a rate of at least MinRate.
+type Request struct {
+	Taker     map[wallet.BackendID]wire.Address
+	Sell, Buy int
+	Amount    *big.Int
+	MinRate   client.Rate
+}
+
+// Match is a request matched with an offer. The amount is reserved at the
+// offer until the channel of the match is opened or the taker cancels the
+// match.
+type Match struct {
+	ID     MatchID
+	Offer  Offer
+	Taker  map[wallet.BackendID]wire.Address
+	Amount *big.Int
+}
+
+// Balances returns the funding of the channel for the match. The taker
+// proposes the channel and therefore has index 0. It deposits the sold amount
+// and the maker deposits the amount it pays for it.
+func (m Match) Balances(numAssets int) channel.Balances {
+	bals := make(channel.Balances, numAssets)
+	for i := range bals {
+		bals[i] = []channel.Bal{new(big.Int), new(big.Int)}
+	}
+	bals[m.Offer.Sell][0].Set(m.Amount)
+	bals[m.Offer.Buy][1].Set(m.Offer.Rate.Mul(m.Amount))
+	return bals
+}
+
+// Service matches the requests of takers with the offers of makers.
+type Service interface {
+	// Publish publishes the offer and returns its ID.
+	Publish(o Offer) (OfferID, error)
+	// Withdraw removes the offer. Pending matches of the offer stay valid.
+	Withdraw(id OfferID) error
+	// Request matches the request with the offer of the best rate that has
+	// enough size left and reserves the amount.
+	Request(req Request) (Match, error)
+	// Confirm is called by the maker when the taker proposes a channel. It
+	// returns the pending match with the maker and taker whose balances are
+	// the proposed ones and marks it as confirmed, so that it funds no other
+	// channel. The match stays reserved until it is completed or cancelled.
+	Confirm(maker, taker map[wallet.BackendID]wire.Address, bals channel.Balances) (Match, error)
+	// Complete is called by the taker when the channel of the match is
+	// opened. It removes the match, whose amount stays taken from the offer.
+	Complete(id MatchID) error
+	// Cancel removes the pending or confirmed match and returns its amount
+	// to the offer if the offer was not withdrawn.
+	Cancel(id MatchID) error
+}
+
+// validate checks the offer.
+func (o Offer) validate() error {
+	if err := validatePair(o.Sell, o.Buy); err != nil {
+		return err
+	}
+	if o.Size == nil || o.Size.Sign() <= 0 {
+		return fmt.Errorf("invalid size: %v", o.Size)
+	}
+	return o.Rate.Validate()
+}
+
+// validate checks the request.
+func (r Request) validate() error {
+	if err := validatePair(r.Sell, r.Buy); err != nil {
+		return err
+	}
+	if r.Amount == nil || r.Amount.Sign() <= 0 {
+		return fmt.Errorf("invalid amount: %v", r.Amount)
+	}
+	return r.MinRate.Validate()
+}
+
+func validatePair(sell, buy int) error {
+	if sell < 0 || buy < 0 || sell == buy {
+		return fmt.Errorf("invalid asset pair: %d, %d", sell, buy)
+	}
+	return nil
+}
+
+// equalAddrs returns whether a and b hold the same wire addresses.
+func equalAddrs(a, b map[wallet.BackendID]wire.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id, addr := range a {
+		other, ok := b[id]
+		if !ok || !addr.Equal(other) {
+			return false
+		}
+	}
+	return true
+}
@@ -25,12 +25,14 @@ import (
 	p2p "perun.network/go-perun/wire/net/libp2p"
 	perunio "perun.network/go-perun/wire/perunio/serializer"
 	"perun.network/perun-examples/multiledger-channel/client"
+	"perun.network/perun-examples/multiledger-channel/deployment"
+	"perun.network/perun-examples/shared/chainconfig"
+	"perun.network/perun-examples/shared/registry"
 
-	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
-	ethchannel "github.com/perun-network/perun-eth-backend/channel"
+	echannel "github.com/perun-network/perun-eth-backend/channel"
 	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
 	swallet "github.com/perun-network/perun-eth-backend/wallet/simple"
 
@@ -39,52 +41,64 @@ import (
 	"perun.network/go-perun/wire/net"
 )
 
-// deployContracts deploys the Perun smart contracts on the specified chains and
-// sets the contract addresses.
-func deployContracts(chains []client.ChainConfig) {
-	k, err := crypto.HexToECDSA(keyDeployer)
+// loadChains loads the chain configurations from the file at path. Contract
+// addresses that are not configured are loaded from the deployment registry,
+// which verifies that the contracts are deployed.
+func loadChains(path string) []client.ChainConfig {
+	cfg, err := chainconfig.Load(path)
 	if err != nil {
-		panic(err)
+		log.Fatalf("Loading chain configuration: %v", err)
 	}
-	w := swallet.NewWallet(k)
 
-	for i, chain := range chains {
-		cb, err := client.CreateContractBackend(chain.ChainURL, chain.ChainID.Int, w)
-		if err != nil {
-			panic(err)
+	var r *registry.Registry
+	chains := make([]client.ChainConfig, len(cfg.Chains))
+	for i, ch := range cfg.Chains {
+		chains[i] = client.ChainConfig{
+			ChainID:             echannel.MakeChainID(ch.ID()),
+			ChainURL:            ch.RPCURL,
+			Token:               ch.TokenAddress(),
+			Adjudicator:         ch.AdjudicatorAddress(),
+			AssetHolder:         ch.AssetHolderAddress(),
+			DepositGasLimit:     ch.Gas.DepositLimit,
+			AdjudicatorGasLimit: ch.Gas.AdjudicatorLimit,
+			FinalityDepth:       ch.FinalityDepth,
 		}
-		acc := accounts.Account{Address: crypto.PubkeyToAddress(k.PublicKey)}
-
-		// Deploy the ERC20 PerunToken.
-		initAccs := []common.Address{privKeyToAddress(keyAlice), privKeyToAddress(keyBob)}
-		initAmount := big.NewInt(initialTokenAmount)
-		token, err := ethchannel.DeployPerunToken(context.TODO(), cb, acc, initAccs, initAmount)
-		chains[i].Token = token
-		if err != nil {
-			panic(err)
+		if ch.Token != "" && ch.Adjudicator != "" && ch.AssetHolder != "" {
+			continue
 		}
 
-		// Deploy adjudicator.
-		chains[i].Adjudicator, err = ethchannel.DeployAdjudicator(context.TODO(), cb, acc)
+		if r == nil {
+			if r, err = registry.Load(registry.DefaultFile); err != nil {
+				log.Fatal(err)
+			}
+		}
+		ethClient, err := ch.Dial(context.TODO())
 		if err != nil {
-			panic(err)
+			log.Fatal(err)
 		}
-
-		// Deploy ERC20 asset holder.
-		chains[i].AssetHolder, err = ethchannel.DeployERC20Assetholder(context.TODO(), cb, chains[i].Adjudicator, token, acc)
+		c, err := deployment.Lookup(context.TODO(), ethClient, ch.ID(), r)
+		ethClient.Close()
 		if err != nil {
-			panic(err)
+			log.Fatalf("Loading contracts of chain %s: %v. Deploy them with: go run ./cmd/deploy", ch.Name, err)
+		}
+		if ch.Token == "" {
+			chains[i].Token = c.Token
+		}
+		if ch.Adjudicator == "" {
+			chains[i].Adjudicator = c.Adjudicator
+		}
+		if ch.AssetHolder == "" {
+			chains[i].AssetHolder = c.AssetHolder
 		}
-
 	}
-
+	return chains
 }
 
 // setupPaymentClient sets up a new client with the given parameters.
 func setupPaymentClient(
 	bus wire.Bus,
 	privateKey string,
-	chains [2]client.ChainConfig,
+	chains []client.ChainConfig,
 	waddress wire.Address, // waddress is the wire address of the client, used for off-chain communication.
 ) *client.SwapClient {
 	// Create wallet and account.
@@ -110,43 +124,29 @@ func setupPaymentClient(
 	return c
 }
 
-func privKeyToAddress(privateKey string) common.Address {
-	pk, err := crypto.HexToECDSA(privateKey)
-	if err != nil {
-		panic(err)
-	}
-
-	return crypto.PubkeyToAddress(pk.PublicKey)
-}
-
-// balanceLogger is a utility for logging client balances on two chains (A & B).
+// balanceLogger is a utility for logging client balances on several chains.
 type balanceLogger struct {
-	ethClientA *ethclient.Client
-	ethClientB *ethclient.Client
-	tokenA     common.Address
-	tokenB     common.Address
+	ethClients []*ethclient.Client
+	chains     []client.ChainConfig
 }
 
-// newBalanceLogger creates a new balance logger for the specified ledger.
-func newBalanceLogger(chainA, chainB client.ChainConfig) balanceLogger {
-	clientA, err := ethclient.Dial(chainA.ChainURL)
-	if err != nil {
-		panic(err)
-	}
-
-	clientB, err := ethclient.Dial(chainB.ChainURL)
-	if err != nil {
-		panic(err)
+// newBalanceLogger creates a new balance logger for the specified ledgers.
+func newBalanceLogger(chains []client.ChainConfig) balanceLogger {
+	clients := make([]*ethclient.Client, len(chains))
+	for i, chain := range chains {
+		var err error
+		clients[i], err = ethclient.Dial(chain.ChainURL)
+		if err != nil {
+			panic(err)
+		}
 	}
 	return balanceLogger{
-		ethClientA: clientA,
-		ethClientB: clientB,
-		tokenA:     chainA.Token,
-		tokenB:     chainA.Token,
+		ethClients: clients,
+		chains:     chains,
 	}
 }
 
-// LogBalances prints the token balances of the specified clients on the two chains.
+// LogBalances prints the token balances of the specified clients on each chain.
 func (l balanceLogger) LogBalances(addresses ...common.Address) {
 	getBals := func(cb bind.ContractBackend, token common.Address) []*big.Int {
 		bals := make([]*big.Int, len(addresses))
@@ -166,8 +166,9 @@ func (l balanceLogger) LogBalances(addresses ...common.Address) {
 		return bals
 	}
 
-	log.Println("Client balances Chain A (PRN):", getBals(l.ethClientA, l.tokenA))
-	log.Println("Client balances Chain B (PRN):", getBals(l.ethClientB, l.tokenB))
+	for i, chain := range l.chains {
+		log.Printf("Client balances chain %v (PRN): %v", chain.ChainID, getBals(l.ethClients[i], chain.Token))
+	}
 }
 
 // setupBusWire sets up a wire.Bus for the given wire.Account.
@@ -24,6 +24,7 @@ import (
 	"log"
 	"math/big"
 	"perun.network/go-perun/channel/multi"
+	"perun.network/perun-examples/shared/chainconfig"
 	stellarChannel "perun.network/perun-stellar-backend/channel"
 	chtypes "perun.network/perun-stellar-backend/channel/types"
 	"perun.network/perun-stellar-backend/wallet/types"
@@ -57,8 +58,7 @@ func SetupPaymentClient(
 	ethWallet *simplewallet.Wallet, // w is the wallet used for signing ethereum transactions.
 	acc common.Address, // acc is the address of the account to be used for signing transactions.
 	ethAddress *ethwallet.Address, // ethAddress is the address of the Ethereum account to be used for signing transactions.
-	nodeURL string, // nodeURL is the URL of the blockchain node.
-	chainID uint64, // chainID is the identifier of the blockchain.
+	chain chainconfig.Chain, // chain is the configuration of the Ethereum chain, including its gas limits and finality depth.
 	adjudicator common.Address, // adjudicator is the address of the adjudicator.
 	assetAddr ethwallet.Address, // asset is the address of the asset holder for our payment channels.
 	stellarWallet *swallet.EphemeralWallet, // stellarWallet is the wallet used for signing stellar transactions.
@@ -78,7 +78,7 @@ func SetupPaymentClient(
 	}
 	stellarWireAddr := swire.WirePart{Participant: sPart}
 	// Create Ethereum client and contract backend.
-	cb, err := CreateContractBackend(nodeURL, chainID, ethWallet)
+	cb, err := CreateContractBackend(chain, ethWallet)
 	if err != nil {
 		return nil, fmt.Errorf("creating contract backend: %w", err)
 	}
@@ -95,17 +95,17 @@ func SetupPaymentClient(
 
 	// Setup funder.
 	ethFunder := ethchannel.NewFunder(cb)
-	ethAssetID := ethchannel.MakeLedgerBackendID(big.NewInt(int64(chainID)))
+	ethAssetID := ethchannel.MakeLedgerBackendID(chain.ID())
 	stellarAssetID := chtypes.MakeCCID(chtypes.MakeContractID("2"))
 	multiFunder.RegisterFunder(ethAssetID, ethFunder)
 	multiFunder.RegisterFunder(stellarAssetID, stellarFunder)
-	dep := ethchannel.NewETHDepositor(50000)
+	dep := ethchannel.NewETHDepositor(chain.Gas.DepositLimit)
 	ethAcc := accounts.Account{Address: acc}
-	asset := ethchannel.NewAsset(big.NewInt(int64(chainID)), common.Address(assetAddr))
+	asset := ethchannel.NewAsset(chain.ID(), common.Address(assetAddr))
 	ethFunder.RegisterAsset(*asset, dep, ethAcc)
 
 	// Setup adjudicator.
-	ethAdj := ethchannel.NewAdjudicator(cb, adjudicator, acc, ethAcc, 1000000)
+	ethAdj := ethchannel.NewAdjudicator(cb, adjudicator, acc, ethAcc, chain.Gas.AdjudicatorLimit)
 	multiAdjudicator.RegisterAdjudicator(ethAssetID, ethAdj)
 	multiAdjudicator.RegisterAdjudicator(stellarAssetID, stellarAdj)
 
@@ -24,27 +24,24 @@ import (
 	"math/big"
 	"perun.network/go-perun/wallet"
 	"perun.network/go-perun/wire"
+	"perun.network/perun-examples/shared/chainconfig"
 )
 
-const (
-	txFinalityDepth = 1 // Number of blocks required to confirm a transaction.
-)
-
-// CreateContractBackend creates a new contract backend.
+// CreateContractBackend creates a new contract backend for the chain. It
+// waits for the configured finality depth to confirm a transaction.
 func CreateContractBackend(
-	nodeURL string,
-	chainID uint64,
+	chain chainconfig.Chain,
 	w *swallet.Wallet,
 ) (ethchannel.ContractBackend, error) {
-	signer := types.LatestSignerForChainID(new(big.Int).SetUint64(chainID))
+	signer := types.LatestSignerForChainID(chain.ID())
 	transactor := swallet.NewTransactor(w, signer)
 
-	ethClient, err := ethclient.Dial(nodeURL)
+	ethClient, err := ethclient.Dial(chain.RPCURL)
 	if err != nil {
 		return ethchannel.ContractBackend{}, err
 	}
 
-	return ethchannel.NewContractBackend(ethClient, ethchannel.MakeChainID(big.NewInt(int64(chainID))), transactor, txFinalityDepth), nil
+	return ethchannel.NewContractBackend(ethClient, ethchannel.MakeChainID(chain.ID()), transactor, chain.FinalityDepth), nil
 }
 
 // WalletAddress returns the wallet address of the client.
@@ -29,18 +29,47 @@ import (
 	"perun.network/go-perun/channel"
 	"perun.network/go-perun/wire"
 	"perun.network/perun-examples/payment-channel-cc/client"
+	"perun.network/perun-examples/shared/chainconfig"
 	stellarChannel "perun.network/perun-stellar-backend/channel"
 	stellarWallet "perun.network/perun-stellar-backend/wallet"
 )
 
-// DeployContracts deploys the Perun smart contracts on the specified ledger.
-func DeployContracts(nodeURL string, chainID uint64, privateKey string) (adj, ah common.Address) {
+// LoadChain loads the chain with the given name from the chain configuration
+// file at path and checks that its node reports the configured chain ID.
+func LoadChain(path, name string) chainconfig.Chain {
+	cfg, err := chainconfig.Load(path)
+	if err != nil {
+		log.Fatalf("Loading chain configuration: %v", err)
+	}
+	chain, err := cfg.Chain(name)
+	if err != nil {
+		log.Fatalf("Loading chain configuration: %s: %v", path, err)
+	}
+	ethClient, err := chain.Dial(context.TODO())
+	if err != nil {
+		log.Fatal(err)
+	}
+	ethClient.Close()
+	return chain
+}
+
+// ChainContracts returns the adjudicator and the asset holder configured for
+// the chain. If one of them is not configured, both are deployed.
+func ChainContracts(chain chainconfig.Chain, privateKey string) (adj, ah common.Address) {
+	if chain.Adjudicator != "" && chain.AssetHolder != "" {
+		return chain.AdjudicatorAddress(), chain.AssetHolderAddress()
+	}
+	return DeployContracts(chain, privateKey)
+}
+
+// DeployContracts deploys the Perun smart contracts on the specified chain.
+func DeployContracts(chain chainconfig.Chain, privateKey string) (adj, ah common.Address) {
 	k, err := crypto.HexToECDSA(privateKey)
 	if err != nil {
 		panic(err)
 	}
 	w := swallet.NewWallet(k)
-	cb, err := client.CreateContractBackend(nodeURL, chainID, w)
+	cb, err := client.CreateContractBackend(chain, w)
 	if err != nil {
 		panic(err)
 	}
@@ -64,7 +93,7 @@ func DeployContracts(nodeURL string, chainID uint64, privateKey string) (adj, ah
 // SetupPaymentClient sets up a new client with the given parameters.
 func SetupPaymentClient(
 	bus wire.Bus,
-	nodeURL string,
+	chain chainconfig.Chain,
 	adjudicator common.Address,
 	asset ethwallet.Address,
 	k *ecdsa.PrivateKey,
@@ -85,8 +114,7 @@ func SetupPaymentClient(
 		w,
 		acc,
 		eaddr,
-		nodeURL,
-		1337,
+		chain,
 		adjudicator,
 		asset,
 		stellarWallet,
@@ -7,7 +7,9 @@ require (
 	github.com/perun-network/perun-eth-backend v0.5.1-0.20250128102234-80faf4a0f2ab
 	github.com/pkg/errors v0.9.1
 	github.com/stellar/go v0.0.0-20241113164517-f09f3e438519
+	github.com/stretchr/testify v1.9.0
 	perun.network/go-perun v0.13.0
+	perun.network/perun-examples/shared/chainconfig v0.0.0
 	perun.network/perun-stellar-backend v0.2.1-0.20250129140329-bd5a98ac6261
 )
 
@@ -33,7 +35,6 @@ require (
 	github.com/sirupsen/logrus v1.9.3 // indirect
 	github.com/stellar/go-xdr v0.0.0-20231122183749-b53fb00bcac2 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
-	github.com/stretchr/testify v1.9.0 // indirect
 	github.com/tklauser/go-sysconf v0.3.5 // indirect
 	github.com/tklauser/numcpus v0.2.2 // indirect
 	golang.org/x/crypto v0.24.0 // indirect
@@ -44,3 +45,5 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	polycry.pt/poly-go v0.0.0-20220301085937-fb9d71b45a37 // indirect
 )
+
+replace perun.network/perun-examples/shared/chainconfig => ../shared/chainconfig
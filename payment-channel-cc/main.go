@@ -24,7 +24,9 @@ import (
 )
 
 const (
-	chainURL = "ws://127.0.0.1:8545"
+	// The chain configuration file and the name of the Ethereum chain in it.
+	chainsFile = "ethereum.yaml"
+	chainName  = "ethereum"
 
 	// Private keys.
 	keyDeployer = "79ea8f62d97bc0591a4224c1725fca6b00de5b2cea286fe2e0bb35c5e76be46e"
@@ -32,16 +34,20 @@ const (
 	keyBob      = "f63d7d8e930bccd74e93cf5662fde2c28fd8be95edb70c73f1bdd863d07f412e"
 )
 
-// main runs a demo of the payment client. It assumes that a blockchain node is
-// available at `chainURL` and that the accounts corresponding to the specified
+// main runs a demo of the payment client. It assumes that the Ethereum chain
+// configured in `chainsFile` is available and that the accounts corresponding to the specified
 // secret keys are provided with sufficient funds.
 func main() {
 
 	// Configure log flags: date/time and file/line number
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	// Deploy contracts.
-	log.Println("Deploying contracts.")
-	adjudicator, assetHolder := ethereumUtil.DeployContracts(chainURL, 1337, keyDeployer)
+	// Load the chain configuration and deploy the contracts that are not
+	// configured.
+	log.Println("Loading chain configuration.")
+	chain := ethereumUtil.LoadChain(chainsFile, chainName)
+	chainURL := chain.RPCURL
+	log.Println("Loading contracts.")
+	adjudicator, assetHolder := ethereumUtil.ChainContracts(chain, keyDeployer)
 	log.Println("Adjudicator:", adjudicator.Hex())
 	log.Println("Asset holder:", assetHolder.Hex())
 
@@ -62,8 +68,8 @@ func main() {
 		panic(err)
 	}
 	bus := wire.NewLocalBus() // Message bus used for off-chain communication.
-	alice := ethereumUtil.SetupPaymentClient(bus, chainURL, adjudicator, asset, kAlice, setup.GetWallets()[0], setup.GetAccounts()[0], setup.GetTokenAsset()[0], setup.GetFunders()[0], setup.GetAdjudicators()[0])
-	bob := ethereumUtil.SetupPaymentClient(bus, chainURL, adjudicator, asset, kBob, setup.GetWallets()[1], setup.GetAccounts()[1], setup.GetTokenAsset()[1], setup.GetFunders()[1], setup.GetAdjudicators()[1])
+	alice := ethereumUtil.SetupPaymentClient(bus, chain, adjudicator, asset, kAlice, setup.GetWallets()[0], setup.GetAccounts()[0], setup.GetTokenAsset()[0], setup.GetFunders()[0], setup.GetAdjudicators()[0])
+	bob := ethereumUtil.SetupPaymentClient(bus, chain, adjudicator, asset, kBob, setup.GetWallets()[1], setup.GetAccounts()[1], setup.GetTokenAsset()[1], setup.GetFunders()[1], setup.GetAdjudicators()[1])
 
 	log.Println("Participants: ", alice.WireAddress(), bob.WireAddress())
 
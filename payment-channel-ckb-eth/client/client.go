@@ -25,10 +25,12 @@ import (
 	"math/big"
 	"perun.network/go-perun/channel/multi"
 	"perun.network/go-perun/wire/net/simple"
+	"perun.network/perun-ckb-backend/backend"
 	"perun.network/perun-ckb-backend/channel/adjudicator"
 	"perun.network/perun-ckb-backend/channel/asset"
 	"perun.network/perun-ckb-backend/channel/funder"
 	ckbaddress "perun.network/perun-ckb-backend/wallet/address"
+	"perun.network/perun-examples/shared/chainconfig"
 
 	"perun.network/go-perun/channel"
 	"perun.network/go-perun/client"
@@ -41,6 +43,7 @@ import (
 	"github.com/pkg/errors"
 
 	ckbwallet "perun.network/perun-ckb-backend/wallet"
+	"perun.network/perun-examples/payment-channel-ckb-eth/deployment"
 )
 
 // PaymentClient is a payment channel client.
@@ -50,6 +53,7 @@ type PaymentClient struct {
 	waddress    map[wallet.BackendID]wire.Address
 	currency    []channel.Asset      // The currency we expect to get paid in.
 	channels    chan *PaymentChannel // Accepted payment channels.
+	ckbCapacity *big.Int             // The capacity in Shannon of the CKB cell of a participant.
 }
 
 // SetupPaymentClient creates a new payment client.
@@ -58,8 +62,7 @@ func SetupPaymentClient(
 	ethWallet *simplewallet.Wallet, // w is the wallet used for signing ethereum transactions.
 	acc common.Address, // acc is the address of the account to be used for signing transactions.
 	ethAddress *ethwallet.Address, // ethAddress is the address of the Ethereum account to be used for signing transactions.
-	nodeURL string, // nodeURL is the URL of the blockchain node.
-	chainID uint64, // chainID is the identifier of the blockchain.
+	chain chainconfig.Chain, // chain is the configuration of the Ethereum chain, including its gas limits and finality depth.
 	adjudicator common.Address, // adjudicator is the address of the adjudicator.
 	assetAddr ethwallet.Address, // asset is the address of the asset holder for our payment channels.
 	ckbWallet *ckbwallet.EphemeralWallet, // stellarWallet is the wallet used for signing stellar transactions.
@@ -67,6 +70,7 @@ func SetupPaymentClient(
 	ckbTokenIDs channel.Asset, // stellarTokenIDs is the list of token IDs to be used for payment channels.
 	ckbFunder *funder.Funder, // stellarFunder is the funder to be used for funding Stellar payment channels.
 	ckbAdj *adjudicator.Adjudicator, // stellarAdj is the adjudicator to be used for Stellar payment channels.
+	ckbDeployment backend.Deployment, // ckbDeployment is the deployment of the channel scripts on CKB.
 ) (*PaymentClient, error) {
 	multiAdjudicator := multi.NewAdjudicator()
 	watcher, err := local.NewWatcher(multiAdjudicator)
@@ -76,7 +80,7 @@ func SetupPaymentClient(
 	ckbPart := ckbAccount.Address().(*ckbaddress.Participant)
 	ckbWireAddr := simple.NewAddress(ckbPart.String())
 	// Create Ethereum client and contract backend.
-	cb, err := CreateContractBackend(nodeURL, chainID, ethWallet)
+	cb, err := CreateContractBackend(chain, ethWallet)
 	if err != nil {
 		return nil, fmt.Errorf("creating contract backend: %w", err)
 	}
@@ -93,17 +97,17 @@ func SetupPaymentClient(
 
 	// Setup funder.
 	ethFunder := ethchannel.NewFunder(cb)
-	ethAssetID := ethchannel.MakeLedgerBackendID(big.NewInt(int64(chainID)))
+	ethAssetID := ethchannel.MakeLedgerBackendID(chain.ID())
 	ckbAssetID := asset.MakeCCID(asset.MakeContractID("03"))
 	multiFunder.RegisterFunder(ethAssetID, ethFunder)
 	multiFunder.RegisterFunder(ckbAssetID, ckbFunder)
-	dep := ethchannel.NewETHDepositor(50000)
+	dep := ethchannel.NewETHDepositor(chain.Gas.DepositLimit)
 	ethAcc := accounts.Account{Address: acc}
-	asset := ethchannel.NewAsset(big.NewInt(int64(chainID)), common.Address(assetAddr))
+	asset := ethchannel.NewAsset(chain.ID(), common.Address(assetAddr))
 	ethFunder.RegisterAsset(*asset, dep, ethAcc)
 
 	// Setup adjudicator.
-	ethAdj := ethchannel.NewAdjudicator(cb, adjudicator, acc, ethAcc, 1000000)
+	ethAdj := ethchannel.NewAdjudicator(cb, adjudicator, acc, ethAcc, chain.Gas.AdjudicatorLimit)
 	multiAdjudicator.RegisterAdjudicator(ethAssetID, ethAdj)
 	multiAdjudicator.RegisterAdjudicator(ckbAssetID, ckbAdj)
 
@@ -125,6 +129,7 @@ func SetupPaymentClient(
 		waddress:    addresses,
 		currency:    []channel.Asset{asset, ckbTokenIDs},
 		channels:    make(chan *PaymentChannel, 1),
+		ckbCapacity: new(big.Int).SetUint64(deployment.FundingCellCapacity(ckbDeployment)),
 	}
 	go perunClient.Handle(c, c)
 
@@ -147,9 +152,13 @@ func (c *PaymentClient) OpenChannel(peer map[wallet.BackendID]wire.Address, ethA
 		EthToWei(big.NewFloat(ethAmount)), // Our initial balance.
 		big.NewInt(0),                     // Peer's initial balance.
 	})
+	// The CKBytes of a participant are held in a cell, which occupies
+	// capacity itself. We add that capacity to both balances; it is paid out
+	// again when the channel is settled.
+	log.Println("CKB cell capacity per participant: ", ShannonToCKByte(c.ckbCapacity), "CKB, included in the CKB balances")
 	initAlloc.SetAssetBalances(c.currency[1], []channel.Bal{
-		big.NewInt(8000000000),                                 // Our initial balance.
-		big.NewInt(int64(8000000000 + shannonAmount.Uint64())), // Peer's initial balance.
+		new(big.Int).Set(c.ckbCapacity),                // Our initial balance.
+		new(big.Int).Add(c.ckbCapacity, shannonAmount), // Peer's initial balance.
 	})
 
 	// Prepare the channel proposal by defining the channel parameters.
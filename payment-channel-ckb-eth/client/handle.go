@@ -40,12 +40,19 @@ func (c *PaymentClient) HandleProposal(p client.ChannelProposal, r *client.Propo
 		}
 
 		// Check that the channel has the expected assets and funding balances.
-		const assetIdx, peerIdx = 0, 1
+		const assetIdx, ckbIdx, peerIdx = 0, 1, 1
 		if err := channel.AssertAssetsEqual(lcp.InitBals.Assets, c.currency); err != nil {
 			return nil, fmt.Errorf("invalid assets: %v", err)
 		} else if lcp.FundingAgreement[assetIdx][peerIdx].Cmp(big.NewInt(0)) != 0 {
 			return nil, fmt.Errorf("invalid funding balance")
 		}
+
+		// Check that the CKB balances cover the capacity of the CKB cells.
+		for i, bal := range lcp.InitBals.Balances[ckbIdx] {
+			if bal.Cmp(c.ckbCapacity) < 0 {
+				return nil, fmt.Errorf("ckb balance %v of participant %d below the cell capacity %v", bal, i, c.ckbCapacity)
+			}
+		}
 		return lcp, nil
 	}()
 	if err != nil {
@@ -29,12 +29,6 @@ import (
 	"perun.network/perun-ckb-backend/backend"
 )
 
-// PFLSMinCapacity is the minimum capacity required for the PFLS cell.
-const (
-	PFLSMinCapacity = 4100000032
-	sudtMaxCapacity = 200_00_000_000
-)
-
 // SUDTInfo contains the script and cell dep of an SUDT.
 type SUDTInfo struct {
 	Script      *types.Script  `json:"script"`
@@ -108,11 +102,10 @@ func (m Migration) MakeDeployment(systemScripts test.SystemScripts, sudtOwnerLoc
 			HashType: sudtInfo.Script.HashType,
 			Args:     byte0,
 		},
-		CellDep:     sudtInfo.CellDep,
-		MaxCapacity: sudtInfo.MaxCapacity,
+		CellDep: sudtInfo.CellDep,
 	}
 	log.Println("Using SUDT owner lock args:", sUDTInfos[0].Script.Args, "for SUDT:", sUDTInfos[0].Script.Hash())
-	return backend.Deployment{
+	d := backend.Deployment{
 		Network: types.NetworkTest,
 		PCTSDep: types.CellDep{
 			OutPoint: &types.OutPoint{
@@ -199,10 +192,18 @@ func (m Migration) MakeDeployment(systemScripts test.SystemScripts, sudtOwnerLoc
 		SUDTs: map[types.Hash]types.Script{
 			sUDTInfos[0].Script.Hash(): *sUDTInfos[0].Script,
 		},
-	}, sUDTInfos, nil
+	}
+	// The funding cells hold at least the capacity that the payout cells
+	// occupy.
+	d.PFLSMinCapacity = FundingCellCapacity(d)
+	log.Println("Funding cells occupy", d.PFLSMinCapacity, "Shannon")
+	sUDTInfos[0].MaxCapacity = int64(SUDTCellCapacity(d, sUDTInfos[0].Script))
+	log.Println("SUDT cells occupy", sUDTInfos[0].MaxCapacity, "Shannon")
+	return d, sUDTInfos, nil
 }
 
-// GetSUDT returns the SUDT info from the migration.
+// GetSUDT returns the SUDT info from the migration. Its max capacity depends
+// on the scripts of the deployment and is set by MakeDeployment.
 func (m Migration) GetSUDT() (*SUDTInfo, error) {
 	sudt := m.CellRecipes[0]
 	if sudt.Name != "sudt" {
@@ -222,9 +223,8 @@ func (m Migration) GetSUDT() (*SUDTInfo, error) {
 		DepType: types.DepTypeCode,
 	}
 	return &SUDTInfo{
-		Script:      &sudtScript,
-		CellDep:     &sudtCellDep,
-		MaxCapacity: sudtMaxCapacity,
+		Script:  &sudtScript,
+		CellDep: &sudtCellDep,
 	}, nil
 }
 
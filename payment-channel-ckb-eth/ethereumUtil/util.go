@@ -18,40 +18,58 @@ import (
 	"context"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/nervosnetwork/ckb-sdk-go/v2/indexer"
 	ckbtypes "github.com/nervosnetwork/ckb-sdk-go/v2/types"
 	ethchannel "github.com/perun-network/perun-eth-backend/channel"
-	"github.com/perun-network/perun-eth-backend/wallet/simple"
 	swallet "github.com/perun-network/perun-eth-backend/wallet/simple"
 	"log"
 	"math/big"
 	"perun.network/perun-ckb-backend/wallet/address"
 	"perun.network/perun-examples/payment-channel-ckb-eth/client"
+	"perun.network/perun-examples/shared/chainconfig"
 )
 
-// DeployContracts deploys the Perun smart contracts on the specified ledger.
-func DeployContracts(nodeURL string, chainID uint64, privateKey string) (adj, ah common.Address) {
+// LoadChain loads the chain with the given name from the chain configuration
+// file at path and checks that its node reports the configured chain ID.
+func LoadChain(path, name string) chainconfig.Chain {
+	cfg, err := chainconfig.Load(path)
+	if err != nil {
+		log.Fatalf("Loading chain configuration: %v", err)
+	}
+	chain, err := cfg.Chain(name)
+	if err != nil {
+		log.Fatalf("Loading chain configuration: %s: %v", path, err)
+	}
+	ethClient, err := chain.Dial(context.TODO())
+	if err != nil {
+		log.Fatal(err)
+	}
+	ethClient.Close()
+	return chain
+}
+
+// ChainContracts returns the adjudicator and the asset holder configured for
+// the chain. If one of them is not configured, both are deployed.
+func ChainContracts(chain chainconfig.Chain, privateKey string) (adj, ah common.Address) {
+	if chain.Adjudicator != "" && chain.AssetHolder != "" {
+		return chain.AdjudicatorAddress(), chain.AssetHolderAddress()
+	}
+	return DeployContracts(chain, privateKey)
+}
+
+// DeployContracts deploys the Perun smart contracts on the specified chain.
+func DeployContracts(chain chainconfig.Chain, privateKey string) (adj, ah common.Address) {
 	k, err := crypto.HexToECDSA(privateKey)
 	if err != nil {
 		panic(err)
 	}
 	w := swallet.NewWallet(k)
-	ethClient, err := ethclient.Dial(nodeURL)
+	cb, err := client.CreateContractBackend(chain, w)
 	if err != nil {
 		panic(err)
 	}
-	cb := ethchannel.NewContractBackend(
-		ethClient,
-		ethchannel.MakeChainID(big.NewInt(int64(chainID))),
-		simple.NewTransactor(
-			w,
-			types.NewLondonSigner(big.NewInt(int64(chainID))),
-		),
-		1,
-	)
 	acc := accounts.Account{Address: crypto.PubkeyToAddress(k.PublicKey)}
 
 	// Deploy adjudicator.
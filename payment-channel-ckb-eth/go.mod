@@ -8,8 +8,11 @@ require (
 	github.com/nervosnetwork/ckb-sdk-go/v2 v2.2.0
 	github.com/perun-network/perun-eth-backend v0.6.1-0.20251105111134-b1a83fe801db
 	github.com/pkg/errors v0.9.1
+	github.com/stretchr/testify v1.8.4
 	perun.network/go-perun v0.13.1-0.20251022092243-2a0c6c36657c
 	perun.network/perun-ckb-backend v0.2.1-0.20251118120346-7099b21cf565
+	perun.network/perun-examples/shared/chainconfig v0.0.0
+	perun.network/perun-examples/shared/ckbcapacity v0.0.0
 )
 
 replace github.com/nervosnetwork/ckb-sdk-go/v2 v2.2.0 => github.com/perun-network/ckb-sdk-go/v2 v2.2.1-0.20251021125048-917f124466f1
@@ -37,7 +40,6 @@ require (
 	github.com/shirou/gopsutil v3.21.11+incompatible // indirect
 	github.com/sirupsen/logrus v1.9.0 // indirect
 	github.com/stretchr/objx v0.5.1 // indirect
-	github.com/stretchr/testify v1.8.4 // indirect
 	github.com/supranational/blst v0.3.11 // indirect
 	github.com/tklauser/go-sysconf v0.3.13 // indirect
 	github.com/tklauser/numcpus v0.7.0 // indirect
@@ -53,3 +55,7 @@ require (
 	polycry.pt/poly-go v0.0.0-20220301085937-fb9d71b45a37 // indirect
 	rsc.io/tmplfunc v0.0.3 // indirect
 )
+
+replace perun.network/perun-examples/shared/chainconfig => ../shared/chainconfig
+
+replace perun.network/perun-examples/shared/ckbcapacity => ../shared/ckbcapacity
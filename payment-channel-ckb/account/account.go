@@ -0,0 +1,114 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package account manages the secp256k1 keys of CKB accounts and the account
+// files the examples read from the accounts directory of the devnet.
+package account
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"perun.network/perun-examples/payment-channel-ckb/deployment"
+)
+
+const (
+	keyExt  = ".pk"
+	infoExt = ".txt"
+	// SUDTOwnerFile is the file that holds the lock hash of the SUDT owner.
+	SUDTOwnerFile = "sudt-owner-lock-hash.txt"
+)
+
+// Account is a CKB account with a secp256k1 key.
+type Account struct {
+	Name string
+	Key  *secp256k1.PrivateKey
+}
+
+// Generate generates an account with a random key.
+func Generate(name string) (*Account, error) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generating key: %w", err)
+	}
+	return &Account{Name: name, Key: key}, nil
+}
+
+// Import imports the account with the given key, hex encoded with an
+// optional 0x prefix, or the path of a key file.
+func Import(name, key string) (*Account, error) {
+	if _, err := os.Stat(key); err == nil {
+		k, err := deployment.GetKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("reading key file %s: %w", key, err)
+		}
+		return &Account{Name: name, Key: k}, nil
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(key), "0x"))
+	if err != nil || len(b) != secp256k1.PrivKeyBytesLen {
+		return nil, fmt.Errorf("invalid key: expected %d hex encoded bytes or a key file", secp256k1.PrivKeyBytesLen)
+	}
+	return &Account{Name: name, Key: secp256k1.PrivKeyFromBytes(b)}, nil
+}
+
+// Load loads the account with the given name from the directory.
+func Load(dir, name string) (*Account, error) {
+	key, err := deployment.GetKey(path.Join(dir, name+keyExt))
+	if err != nil {
+		return nil, fmt.Errorf("loading account %s: %w", name, err)
+	}
+	return &Account{Name: name, Key: key}, nil
+}
+
+// List returns the names of the accounts in the directory, sorted.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), keyExt) {
+			names = append(names, strings.TrimSuffix(e.Name(), keyExt))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SaveKey writes the key file of the account to the directory in the format
+// of `ckb-cli account export`: the key and the chain code, one per line. It
+// refuses to overwrite an existing key unless overwrite is set.
+func (a *Account) SaveKey(dir string, overwrite bool) error {
+	file := path.Join(dir, a.Name+keyExt)
+	if _, err := os.Stat(file); err == nil && !overwrite {
+		return fmt.Errorf("account %s already exists in %s", a.Name, dir)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	// The chain code is only used by ckb-cli for deriving child keys.
+	chainCode := make([]byte, 32)
+	if _, err := rand.Read(chainCode); err != nil {
+		return err
+	}
+	content := hex.EncodeToString(a.Key.Serialize()) + "\n" + hex.EncodeToString(chainCode)
+	return os.WriteFile(file, []byte(content), 0o600)
+}
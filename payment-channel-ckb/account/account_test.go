@@ -0,0 +1,47 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"perun.network/perun-examples/payment-channel-ckb/account"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	acc, err := account.Generate("alice")
+	require.NoError(t, err)
+	require.NoError(t, acc.SaveKey(dir, false))
+	require.Error(t, acc.SaveKey(dir, false), "overwriting")
+
+	loaded, err := account.Load(dir, "alice")
+	require.NoError(t, err)
+	require.Equal(t, acc.Key.Serialize(), loaded.Key.Serialize())
+
+	imported, err := account.Import("bob", "0x"+hex.EncodeToString(acc.Key.Serialize()))
+	require.NoError(t, err)
+	require.Equal(t, acc.Key.Serialize(), imported.Key.Serialize())
+	require.NoError(t, imported.SaveKey(dir, false))
+
+	names, err := account.List(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice", "bob"}, names)
+
+	_, err = account.Import("carol", "0x1234")
+	require.Error(t, err)
+}
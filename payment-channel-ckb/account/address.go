@@ -0,0 +1,182 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	sdkaddress "github.com/nervosnetwork/ckb-sdk-go/v2/address"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/crypto/blake2b"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"perun.network/perun-ckb-backend/wallet"
+	"perun.network/perun-ckb-backend/wallet/address"
+	"perun.network/perun-examples/payment-channel-ckb/deployment"
+)
+
+// AuthFlag selects how an omnilock verifies signatures.
+type AuthFlag byte
+
+const (
+	// AuthSecp256k1 verifies signatures of the key whose compressed public
+	// key has the blake160 hash in the lock args, like the default lock.
+	AuthSecp256k1 AuthFlag = 0x00
+	// AuthEthereum verifies Ethereum signatures of the Ethereum address in
+	// the lock args.
+	AuthEthereum AuthFlag = 0x01
+)
+
+// omnilockCodeHashes are the type hashes of the omnilock on the public
+// networks. On a devnet, the code hash depends on the deployment.
+var omnilockCodeHashes = map[deployment.Network]types.Hash{
+	deployment.Mainnet: types.HexToHash("0x9b819793a64463aed77c615d6cb226eea5487ccfc0783043a587254cda2b6f26"),
+	deployment.Testnet: types.HexToHash("0xf329effd1c475a2978453c8600e1eaf0bc2087ee093c3ee64cc96ec6847752cb"),
+}
+
+// OmnilockCodeHash returns the code hash of the omnilock on the network.
+func OmnilockCodeHash(n deployment.Network) (types.Hash, error) {
+	h, ok := omnilockCodeHashes[n]
+	if !ok {
+		return types.Hash{}, fmt.Errorf("the omnilock code hash of %s depends on the deployment, pass it explicitly", n)
+	}
+	return h, nil
+}
+
+// LockScript returns the default lock script of the account, the
+// secp256k1_blake160_sighash_all lock that the payment client uses.
+func (a *Account) LockScript() *types.Script {
+	return address.AsParticipant(wallet.NewAccountFromPrivateKey(a.Key).Address()).PaymentScript
+}
+
+// Address returns the address of the default lock on the network.
+func (a *Account) Address(n types.Network) (string, error) {
+	return encodeAddress(a.LockScript(), n)
+}
+
+// EthAddress returns the Ethereum address of the key.
+func (a *Account) EthAddress() common.Address {
+	return ethcrypto.PubkeyToAddress(a.Key.ToECDSA().PublicKey)
+}
+
+// OmnilockScript returns the omnilock script of the account with the given
+// code hash and auth flag.
+func (a *Account) OmnilockScript(codeHash types.Hash, auth AuthFlag) *types.Script {
+	return OmnilockScript(a.Key.PubKey(), codeHash, auth)
+}
+
+// OmnilockScript returns the omnilock script of the public key with the
+// given code hash and auth flag. The lock args are the auth flag, the auth
+// content and the omnilock flags, which are empty.
+func OmnilockScript(pub *secp256k1.PublicKey, codeHash types.Hash, auth AuthFlag) *types.Script {
+	var content []byte
+	switch auth {
+	case AuthEthereum:
+		eth := ethcrypto.PubkeyToAddress(*pub.ToECDSA())
+		content = eth[:]
+	default:
+		content = blake2b.Blake160(pub.SerializeCompressed())
+	}
+	args := append([]byte{byte(auth)}, content...)
+	args = append(args, 0x00)
+	return &types.Script{CodeHash: codeHash, HashType: types.HashTypeType, Args: args}
+}
+
+// MultisigCodeHash is the type hash of the secp256k1_blake160_multisig_all
+// lock, which is the same on all networks.
+var MultisigCodeHash = types.HexToHash("0x5c5069eb0857efc65e1bca0c07df34c31663b3622fd3876c876320fc9634e2a8")
+
+// MultisigScript returns the secp256k1_blake160_multisig_all lock script that
+// requires threshold signatures of the public keys, of which the first
+// requireFirstN must sign. The lock args are the blake160 hash of the
+// multisig, see EncodeMultisig.
+func MultisigScript(pubs []*secp256k1.PublicKey, threshold, requireFirstN uint8) (*types.Script, error) {
+	multisig, err := EncodeMultisig(pubs, threshold, requireFirstN)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Script{CodeHash: MultisigCodeHash, HashType: types.HashTypeType, Args: blake2b.Blake160(multisig)}, nil
+}
+
+// EncodeMultisig returns the multisig with which the witness of a
+// secp256k1_blake160_multisig_all lock starts: its version, requireFirstN,
+// threshold, the number of keys and the blake160 hash of each compressed
+// public key.
+func EncodeMultisig(pubs []*secp256k1.PublicKey, threshold, requireFirstN uint8) ([]byte, error) {
+	if len(pubs) == 0 || len(pubs) > 255 {
+		return nil, fmt.Errorf("multisig needs 1 to 255 keys, got %d", len(pubs))
+	}
+	if threshold == 0 || int(threshold) > len(pubs) {
+		return nil, fmt.Errorf("multisig threshold %d out of range [1, %d]", threshold, len(pubs))
+	}
+	if requireFirstN > threshold {
+		return nil, fmt.Errorf("multisig requires the first %d keys but the threshold is %d", requireFirstN, threshold)
+	}
+	multisig := []byte{0, requireFirstN, threshold, byte(len(pubs))}
+	for _, pub := range pubs {
+		multisig = append(multisig, blake2b.Blake160(pub.SerializeCompressed())...)
+	}
+	return multisig, nil
+}
+
+// OmnilockAddress returns the address of the omnilock of the account on the
+// network.
+func (a *Account) OmnilockAddress(codeHash types.Hash, auth AuthFlag, n types.Network) (string, error) {
+	return encodeAddress(a.OmnilockScript(codeHash, auth), n)
+}
+
+func encodeAddress(script *types.Script, n types.Network) (string, error) {
+	addr := sdkaddress.Address{Script: script, Network: n}
+	return addr.EncodeFullBech32m()
+}
+
+// Info returns the account info in the format of `ckb-cli account new`,
+// which the devnet scripts parse, and the Ethereum address.
+func (a *Account) Info() (string, error) {
+	mainnet, err := a.Address(types.NetworkMain)
+	if err != nil {
+		return "", err
+	}
+	testnet, err := a.Address(types.NetworkTest)
+	if err != nil {
+		return "", err
+	}
+	lock := a.LockScript()
+	var b strings.Builder
+	fmt.Fprintf(&b, "address:\n  mainnet: %s\n  testnet: %s\n", mainnet, testnet)
+	fmt.Fprintf(&b, "lock_arg: 0x%x\n", lock.Args)
+	fmt.Fprintf(&b, "lock_hash: %s\n", lock.Hash())
+	fmt.Fprintf(&b, "eth_address: %s\n", a.EthAddress())
+	return b.String(), nil
+}
+
+// SaveInfo writes the info file of the account to the directory.
+func (a *Account) SaveInfo(dir string) error {
+	info, err := a.Info()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path.Join(dir, a.Name+infoExt), []byte(info), 0o644)
+}
+
+// SaveSUDTOwner writes the lock hash of the account to the SUDT owner file
+// in the directory, from which the examples derive the SUDT type script.
+func (a *Account) SaveSUDTOwner(dir string) error {
+	return os.WriteFile(path.Join(dir, SUDTOwnerFile), []byte(a.LockScript().Hash().String()+"\n"), 0o644)
+}
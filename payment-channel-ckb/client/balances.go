@@ -16,7 +16,6 @@ package client
 
 import (
 	"context"
-	"encoding/binary"
 	"fmt"
 	"log"
 	"math"
@@ -25,9 +24,8 @@ import (
 	"time"
 
 	"github.com/nervosnetwork/ckb-sdk-go/v2/indexer"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/rpc"
 	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
-	"perun.network/perun-ckb-backend/channel"
-	"perun.network/perun-ckb-backend/wallet/address"
 )
 
 type BalanceExtractor func(*indexer.LiveCell) *big.Int
@@ -36,57 +34,261 @@ func ckbBalanceExtractor(cell *indexer.LiveCell) *big.Int {
 	return new(big.Int).SetUint64(cell.Output.Capacity)
 }
 
+// sudtBalanceExtractor returns the SUDT amount of the cell, or 0 if the cell
+// data does not hold an amount.
 func sudtBalanceExtractor(cell *indexer.LiveCell) *big.Int {
-	if len(cell.OutputData) != 16 {
+	amount, err := DecodeSUDTAmount(cell.OutputData)
+	if err != nil {
 		return big.NewInt(0)
 	}
-	return new(big.Int).SetUint64(binary.LittleEndian.Uint64(cell.OutputData))
+	return amount
 }
 
+// Balances are the on-chain balances of a client.
+type Balances struct {
+	CKBytes *big.Int                // In Shannon.
+	SUDTs   map[types.Hash]*big.Int // Registered SUDTs by the hash of their type script, in base units.
+}
+
+// equal returns whether the balances equal o.
+func (b Balances) equal(o Balances) bool {
+	if b.CKBytes.Cmp(o.CKBytes) != 0 || len(b.SUDTs) != len(o.SUDTs) {
+		return false
+	}
+	for h, bal := range b.SUDTs {
+		if obal, ok := o.SUDTs[h]; !ok || bal.Cmp(obal) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// clone returns a deep copy of the balances.
+func (b Balances) clone() Balances {
+	c := Balances{CKBytes: new(big.Int).Set(b.CKBytes), SUDTs: make(map[types.Hash]*big.Int, len(b.SUDTs))}
+	for h, bal := range b.SUDTs {
+		c.SUDTs[h] = new(big.Int).Set(bal)
+	}
+	return c
+}
+
+// BalanceHandler is called with the new balances of a client whenever they
+// change, e.g., when funds arrive or are locked into a channel.
+type BalanceHandler func(Balances)
+
+// balanceWatcher updates the balances of a client in the background.
+type balanceWatcher struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// balanceQueryTimeout is the timeout of a balance query.
+const balanceQueryTimeout = 10 * time.Second
+
+// PollBalances updates the balances of the client once.
 func (p *PaymentClient) PollBalances() {
-	pollingInterval := time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), balanceQueryTimeout)
+	defer cancel()
+	if err := p.updateBalances(ctx); err != nil {
+		log.Println("balance poll error: ", err)
+	}
+}
+
+// OnBalanceChange registers a handler that is called with the new balances
+// whenever they change. Handlers run on the goroutine that updates the
+// balances and must not block. The updates are serialized, so handlers are
+// called in the order of the queries and never with stale balances.
+func (p *PaymentClient) OnBalanceChange(h BalanceHandler) {
+	p.balanceMutex.Lock()
+	defer p.balanceMutex.Unlock()
+	p.balanceHandlers = append(p.balanceHandlers, h)
+}
+
+// WatchBalances starts updating the balances of the client in the
+// background until StopWatchingBalances or Shutdown is called. The balances
+// are updated every interval. If tipURL is set, they are also updated on
+// every new block, using the tip header subscription of the node at tipURL,
+// a websocket or TCP RPC URL. If the subscription fails, the watcher falls
+// back to polling. The interval must be positive.
+func (p *PaymentClient) WatchBalances(interval time.Duration, tipURL string) error {
+	var subscribe func(context.Context) (*tipSubscription, error)
+	if tipURL != "" {
+		subscribe = func(ctx context.Context) (*tipSubscription, error) {
+			return subscribeTips(ctx, tipURL)
+		}
+	}
+	return p.watchBalances(interval, subscribe)
+}
+
+// watchBalances starts the balance watcher. If subscribe is not nil, the
+// balances are also updated on the new blocks of its subscription.
+func (p *PaymentClient) watchBalances(interval time.Duration, subscribe func(context.Context) (*tipSubscription, error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("non-positive balance interval: %v", interval)
+	}
+	p.watcherMutex.Lock()
+	defer p.watcherMutex.Unlock()
+	if p.watcher != nil {
+		return fmt.Errorf("already watching balances")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := &tipSubscription{unsubscribe: func() {}}
+	if subscribe != nil {
+		var err error
+		if sub, err = subscribe(ctx); err != nil {
+			cancel()
+			return fmt.Errorf("subscribing to new blocks: %w", err)
+		}
+	}
+
+	w := &balanceWatcher{cancel: cancel, done: make(chan struct{})}
+	p.watcher = w
+	go func() {
+		defer close(w.done)
+		defer sub.unsubscribe()
+		tips, subErr := sub.tips, sub.err
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			qctx, qcancel := context.WithTimeout(ctx, balanceQueryTimeout)
+			if err := p.updateBalances(qctx); err != nil && ctx.Err() == nil {
+				log.Println("balance watcher error: ", err)
+			}
+			qcancel()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-tips:
+			case err := <-subErr:
+				log.Printf("balance watcher: block subscription failed, polling every %v: %v", interval, err)
+				tips, subErr = nil, nil
+			}
+		}
+	}()
+	return nil
+}
+
+// StopWatchingBalances stops the balance watcher and waits until it returned.
+func (p *PaymentClient) StopWatchingBalances() {
+	p.watcherMutex.Lock()
+	w := p.watcher
+	p.watcher = nil
+	p.watcherMutex.Unlock()
+	if w == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+}
+
+// tipSubscription is a subscription to the new blocks of a node.
+type tipSubscription struct {
+	tips        <-chan types.Header // The headers of new blocks.
+	err         <-chan error        // The error of the subscription.
+	unsubscribe func()              // Ends the subscription.
+}
+
+// subscribeTips subscribes to the new blocks of the node at url.
+func subscribeTips(ctx context.Context, url string) (*tipSubscription, error) {
+	c, err := rpc.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+	tips := make(chan types.Header, 1)
+	sub, err := c.SubscribeNewTipHeader(ctx, tips)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	return &tipSubscription{tips: tips, err: sub.Err(), unsubscribe: func() {
+		sub.Unsubscribe()
+		c.Close()
+	}}, nil
+}
+
+// updateBalances queries the balances of the client and notifies the balance
+// handlers if they changed. Concurrent updates are serialized, so that the
+// handlers never receive balances older than the previous ones.
+func (p *PaymentClient) updateBalances(ctx context.Context) error {
+	p.updateMutex.Lock()
+	defer p.updateMutex.Unlock()
+
 	searchKey := &indexer.SearchKey{
-		Script:           address.AsParticipant(p.WalletAddress()[channel.CKBBackendID]).PaymentScript,
+		Script:           p.fundingLock,
 		ScriptType:       types.ScriptTypeLock,
 		ScriptSearchMode: types.ScriptSearchModeExact,
 		Filter:           nil,
 		WithData:         true,
 	}
-	updateBalance := func() {
-		ctx, _ := context.WithTimeout(context.Background(), pollingInterval)
+	cells, err := p.rpcClient.GetCells(ctx, searchKey, indexer.SearchOrderDesc, math.MaxUint32, "")
+	if err != nil {
+		return err
+	}
 
-		cells, err := p.rpcClient.GetCells(ctx, searchKey, indexer.SearchOrderDesc, math.MaxUint32, "")
-		if err != nil {
-			log.Println("balance poll error: ", err)
-			return
+	bals := Balances{CKBytes: big.NewInt(0), SUDTs: make(map[types.Hash]*big.Int)}
+	p.balanceMutex.Lock()
+	for _, t := range p.sudts {
+		bals.SUDTs[t.hash] = big.NewInt(0)
+	}
+	p.balanceMutex.Unlock()
+	for _, cell := range cells.Objects {
+		bals.CKBytes.Add(bals.CKBytes, ckbBalanceExtractor(cell))
+		// Only cells of registered SUDTs are counted.
+		if cell.Output.Type == nil {
+			continue
 		}
-		ckbBalance := big.NewInt(0)
-		sudtBalance := big.NewInt(0)
-		for _, cell := range cells.Objects {
-			ckbBalance = new(big.Int).Add(ckbBalance, ckbBalanceExtractor(cell))
-			sudtBalance = new(big.Int).Add(sudtBalance, sudtBalanceExtractor(cell))
+		if bal, ok := bals.SUDTs[cell.Output.Type.Hash()]; ok {
+			bal.Add(bal, sudtBalanceExtractor(cell))
 		}
+	}
 
-		p.balanceMutex.Lock()
-		if ckbBalance.Cmp(p.balance) != 0 || sudtBalance.Cmp(p.sudtBalance) != 0 {
-			// Update ckb balance.
-			p.balance = ckbBalance
-
-			// Update sudt balance.
-			p.sudtBalance = sudtBalance
+	p.balanceMutex.Lock()
+	changed := !bals.equal(p.balances())
+	// Update ckb balance.
+	p.balance = bals.CKBytes
+	// Update sudt balances.
+	for _, t := range p.sudts {
+		if bal, ok := bals.SUDTs[t.hash]; ok {
+			t.balance = bal
+		}
+	}
+	handlers := p.balanceHandlers
+	p.balanceMutex.Unlock()
 
-			p.balanceMutex.Unlock()
-		} else {
-			p.balanceMutex.Unlock()
+	if changed {
+		for _, h := range handlers {
+			h(bals.clone())
 		}
 	}
-	updateBalance()
+	return nil
+}
+
+// Balances returns the balances of the client as of the last update.
+func (p *PaymentClient) Balances() Balances {
+	p.balanceMutex.Lock()
+	defer p.balanceMutex.Unlock()
+	return p.balances()
+}
 
+// balances returns a copy of the balances. The balance mutex must be held.
+func (p *PaymentClient) balances() Balances {
+	bals := Balances{CKBytes: new(big.Int).Set(p.balance), SUDTs: make(map[types.Hash]*big.Int, len(p.sudts))}
+	for _, t := range p.sudts {
+		bals.SUDTs[t.hash] = new(big.Int).Set(t.balance)
+	}
+	return bals
 }
 
-func FormatBalance(ckbBal, sudtBal *big.Int) string {
+// FormatBalance formats the CKByte balance, given in Shannon, and the SUDT
+// balances, formatted with FormatAmount.
+func FormatBalance(ckbBal *big.Int, sudtBals ...string) string {
 	balCKByte, _ := ShannonToCKByte(ckbBal).Float64()
-	return fmt.Sprintf("[green]%s\t[yellow]%s[white]",
-		strconv.FormatFloat(balCKByte, 'f', 2, 64)+" CKByte",
-		fmt.Sprintf("%v", sudtBal.Int64())+" SUDT")
+	s := fmt.Sprintf("[green]%s", strconv.FormatFloat(balCKByte, 'f', 2, 64)+" CKByte")
+	for _, b := range sudtBals {
+		s += fmt.Sprintf("\t[yellow]%s", b)
+	}
+	return s + "[white]"
 }
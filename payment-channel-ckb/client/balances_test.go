@@ -0,0 +1,126 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"github.com/stretchr/testify/require"
+	gpwallet "perun.network/go-perun/wallet"
+	"perun.network/perun-ckb-backend/channel"
+	"perun.network/perun-ckb-backend/channel/asset"
+	"perun.network/perun-ckb-backend/wallet"
+	"perun.network/perun-ckb-backend/wallet/address"
+	"perun.network/perun-examples/payment-channel-ckb/rpcmock"
+)
+
+func TestPollBalances(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	acc := wallet.NewAccountFromPrivateKey(key)
+	lock := address.AsParticipant(acc.Address()).PaymentScript
+	sudtType := &types.Script{CodeHash: types.HexToHash("0x02"), HashType: types.HashTypeData1, Args: []byte{1}}
+	sudtData, err := EncodeSUDTAmount(MaxSUDTAmount)
+	require.NoError(t, err)
+
+	node := rpcmock.New()
+	node.AddCell(types.CellOutput{Capacity: 1000_00_000_000, Lock: lock}, nil)
+	node.AddCell(types.CellOutput{Capacity: 200_00_000_000, Lock: lock, Type: sudtType}, sudtData)
+	other := &types.Script{CodeHash: lock.CodeHash, HashType: lock.HashType, Args: make([]byte, 20)}
+	node.AddCell(types.CellOutput{Capacity: 500_00_000_000, Lock: other}, nil)
+
+	p := &PaymentClient{
+		balance:     big.NewInt(0),
+		walletAccs:  map[gpwallet.BackendID]gpwallet.Account{channel.CKBBackendID: acc},
+		rpcClient:   node,
+		fundingLock: lock,
+	}
+	sudt := &asset.Asset{SUDT: asset.NewSUDT(*sudtType, 200_00_000_000)}
+	require.NoError(t, p.RegisterSUDT(sudt, SUDTMeta{Symbol: "SUDT", Decimals: 8}))
+	changes := 0
+	p.OnBalanceChange(func(Balances) { changes++ })
+
+	p.PollBalances()
+	bals := p.Balances()
+	require.Zero(t, bals.CKBytes.Cmp(big.NewInt(1200_00_000_000)), "ckbytes")
+	require.Zero(t, bals.SUDTs[sudtType.Hash()].Cmp(MaxSUDTAmount), "sudt")
+	p.PollBalances()
+	require.Equal(t, 1, changes)
+}
+
+func TestWatchBalances(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	lock := address.AsParticipant(wallet.NewAccountFromPrivateKey(key).Address()).PaymentScript
+	node := rpcmock.New()
+	node.AddCell(types.CellOutput{Capacity: 100_00_000_000, Lock: lock}, nil)
+
+	p := &PaymentClient{balance: big.NewInt(0), rpcClient: node, fundingLock: lock}
+	changes := make(chan *big.Int, 10)
+	p.OnBalanceChange(func(b Balances) { changes <- b.CKBytes })
+
+	// The interval is long, so that only new blocks update the balances.
+	tips := make(chan types.Header)
+	unsubscribed := make(chan struct{})
+	subscribe := func(context.Context) (*tipSubscription, error) {
+		return &tipSubscription{tips: tips, unsubscribe: func() { close(unsubscribed) }}, nil
+	}
+	require.ErrorContains(t, p.WatchBalances(0, ""), "non-positive balance interval")
+	require.ErrorContains(t, p.WatchBalances(-time.Second, ""), "non-positive balance interval")
+	require.NoError(t, p.watchBalances(time.Hour, subscribe))
+	require.ErrorContains(t, p.watchBalances(time.Hour, nil), "already watching")
+	requireChange(t, changes, 100_00_000_000)
+
+	node.AddCell(types.CellOutput{Capacity: 50_00_000_000, Lock: lock}, nil)
+	tips <- *node.Mine()
+	requireChange(t, changes, 150_00_000_000)
+
+	// The stopped watcher ends the subscription and no longer updates.
+	p.StopWatchingBalances()
+	select {
+	case <-unsubscribed:
+	default:
+		t.Fatal("subscription not ended")
+	}
+	node.AddCell(types.CellOutput{Capacity: 25_00_000_000, Lock: lock}, nil)
+	select {
+	case tips <- *node.Mine():
+		t.Fatal("watcher still running")
+	default:
+	}
+	require.Empty(t, changes)
+
+	// The watcher can be restarted.
+	require.NoError(t, p.watchBalances(time.Hour, nil))
+	requireChange(t, changes, 175_00_000_000)
+	p.StopWatchingBalances()
+}
+
+// requireChange requires that the balance handler is called with the
+// expected CKBytes.
+func requireChange(t *testing.T, changes <-chan *big.Int, expected int64) {
+	t.Helper()
+	select {
+	case bal := <-changes:
+		require.Zero(t, bal.Cmp(big.NewInt(expected)), "balance %v", bal)
+	case <-time.After(5 * time.Second):
+		t.Fatal("balances not updated")
+	}
+}
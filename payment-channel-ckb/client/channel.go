@@ -20,7 +20,8 @@ import (
 
 	"perun.network/go-perun/channel"
 	"perun.network/go-perun/client"
-	"perun.network/perun-ckb-backend/channel/asset"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
 )
 
 type PaymentChannel struct {
@@ -40,28 +41,48 @@ func (c PaymentChannel) State() *channel.State {
 	return c.ch.State().Clone()
 }
 
-func (c PaymentChannel) SendPayment(amounts map[channel.Asset]float64) {
+// ID returns the ID of the channel.
+func (c PaymentChannel) ID() channel.ID {
+	return c.ch.ID()
+}
+
+// Idx returns our index in the channel.
+func (c PaymentChannel) Idx() channel.Index {
+	return c.ch.Idx()
+}
+
+// Assets returns the assets of the channel.
+func (c PaymentChannel) Assets() []channel.Asset {
+	return c.assets
+}
+
+// Peer returns the wire address of the peer.
+func (c PaymentChannel) Peer() map[wallet.BackendID]wire.Address {
+	return c.ch.Peers()[1-c.ch.Idx()]
+}
+
+// ParentRef returns the reference to the channel for opening a virtual
+// channel on top of it. It must be a ledger channel with a hub.
+func (c PaymentChannel) ParentRef() ParentRef {
+	return ParentRef{ID: c.ch.ID(), Idx: c.ch.Idx()}
+}
+
+// SendPayment sends the given amounts to the peer, in Shannon for CKBytes and
+// in base units for SUDTs.
+func (c PaymentChannel) SendPayment(amounts map[channel.Asset]*big.Int) {
+	for a, amount := range amounts {
+		if err := checkAmount(a, amount); err != nil {
+			panic(err)
+		}
+	}
+
 	// Transfer the given amount from us to peer.
 	// Use UpdateBy to update the channel state.
 	err := c.ch.Update(context.TODO(), func(state *channel.State) {
 		actor := c.ch.Idx()
 		peer := 1 - actor
 		for a, amount := range amounts {
-			if amount < 0 {
-				continue
-			}
-			switch a := a.(type) {
-			case *asset.Asset:
-				if a.IsCKBytes {
-					shannonAmount := CKByteToShannon(big.NewFloat(amount))
-					state.Allocation.TransferBalance(actor, peer, a, shannonAmount)
-				} else {
-					sudtAmmount := new(big.Int).SetUint64(uint64(amount))
-					state.Allocation.TransferBalance(actor, peer, a, sudtAmmount)
-				}
-			default:
-				panic("Asset is not of type *asset.Asset")
-			}
+			state.Allocation.TransferBalance(actor, peer, a, amount)
 		}
 
 	})
@@ -20,6 +20,7 @@ import (
 	"math/big"
 
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/nervosnetwork/ckb-sdk-go/v2/rpc"
 	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
 	"github.com/perun-network/perun-libp2p-wire/p2p"
@@ -36,42 +37,91 @@ import (
 	ckbclient "perun.network/perun-ckb-backend/client"
 	"perun.network/perun-ckb-backend/wallet"
 	"perun.network/perun-ckb-backend/wallet/address"
+	ckbdeployment "perun.network/perun-examples/payment-channel-ckb/deployment"
 	"polycry.pt/poly-go/sync"
 )
 
 type PaymentClient struct {
-	balanceMutex sync.Mutex
-	Name         string
-	balance      *big.Int
-	sudtBalance  *big.Int
-	walletAccs   map[gpwallet.BackendID]gpwallet.Account
-	wireAddrs    map[gpwallet.BackendID]wire.Address
-	Network      types.Network
-	PerunClient  *client.Client
-	net          *p2p.Net
-	channels     chan *PaymentChannel
-	rpcClient    rpc.Client
+	balanceMutex    sync.Mutex
+	Name            string
+	balance         *big.Int
+	sudts           []*sudtToken     // Registered SUDTs, guarded by balanceMutex.
+	balanceHandlers []BalanceHandler // Guarded by balanceMutex.
+	updateMutex     sync.Mutex       // Serializes the balance updates and their notifications.
+	watcherMutex    sync.Mutex
+	watcher         *balanceWatcher
+	policyMutex     sync.Mutex
+	policy          AcceptancePolicy // Guarded by policyMutex.
+	fundingCapacity uint64           // Minimum capacity of a funding cell in Shannon.
+	walletAccs      map[gpwallet.BackendID]gpwallet.Account
+	wireAddrs       map[gpwallet.BackendID]wire.Address
+	Network         types.Network
+	PerunClient     *client.Client
+	dialer          Dialer
+	channels        chan *PaymentChannel
+	rpcClient       rpc.Client
+	fundingLock     *types.Script // The lock of the cells that fund channels.
 }
 
+// NewPaymentClient creates a payment client that connects to the CKB node at
+// rpcUrl. The lock config selects the payment script of the account.
 func NewPaymentClient(
 	name string,
 	network types.Network,
 	deployment backend.Deployment,
 	rpcUrl string,
 	walletAcc *wallet.Account,
+	lock LockConfig,
 	key secp256k1.PrivateKey,
 	wallet *wallet.EphemeralWallet,
 	wAddr wire.Address,
 	net *p2p.Net,
 
 ) (*PaymentClient, error) {
-	backendRPCClient, err := rpc.Dial(rpcUrl)
+	rpcClient, err := rpc.Dial(rpcUrl)
 	if err != nil {
 		return nil, err
 	}
-	signer := backend.NewSignerInstance(address.AsParticipant(walletAcc.Address()).ToCKBAddress(network), key, network)
+	return NewPaymentClientWithRPC(name, network, deployment, rpcClient, walletAcc, lock, key, wallet, wAddr, net.Bus, net.Dialer)
+}
+
+// Dialer registers the peer IDs of wire addresses, e.g., the dialer of a
+// p2p.Net.
+type Dialer interface {
+	Register(addr map[gpwallet.BackendID]wire.Address, id string)
+}
+
+// NewPaymentClientWithRPC creates a payment client that uses the given RPC
+// client, e.g., the in-process node of package rpcmock in tests, and
+// communicates over the given bus.
+func NewPaymentClientWithRPC(
+	name string,
+	network types.Network,
+	deployment backend.Deployment,
+	rpcClient rpc.Client,
+	walletAcc *wallet.Account,
+	lock LockConfig,
+	key secp256k1.PrivateKey,
+	wallet *wallet.EphemeralWallet,
+	wAddr wire.Address,
+	bus wire.Bus,
+	dialer Dialer,
+) (*PaymentClient, error) {
+	// The signer funds channels from the cells of the configured payment
+	// script, in which the participant also receives its payouts. The signer
+	// of the backend unlocks the default lock, the other locks are signed by
+	// the lock signer of the account.
+	lockAcc, err := newLockAccount(walletAcc, &key, lock)
+	if err != nil {
+		return nil, err
+	}
+	fundingLock := lockAcc.participant
+	signer := backend.NewSignerInstance(fundingLock.ToCKBAddress(network), key, network)
+	if lockAcc.signer != nil {
+		registerLockSigner(network, fundingLock.PaymentScript, lockAcc.signer)
+	}
 
-	ckbClient, err := ckbclient.NewClient(backendRPCClient, *signer, deployment)
+	ckbClient, err := ckbclient.NewClient(rpcClient, *signer, deployment)
 	if err != nil {
 		return nil, err
 	}
@@ -84,38 +134,43 @@ func NewPaymentClient(
 
 	waddresses := map[gpwallet.BackendID]wire.Address{channel.CKBBackendID: wAddr}
 	wallets := map[gpwallet.BackendID]gpwallet.Wallet{
-		channel.CKBBackendID: wallet,
+		channel.CKBBackendID: &lockWallet{EphemeralWallet: wallet, acc: lockAcc},
 	}
 	walletAccs := map[gpwallet.BackendID]gpwallet.Account{
-		channel.CKBBackendID: walletAcc,
+		channel.CKBBackendID: lockAcc,
 	}
 
-	perunClient, err := client.New(waddresses, net.Bus, f, a, wallets, watcher)
+	perunClient, err := client.New(waddresses, bus, f, a, wallets, watcher)
 	if err != nil {
 		return nil, err
 	}
 
-	balanceRPC, err := rpc.Dial(rpcUrl)
-	if err != nil {
-		return nil, err
-	}
 	p := &PaymentClient{
 		Name:        name,
 		balance:     big.NewInt(0),
-		sudtBalance: big.NewInt(0),
 		walletAccs:  walletAccs,
 		wireAddrs:   waddresses,
 		Network:     network,
 		PerunClient: perunClient,
 		channels:    make(chan *PaymentChannel, 1),
-		rpcClient:   balanceRPC,
-		net:         net,
+		rpcClient:   rpcClient,
+		fundingLock: fundingLock.PaymentScript,
+		dialer:      dialer,
+		policy:      DefaultAcceptancePolicy(),
+		// Computed from the scripts of the deployment.
+		fundingCapacity: ckbdeployment.FundingCellCapacity(deployment),
 	}
 
 	go perunClient.Handle(p, p)
 	return p, nil
 }
 
+// PaymentScript returns the lock script from whose cells the client funds
+// channels and to which the channels pay out.
+func (p *PaymentClient) PaymentScript() *types.Script {
+	return address.AsParticipant(p.WalletAddress()[channel.CKBBackendID]).PaymentScript
+}
+
 // WalletAddress returns the wallet address of the client.
 func (p *PaymentClient) WalletAddress() map[gpwallet.BackendID]gpwallet.Address {
 	addresses := make(map[gpwallet.BackendID]gpwallet.Address, len(p.walletAccs))
@@ -134,60 +189,52 @@ func (p *PaymentClient) PeerID() string {
 	return walletAddr.ID.String()
 }
 
-func (p *PaymentClient) GetSudtBalance() *big.Int {
-	p.balanceMutex.Lock()
-	defer p.balanceMutex.Unlock()
-	return new(big.Int).Set(p.sudtBalance)
+// PeerWireAddress returns the wire address of the peer with the given libp2p
+// peer ID, see PeerID.
+func PeerWireAddress(peerID string) (map[gpwallet.BackendID]wire.Address, error) {
+	id, err := peer.Decode(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer id %q: %w", peerID, err)
+	}
+	return map[gpwallet.BackendID]wire.Address{channel.CKBBackendID: &p2p.Address{ID: id}}, nil
 }
 
 // GetBalances retrieves the current balances of the client.
 func (p *PaymentClient) GetBalances() string {
 	p.PollBalances()
-	return FormatBalance(p.balance, p.sudtBalance)
+	return p.FormatBalances(p.Balances())
 }
 
-// OpenChannel opens a new channel with the specified peer and funding.
-func (p *PaymentClient) OpenChannel(peer map[gpwallet.BackendID]wire.Address, peerID string, amounts map[gpchannel.Asset]float64) *PaymentChannel {
+// FormatBalances formats the balances with the metadata of the registered
+// SUDTs.
+func (p *PaymentClient) FormatBalances(b Balances) string {
+	p.balanceMutex.Lock()
+	defer p.balanceMutex.Unlock()
+	sudtBals := make([]string, 0, len(p.sudts))
+	for _, t := range p.sudts {
+		if bal, ok := b.SUDTs[t.hash]; ok {
+			sudtBals = append(sudtBals, t.meta.FormatAmount(bal))
+		}
+	}
+	return FormatBalance(b.CKBytes, sudtBals...)
+}
+
+// OpenChannel opens a new channel with the specified peer in which we
+// deposit the own amount and the peer the peer amount of each asset. Either
+// amount may be zero, e.g., if only a customer deposits in a channel with a
+// merchant.
+func (p *PaymentClient) OpenChannel(peer map[gpwallet.BackendID]wire.Address, peerID string, funding map[gpchannel.Asset]Funding) *PaymentChannel {
 	// We define the channel participants. The proposer always has index 0. Here
 	// we use the on-chain addresses as off-chain addresses, but we could also
 	// use different ones.
 	participants := []map[gpwallet.BackendID]wire.Address{p.WireAddress(), peer}
-	p.net.Dialer.Register(peer, peerID)
-
-	assets := make([]gpchannel.Asset, len(amounts))
-	backends := make([]gpwallet.BackendID, len(amounts))
-	i := 0
-	for a := range amounts {
-		assets[i] = a
-		backends[i] = channel.CKBBackendID
-		i++
-	}
+	p.dialer.Register(peer, peerID)
 
 	// We create an initial allocation which defines the starting balances.
-	initAlloc := gpchannel.NewAllocation(2, backends, assets...)
-	for a, amount := range amounts {
-		switch a := a.(type) {
-		case *asset.Asset:
-			if a.IsCKBytes {
-				initAlloc.SetAssetBalances(a, []gpchannel.Bal{
-					CKByteToShannon(big.NewFloat(amount)), // Our initial balance.
-					CKByteToShannon(big.NewFloat(amount)), // Peer's initial balance.
-				})
-			} else {
-				intAmount := new(big.Int).SetUint64(uint64(amount))
-				initAlloc.SetAssetBalances(a, []gpchannel.Bal{
-					intAmount, // Our initial balance.
-					intAmount, // Peer's initial balance.
-				})
-			}
-		default:
-			panic("Asset is not of type *asset.Asset")
-		}
-
-	}
+	initAlloc, assets := newInitAlloc(funding)
 
 	// Prepare the channel proposal by defining the channel parameters.
-	challengeDuration := uint64(10) // On-chain challenge duration in seconds.
+	challengeDuration := uint64(defaultChallengeDuration) // On-chain challenge duration in seconds.
 	proposal, err := client.NewLedgerChannelProposal(
 		challengeDuration,
 		p.WalletAddress(),
@@ -210,6 +257,49 @@ func (p *PaymentClient) OpenChannel(peer map[gpwallet.BackendID]wire.Address, pe
 	return newPaymentChannel(ch, assets)
 }
 
+// newInitAlloc returns the allocation of the given funding, in which the
+// proposer has index 0, and its assets.
+func newInitAlloc(funding map[gpchannel.Asset]Funding) (*gpchannel.Allocation, []gpchannel.Asset) {
+	assets := make([]gpchannel.Asset, len(funding))
+	backends := make([]gpwallet.BackendID, len(funding))
+	i := 0
+	for a := range funding {
+		assets[i] = a
+		backends[i] = channel.CKBBackendID
+		i++
+	}
+
+	initAlloc := gpchannel.NewAllocation(2, backends, assets...)
+	for a, f := range funding {
+		for _, amount := range []*big.Int{f.Own, f.Peer} {
+			if err := checkAmount(a, amount); err != nil {
+				panic(err)
+			}
+		}
+		initAlloc.SetAssetBalances(a, []gpchannel.Bal{
+			new(big.Int).Set(f.Own),  // Our initial balance.
+			new(big.Int).Set(f.Peer), // Peer's initial balance.
+		})
+	}
+	return initAlloc, assets
+}
+
+// checkAmount checks that the amount is valid for the asset: CKBytes fit
+// into a uint64 and SUDT amounts into a uint128.
+func checkAmount(a gpchannel.Asset, amount *big.Int) error {
+	ckbAsset, ok := a.(*asset.Asset)
+	if !ok {
+		return fmt.Errorf("asset is not of type *asset.Asset")
+	}
+	if ckbAsset.IsCKBytes {
+		if amount.Sign() < 0 || !amount.IsUint64() {
+			return fmt.Errorf("ckbyte amount %v out of range [0, 2^64-1]", amount)
+		}
+		return nil
+	}
+	return checkSUDTAmount(amount)
+}
+
 // startWatching starts the dispute watcher for the specified channel.
 func (p *PaymentClient) startWatching(ch *client.Channel) {
 	go func() {
@@ -225,5 +315,6 @@ func (p *PaymentClient) AcceptedChannel() *PaymentChannel {
 }
 
 func (p *PaymentClient) Shutdown() {
+	p.StopWatchingBalances()
 	p.PerunClient.Close()
 }
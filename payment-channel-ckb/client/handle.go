@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/big"
 
 	"perun.network/go-perun/channel"
 	"perun.network/go-perun/client"
@@ -26,48 +27,58 @@ import (
 
 // HandleProposal is the callback for incoming channel proposals.
 func (p *PaymentClient) HandleProposal(prop client.ChannelProposal, r *client.ProposalResponder) {
-	lcp, err := func() (*client.LedgerChannelProposalMsg, error) {
-		// Ensure that we got a ledger channel proposal.
-		lcp, ok := prop.(*client.LedgerChannelProposalMsg)
-		if !ok {
-			return nil, fmt.Errorf("invalid proposal type: %T", p)
-		}
-
+	accept, err := func() (client.ChannelProposalAccept, error) {
+		base := prop.Base()
 		// Check that we have the correct number of participants.
-		if lcp.NumPeers() != 2 {
-			return nil, fmt.Errorf("invalid number of participants: %d", lcp.NumPeers())
+		if base.NumPeers() != 2 {
+			return nil, fmt.Errorf("invalid number of participants: %d", base.NumPeers())
+		}
+		// Check the assets, our deposits and the challenge duration against
+		// our policy. We are the responder with index 1.
+		const ourIdx = 1
+		if err := p.AcceptancePolicy().check(base, ourIdx); err != nil {
+			return nil, err
 		}
-		// Check that the channel has the expected assets and funding balances.
-		for i, assetAlloc := range lcp.FundingAgreement {
-			if assetAlloc[0].Cmp(assetAlloc[1]) != 0 {
-				return nil, fmt.Errorf("invalid funding balance for asset %d: %v", i, assetAlloc)
-			}
 
+		// Create a channel accept message with our account and our share of
+		// the channel nonce.
+		switch prop := prop.(type) {
+		case *client.LedgerChannelProposalMsg:
+			// We fund a ledger channel on-chain, so our balances must cover
+			// our deposits and the capacity of the funding cells.
+			deposits := make([]*big.Int, len(base.FundingAgreement))
+			for i, assetAlloc := range base.FundingAgreement {
+				deposits[i] = assetAlloc[ourIdx]
+			}
+			if err := p.checkFunds(base.InitBals.Assets, deposits); err != nil {
+				return nil, err
+			}
+			return prop.Accept(p.WalletAddress(), client.WithRandomNonce()), nil
+		case *client.VirtualChannelProposalMsg:
+			// Our share is funded from our ledger channel with the hub. The
+			// Perun client checks that the ledger channel covers it.
+			return prop.Accept(p.WalletAddress(), client.WithRandomNonce()), nil
+		default:
+			return nil, fmt.Errorf("invalid proposal type: %T", prop)
 		}
-		return lcp, nil
 	}()
 	if err != nil {
 		_ = r.Reject(context.TODO(), err.Error())
+		return
 	}
 
-	// Create a channel accept message and send it.
-	accept := lcp.Accept(
-		p.WalletAddress(),        // The Account we use in the channel.
-		client.WithRandomNonce(), // Our share of the channel nonce.
-	)
+	// Send the accept message.
 	ch, err := r.Accept(context.TODO(), accept)
 	if err != nil {
 		log.Printf("Error accepting channel proposal: %v", err)
 		return
 	}
 
-	//TODO: startWatching
 	// Start the on-chain event watcher. It automatically handles disputes.
 	p.startWatching(ch)
 
 	// Store channel.
-	p.channels <- newPaymentChannel(ch, lcp.InitBals.Clone().Assets)
-	//p.AcceptedChannel()
+	p.channels <- newPaymentChannel(ch, prop.Base().InitBals.Clone().Assets)
 }
 
 // HandleUpdate is the callback for incoming channel updates.
@@ -92,6 +103,7 @@ func (p *PaymentClient) HandleUpdate(cur *channel.State, next client.ChannelUpda
 	}()
 	if err != nil {
 		_ = r.Reject(context.TODO(), err.Error())
+		return
 	}
 
 	// Send the acceptance message.
@@ -0,0 +1,81 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	gpwallet "perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+	"polycry.pt/poly-go/sync"
+)
+
+// Hub is a payment client that routes virtual channels between light users.
+// Each light user opens one ledger channel with the hub. Two users can then
+// open a virtual channel between them, which is funded from their ledger
+// channels with the hub. The Perun client of the hub accepts the funding and
+// the settlement of a virtual channel only if it receives matching proposals
+// on both parent channels, and if the parent channels cover the balances.
+type Hub struct {
+	*PaymentClient
+
+	mtx            sync.Mutex
+	ledgerChannels map[wire.AddrKey]*PaymentChannel // Ledger channels by peer.
+}
+
+// NewHub returns a hub that accepts the ledger channels of light users with
+// the given client.
+func NewHub(c *PaymentClient) *Hub {
+	h := &Hub{
+		PaymentClient:  c,
+		ledgerChannels: make(map[wire.AddrKey]*PaymentChannel),
+	}
+	go h.acceptChannels()
+	return h
+}
+
+// acceptChannels records the ledger channels accepted by the client.
+func (h *Hub) acceptChannels() {
+	for ch := range h.PaymentClient.channels {
+		h.mtx.Lock()
+		h.ledgerChannels[wire.Keys(ch.Peer())] = ch
+		h.mtx.Unlock()
+	}
+}
+
+// Parent returns the reference to the ledger channel of the specified light
+// user with the hub. A user that opens a virtual channel needs it for the
+// parent channel of the peer.
+func (h *Hub) Parent(user map[gpwallet.BackendID]wire.Address) (ParentRef, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	ch, ok := h.ledgerChannels[wire.Keys(user)]
+	if !ok {
+		return ParentRef{}, fmt.Errorf("no ledger channel with user %v", user)
+	}
+	// The hub holds the other index of the ledger channel.
+	ref := ch.ParentRef()
+	ref.Idx = 1 - ref.Idx
+	return ref, nil
+}
+
+// LedgerChannel returns the ledger channel of the specified light user with
+// the hub.
+func (h *Hub) LedgerChannel(user map[gpwallet.BackendID]wire.Address) (*PaymentChannel, bool) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	ch, ok := h.ledgerChannels[wire.Keys(user)]
+	return ch, ok
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	gpwallet "perun.network/go-perun/wallet"
+	"perun.network/perun-ckb-backend/wallet"
+	"perun.network/perun-ckb-backend/wallet/address"
+	"perun.network/perun-examples/payment-channel-ckb/account"
+)
+
+// LockType is the type of the payment script of a participant, the lock of
+// the cells from which the participant funds channels and in which it
+// receives its funds when a channel is settled.
+type LockType int
+
+const (
+	// LockDefault uses the secp256k1_blake160_sighash_all lock of the key
+	// of the account.
+	LockDefault LockType = iota
+	// LockOmnilockEthereum uses an omnilock that the key of the account
+	// unlocks as an Ethereum key.
+	LockOmnilockEthereum
+	// LockMultisig uses a secp256k1_blake160_multisig_all lock, e.g., of an
+	// organization.
+	LockMultisig
+)
+
+// LockConfig configures the payment script of a participant. The client
+// funds channels from the cells of the payment script, signs the
+// transactions that spend them and receives its payouts there. The channel
+// scripts verify the channel states against a single key per participant,
+// so the key of the account signs the states and must be able to unlock the
+// payment script, alone or as one of the multisig keys.
+type LockConfig struct {
+	Type LockType
+	// OmnilockCodeHash is the code hash of the omnilock for
+	// LockOmnilockEthereum, see account.OmnilockCodeHash.
+	OmnilockCodeHash types.Hash
+	// Multisig configures the multisig lock for LockMultisig.
+	Multisig MultisigConfig
+	// CellDep is the cell dep of the omnilock or the multisig lock, which
+	// the client adds to the transactions that spend the payment script.
+	CellDep types.CellDep
+}
+
+// MultisigConfig configures a multisig lock that requires Threshold
+// signatures of PubKeys, of which the first RequireFirstN must sign. The
+// client signs with the key of the account and the keys of CoSigners, which
+// together must reach the threshold.
+type MultisigConfig struct {
+	PubKeys       []*secp256k1.PublicKey
+	Threshold     uint8
+	RequireFirstN uint8
+	CoSigners     []*secp256k1.PrivateKey
+}
+
+// paymentScript returns the payment script of the account.
+func (c LockConfig) paymentScript(acc *wallet.Account, key *secp256k1.PrivateKey) (*types.Script, error) {
+	switch c.Type {
+	case LockDefault:
+		return address.AsParticipant(acc.Address()).PaymentScript, nil
+	case LockOmnilockEthereum:
+		if c.OmnilockCodeHash == (types.Hash{}) {
+			return nil, fmt.Errorf("missing omnilock code hash")
+		}
+		return account.OmnilockScript(key.PubKey(), c.OmnilockCodeHash, account.AuthEthereum), nil
+	case LockMultisig:
+		return account.MultisigScript(c.Multisig.PubKeys, c.Multisig.Threshold, c.Multisig.RequireFirstN)
+	}
+	return nil, fmt.Errorf("unknown lock type %d", c.Type)
+}
+
+// signer returns the signer of the inputs of the payment script, or nil for
+// the default lock, whose inputs the signer of the backend signs.
+func (c LockConfig) signer(key *secp256k1.PrivateKey) (*lockSigner, error) {
+	if c.Type == LockDefault {
+		return nil, nil
+	}
+	if c.CellDep.OutPoint == nil {
+		return nil, fmt.Errorf("missing cell dep of the lock")
+	}
+	s := &lockSigner{lock: c.Type, cellDep: c.CellDep}
+	switch c.Type {
+	case LockOmnilockEthereum:
+		s.keys = []*secp256k1.PrivateKey{key}
+	case LockMultisig:
+		var err error
+		if s.multisig, err = account.EncodeMultisig(c.Multisig.PubKeys, c.Multisig.Threshold, c.Multisig.RequireFirstN); err != nil {
+			return nil, err
+		}
+		if s.keys, err = c.Multisig.signingKeys(key); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// signingKeys returns the keys with which the client signs the multisig in
+// the order of the public keys, given the key of the account. The keys
+// include the first RequireFirstN and reach the threshold.
+func (c MultisigConfig) signingKeys(key *secp256k1.PrivateKey) ([]*secp256k1.PrivateKey, error) {
+	held := make([]*secp256k1.PrivateKey, len(c.PubKeys))
+	for i, k := range append([]*secp256k1.PrivateKey{key}, c.CoSigners...) {
+		j := slices.IndexFunc(c.PubKeys, func(pub *secp256k1.PublicKey) bool { return pub.IsEqual(k.PubKey()) })
+		switch {
+		case j < 0 && i == 0:
+			return nil, fmt.Errorf("the key of the account, which signs the channel states, is not a multisig key")
+		case j < 0:
+			return nil, fmt.Errorf("co-signer %d is not a multisig key", i-1)
+		}
+		held[j] = k
+	}
+	for i := 0; i < int(c.RequireFirstN); i++ {
+		if held[i] == nil {
+			return nil, fmt.Errorf("missing the key of required multisig key %d", i)
+		}
+	}
+	keys := slices.DeleteFunc(held, func(k *secp256k1.PrivateKey) bool { return k == nil })
+	if len(keys) < int(c.Threshold) {
+		return nil, fmt.Errorf("%d multisig keys below the threshold of %d", len(keys), c.Threshold)
+	}
+	return keys[:c.Threshold], nil
+}
+
+// lockAccount is an account whose participant uses a configured payment
+// script.
+type lockAccount struct {
+	*wallet.Account
+	participant *address.Participant
+	signer      *lockSigner // The signer of the payment script, nil for the default lock.
+}
+
+// newLockAccount returns the account with the payment script of the lock
+// config.
+func newLockAccount(acc *wallet.Account, key *secp256k1.PrivateKey, lock LockConfig) (*lockAccount, error) {
+	script, err := lock.paymentScript(acc, key)
+	if err != nil {
+		return nil, fmt.Errorf("payment script: %w", err)
+	}
+	signer, err := lock.signer(key)
+	if err != nil {
+		return nil, fmt.Errorf("payment script signer: %w", err)
+	}
+	participant := *address.AsParticipant(acc.Address())
+	participant.PaymentScript = script
+	return &lockAccount{Account: acc, participant: &participant, signer: signer}, nil
+}
+
+// Address returns the participant with the configured payment script.
+func (a *lockAccount) Address() gpwallet.Address {
+	return a.participant
+}
+
+// lockWallet is a wallet that unlocks the lock account by its participant.
+type lockWallet struct {
+	*wallet.EphemeralWallet
+	acc *lockAccount
+}
+
+// Unlock returns the lock account for its participant and looks up other
+// addresses in the wallet.
+func (w *lockWallet) Unlock(a gpwallet.Address) (gpwallet.Account, error) {
+	if a.Equal(w.acc.Address()) {
+		return w.acc, nil
+	}
+	return w.EphemeralWallet.Unlock(a)
+}
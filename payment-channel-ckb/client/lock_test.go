@@ -0,0 +1,207 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/transaction"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"github.com/stretchr/testify/require"
+	"perun.network/perun-ckb-backend/wallet"
+	"perun.network/perun-ckb-backend/wallet/address"
+	"perun.network/perun-examples/payment-channel-ckb/account"
+)
+
+var (
+	testOmnilockCodeHash = types.HexToHash("0x03")
+	testLockDep          = types.CellDep{OutPoint: &types.OutPoint{TxHash: types.HexToHash("0x04")}, DepType: types.DepTypeCode}
+)
+
+func TestLockConfig(t *testing.T) {
+	keys := newTestKeys(t, 3)
+	pubs := []*secp256k1.PublicKey{keys[0].PubKey(), keys[1].PubKey(), keys[2].PubKey()}
+	multisig := func(threshold, requireFirstN uint8, cosigners ...*secp256k1.PrivateKey) LockConfig {
+		return LockConfig{
+			Type:     LockMultisig,
+			CellDep:  testLockDep,
+			Multisig: MultisigConfig{PubKeys: pubs, Threshold: threshold, RequireFirstN: requireFirstN, CoSigners: cosigners},
+		}
+	}
+	acc := wallet.NewAccountFromPrivateKey(keys[1])
+	msScript, err := account.MultisigScript(pubs, 2, 0)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name   string
+		config LockConfig
+		script *types.Script           // The expected payment script.
+		keys   []*secp256k1.PrivateKey // The expected signing keys.
+		err    string                  // The expected error, if any.
+	}{
+		{"default", LockConfig{Type: LockDefault}, address.AsParticipant(acc.Address()).PaymentScript, nil, ""},
+		{"omnilock", LockConfig{Type: LockOmnilockEthereum, OmnilockCodeHash: testOmnilockCodeHash, CellDep: testLockDep},
+			account.OmnilockScript(keys[1].PubKey(), testOmnilockCodeHash, account.AuthEthereum), keys[1:2], ""},
+		{"omnilock, no code hash", LockConfig{Type: LockOmnilockEthereum, CellDep: testLockDep}, nil, nil, "missing omnilock code hash"},
+		{"omnilock, no cell dep", LockConfig{Type: LockOmnilockEthereum, OmnilockCodeHash: testOmnilockCodeHash}, nil, nil, "missing cell dep"},
+		{"multisig", multisig(2, 0, keys[0]), msScript, keys[:2], ""},
+		{"multisig, extra co-signer", multisig(2, 0, keys[2], keys[0]), msScript, keys[:2], ""},
+		{"multisig, first n", multisig(2, 1, keys[0]), nil, keys[:2], ""},
+		{"multisig, account not a member", LockConfig{Type: LockMultisig, CellDep: testLockDep, Multisig: MultisigConfig{PubKeys: []*secp256k1.PublicKey{pubs[0], pubs[2]}, Threshold: 1}},
+			nil, nil, "key of the account"},
+		{"multisig, below threshold", multisig(2, 0), nil, nil, "below the threshold"},
+		{"multisig, missing first n", multisig(2, 1, keys[2]), nil, nil, "required multisig key 0"},
+		{"multisig, co-signer not a member", multisig(2, 0, keys[0], newTestKeys(t, 1)[0]), nil, nil, "co-signer 1 is not a multisig key"},
+		{"multisig, invalid threshold", multisig(4, 0, keys[0], keys[2]), nil, nil, "threshold"},
+		{"unknown", LockConfig{Type: LockMultisig + 1, CellDep: testLockDep}, nil, nil, "unknown lock type"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			lockAcc, err := newLockAccount(acc, keys[1], tt.config)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.script != nil {
+				require.Equal(t, tt.script, lockAcc.participant.PaymentScript)
+			}
+			if tt.keys == nil {
+				require.Nil(t, lockAcc.signer)
+				return
+			}
+			require.Equal(t, tt.config.Type, lockAcc.signer.lock)
+			require.Equal(t, tt.keys, lockAcc.signer.keys)
+		})
+	}
+}
+
+func TestLockSigner(t *testing.T) {
+	keys := newTestKeys(t, 2)
+	multisig, err := account.EncodeMultisig([]*secp256k1.PublicKey{keys[0].PubKey(), keys[1].PubKey()}, 2, 0)
+	require.NoError(t, err)
+	inputs := []uint32{0, 2} // The inputs of the payment script.
+
+	for _, tt := range []struct {
+		name   string
+		signer *lockSigner
+		// verify requires that the lock signs the message.
+		verify func(t *testing.T, lock []byte, msg func(zeroed []byte) []byte)
+	}{
+		{"multisig", &lockSigner{lock: LockMultisig, cellDep: testLockDep, keys: keys, multisig: multisig},
+			func(t *testing.T, lock []byte, msg func([]byte) []byte) {
+				require.Len(t, lock, len(multisig)+2*signatureSize)
+				require.Equal(t, multisig, lock[:len(multisig)])
+				zeroed := append(append([]byte(nil), multisig...), make([]byte, 2*signatureSize)...)
+				hash := msg(zeroed)
+				for i, k := range keys {
+					sig := lock[len(multisig)+i*signatureSize:][:signatureSize]
+					require.True(t, recoverKey(t, sig, hash).IsEqual(k.PubKey()), "signature %d", i)
+				}
+			}},
+		{"omnilock", &lockSigner{lock: LockOmnilockEthereum, cellDep: testLockDep, keys: keys[:1]},
+			func(t *testing.T, lock []byte, msg func([]byte) []byte) {
+				// The total size, the offsets of the signature, identity and
+				// preimage, and the size of the signature.
+				require.Equal(t, []byte{85, 0, 0, 0, 16, 0, 0, 0, 85, 0, 0, 0, 85, 0, 0, 0, 65, 0, 0, 0}, lock[:20])
+				require.Len(t, lock, 85)
+				hash := ethcrypto.Keccak256([]byte(ethMessagePrefix), msg(make([]byte, 85)))
+				require.True(t, recoverKey(t, lock[20:], hash).IsEqual(keys[0].PubKey()))
+			}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := newTestTransaction()
+			require.NoError(t, tt.signer.sign(tx, inputs))
+			require.Len(t, tx.Witnesses, len(tx.Inputs))
+			require.Len(t, tx.CellDeps, 2)
+			require.Equal(t, testLockDep, *tx.CellDeps[1])
+			require.Equal(t, []byte{1, 2, 3}, tx.Witnesses[1], "witness of another lock")
+			require.Empty(t, tx.Witnesses[2], "witness of the group")
+
+			witness, err := types.DeserializeWitnessArgs(tx.Witnesses[0])
+			require.NoError(t, err)
+			require.Equal(t, []byte{4}, witness.InputType, "input type of the witness")
+			tt.verify(t, witness.Lock, func(zeroed []byte) []byte {
+				w := *witness
+				w.Lock = zeroed
+				return sigHashAll(tx, inputs, w.Serialize())
+			})
+
+			// Signing again neither adds the cell dep again nor changes the
+			// deterministic signatures.
+			signed := tx.Witnesses[0]
+			require.NoError(t, tt.signer.sign(tx, inputs))
+			require.Len(t, tx.CellDeps, 2)
+			require.Equal(t, signed, tx.Witnesses[0])
+		})
+	}
+}
+
+func TestPaymentScriptSigner(t *testing.T) {
+	key := newTestKeys(t, 1)[0]
+	script := account.OmnilockScript(key.PubKey(), testOmnilockCodeHash, account.AuthEthereum)
+	registerLockSigner(types.NetworkTest, script, &lockSigner{lock: LockOmnilockEthereum, cellDep: testLockDep, keys: []*secp256k1.PrivateKey{key}})
+
+	// The signer signs the groups of registered scripts only.
+	tx := newTestTransaction()
+	other := *script
+	other.Args = []byte{1}
+	signed, err := paymentScriptSigner{}.SignTransaction(tx, &transaction.ScriptGroup{Script: &other, InputIndices: []uint32{0}}, nil)
+	require.NoError(t, err)
+	require.False(t, signed)
+	signed, err = paymentScriptSigner{}.SignTransaction(tx, &transaction.ScriptGroup{Script: script, InputIndices: []uint32{0}}, nil)
+	require.NoError(t, err)
+	require.True(t, signed)
+	require.Len(t, tx.CellDeps, 2)
+}
+
+// newTestTransaction returns a transaction with three inputs, the witness of
+// the first with an input type and that of the second of another lock.
+func newTestTransaction() *types.Transaction {
+	input := func(i uint32) *types.CellInput {
+		return &types.CellInput{PreviousOutput: &types.OutPoint{TxHash: types.HexToHash("0x05"), Index: i}}
+	}
+	first := &types.WitnessArgs{InputType: []byte{4}}
+	return &types.Transaction{
+		CellDeps:    []*types.CellDep{{OutPoint: &types.OutPoint{TxHash: types.HexToHash("0x06")}, DepType: types.DepTypeDepGroup}},
+		HeaderDeps:  []types.Hash{},
+		Inputs:      []*types.CellInput{input(0), input(1), input(2)},
+		Outputs:     []*types.CellOutput{{Capacity: 100, Lock: &types.Script{CodeHash: types.HexToHash("0x07"), HashType: types.HashTypeType, Args: []byte{}}}},
+		OutputsData: [][]byte{{}},
+		Witnesses:   [][]byte{first.Serialize(), {1, 2, 3}},
+	}
+}
+
+// newTestKeys returns n random keys.
+func newTestKeys(t *testing.T, n int) []*secp256k1.PrivateKey {
+	t.Helper()
+	keys := make([]*secp256k1.PrivateKey, n)
+	for i := range keys {
+		var err error
+		keys[i], err = secp256k1.GeneratePrivateKey()
+		require.NoError(t, err)
+	}
+	return keys
+}
+
+// recoverKey recovers the public key from a signature in the format of CKB.
+func recoverKey(t *testing.T, sig, hash []byte) *secp256k1.PublicKey {
+	t.Helper()
+	pub, _, err := ecdsa.RecoverCompact(append([]byte{sig[64] + 27}, sig[:64]...), hash)
+	require.NoError(t, err)
+	return pub
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	gpchannel "perun.network/go-perun/channel"
+	"perun.network/go-perun/client"
+	"perun.network/perun-ckb-backend/channel/asset"
+)
+
+// defaultChallengeDuration is the challenge duration of the channels we
+// propose and the minimum challenge duration we accept by default, in
+// seconds.
+const defaultChallengeDuration = 10
+
+// Funding is the initial balance of both participants of a channel in one
+// asset, in Shannon for CKBytes and in base units for SUDTs.
+type Funding struct {
+	Own  *big.Int // The deposit of the proposer.
+	Peer *big.Int // The deposit of the peer.
+}
+
+// SymmetricFunding returns the funding in which both participants deposit
+// the given amounts.
+func SymmetricFunding(amounts map[gpchannel.Asset]*big.Int) map[gpchannel.Asset]Funding {
+	funding := make(map[gpchannel.Asset]Funding, len(amounts))
+	for a, amount := range amounts {
+		funding[a] = Funding{Own: amount, Peer: amount}
+	}
+	return funding
+}
+
+// AssetLimits limits our own deposit of an asset in a proposed channel. A nil
+// bound is unbounded.
+type AssetLimits struct {
+	MinOwnDeposit *big.Int
+	MaxOwnDeposit *big.Int
+}
+
+// check checks that our deposit lies within the limits.
+func (l AssetLimits) check(deposit *big.Int) error {
+	if l.MinOwnDeposit != nil && deposit.Cmp(l.MinOwnDeposit) < 0 {
+		return fmt.Errorf("own deposit %v below minimum %v", deposit, l.MinOwnDeposit)
+	}
+	if l.MaxOwnDeposit != nil && deposit.Cmp(l.MaxOwnDeposit) > 0 {
+		return fmt.Errorf("own deposit %v above maximum %v", deposit, l.MaxOwnDeposit)
+	}
+	return nil
+}
+
+// AcceptancePolicy decides which channel proposals a client accepts.
+type AcceptancePolicy struct {
+	// MinChallengeDuration is the minimum challenge duration in seconds.
+	MinChallengeDuration uint64
+	// CKBytes limits our CKByte deposit. If nil, channels with CKBytes are
+	// rejected.
+	CKBytes *AssetLimits
+	// SUDTs are the allowed SUDTs by the hash of their type script, with the
+	// limits of our deposit.
+	SUDTs map[types.Hash]AssetLimits
+}
+
+// DefaultAcceptancePolicy returns the policy that accepts channels in
+// CKBytes with any deposit and the default challenge duration.
+func DefaultAcceptancePolicy() AcceptancePolicy {
+	return AcceptancePolicy{
+		MinChallengeDuration: defaultChallengeDuration,
+		CKBytes:              &AssetLimits{},
+	}
+}
+
+// limits returns the limits of the asset, or an error if the asset is not
+// allowed.
+func (pol AcceptancePolicy) limits(a gpchannel.Asset) (AssetLimits, error) {
+	ckbAsset, ok := a.(*asset.Asset)
+	switch {
+	case !ok:
+		return AssetLimits{}, fmt.Errorf("asset is not of type *asset.Asset")
+	case ckbAsset.IsCKBytes:
+		if pol.CKBytes == nil {
+			return AssetLimits{}, fmt.Errorf("ckbytes not allowed")
+		}
+		return *pol.CKBytes, nil
+	}
+	h := ckbAsset.SUDT.TypeScript.Hash()
+	l, ok := pol.SUDTs[h]
+	if !ok {
+		return AssetLimits{}, fmt.Errorf("sudt %v not allowed", h)
+	}
+	return l, nil
+}
+
+// check checks the proposal against the policy, where ourIdx is our index
+// in the channel.
+func (pol AcceptancePolicy) check(base *client.BaseChannelProposal, ourIdx gpchannel.Index) error {
+	if base.ChallengeDuration < pol.MinChallengeDuration {
+		return fmt.Errorf("challenge duration %d below minimum %d", base.ChallengeDuration, pol.MinChallengeDuration)
+	}
+	for i, a := range base.InitBals.Assets {
+		l, err := pol.limits(a)
+		if err != nil {
+			return fmt.Errorf("asset %d: %w", i, err)
+		}
+		deposit := base.FundingAgreement[i][ourIdx]
+		if err := checkAmount(a, deposit); err != nil {
+			return fmt.Errorf("asset %d: %w", i, err)
+		}
+		if err := l.check(deposit); err != nil {
+			return fmt.Errorf("asset %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// SetAcceptancePolicy sets the policy for incoming channel proposals.
+func (p *PaymentClient) SetAcceptancePolicy(pol AcceptancePolicy) {
+	p.policyMutex.Lock()
+	defer p.policyMutex.Unlock()
+	p.policy = pol
+}
+
+// AcceptancePolicy returns the policy for incoming channel proposals.
+func (p *PaymentClient) AcceptancePolicy() AcceptancePolicy {
+	p.policyMutex.Lock()
+	defer p.policyMutex.Unlock()
+	return p.policy
+}
+
+// requiredCapacity returns the CKBytes in Shannon we lock on-chain to fund a
+// ledger channel with the given deposits: our CKByte deposit, the minimum
+// capacity of the funding cell and the capacity of a cell per SUDT we
+// deposit.
+func (p *PaymentClient) requiredCapacity(assets []gpchannel.Asset, deposits []*big.Int) *big.Int {
+	required := new(big.Int).SetUint64(p.fundingCapacity)
+	for i, a := range assets {
+		ckbAsset, ok := a.(*asset.Asset)
+		switch {
+		case !ok || deposits[i].Sign() == 0:
+		case ckbAsset.IsCKBytes:
+			required.Add(required, deposits[i])
+		default:
+			required.Add(required, new(big.Int).SetUint64(ckbAsset.SUDT.MaxCapacity))
+		}
+	}
+	return required
+}
+
+// checkFunds checks that our on-chain balances cover our deposits in a
+// ledger channel, including the capacity overhead of the funding cells.
+func (p *PaymentClient) checkFunds(assets []gpchannel.Asset, deposits []*big.Int) error {
+	p.PollBalances()
+	bals := p.Balances()
+	if required := p.requiredCapacity(assets, deposits); bals.CKBytes.Cmp(required) < 0 {
+		return fmt.Errorf("insufficient ckbytes: %s required including the capacity of the funding cells, %s available",
+			p.FormatAmount(&asset.Asset{IsCKBytes: true}, required), p.FormatAmount(&asset.Asset{IsCKBytes: true}, bals.CKBytes))
+	}
+	for i, a := range assets {
+		ckbAsset, ok := a.(*asset.Asset)
+		if !ok || ckbAsset.IsCKBytes {
+			continue
+		}
+		// We only know the balances of registered SUDTs.
+		bal, ok := bals.SUDTs[ckbAsset.SUDT.TypeScript.Hash()]
+		if ok && bal.Cmp(deposits[i]) < 0 {
+			return fmt.Errorf("insufficient sudt: %s required, %s available", p.FormatAmount(a, deposits[i]), p.FormatAmount(a, bal))
+		}
+	}
+	return nil
+}
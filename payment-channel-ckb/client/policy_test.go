@@ -0,0 +1,147 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"github.com/stretchr/testify/require"
+	gpchannel "perun.network/go-perun/channel"
+	"perun.network/go-perun/client"
+	gpwallet "perun.network/go-perun/wallet"
+	"perun.network/perun-ckb-backend/channel"
+	"perun.network/perun-ckb-backend/channel/asset"
+	"perun.network/perun-ckb-backend/wallet"
+	"perun.network/perun-ckb-backend/wallet/address"
+	"perun.network/perun-examples/payment-channel-ckb/rpcmock"
+)
+
+const (
+	testSUDTCapacity    = 142_00_000_000 // The max capacity of the test SUDT.
+	testFundingCapacity = 61_00_000_000  // The capacity of a funding cell.
+)
+
+var (
+	testCKB  = &asset.Asset{IsCKBytes: true}
+	testSUDT = &asset.Asset{SUDT: asset.NewSUDT(types.Script{CodeHash: types.HexToHash("0x02"), HashType: types.HashTypeData1, Args: []byte{1}}, testSUDTCapacity)}
+)
+
+// newTestProposal returns a proposal in which we, with index 1, deposit own
+// and the peer deposits 1 of each asset.
+func newTestProposal(challengeDuration uint64, assets []gpchannel.Asset, own ...*big.Int) *client.BaseChannelProposal {
+	backends := make([]gpwallet.BackendID, len(assets))
+	for i := range backends {
+		backends[i] = channel.CKBBackendID
+	}
+	alloc := gpchannel.NewAllocation(2, backends, assets...)
+	for i, a := range assets {
+		alloc.SetAssetBalances(a, []gpchannel.Bal{big.NewInt(1), own[i]})
+	}
+	return &client.BaseChannelProposal{
+		ChallengeDuration: challengeDuration,
+		InitBals:          alloc,
+		FundingAgreement:  alloc.Balances,
+	}
+}
+
+func TestAcceptancePolicy(t *testing.T) {
+	limited := AcceptancePolicy{
+		MinChallengeDuration: 100,
+		CKBytes:              &AssetLimits{MinOwnDeposit: big.NewInt(10), MaxOwnDeposit: big.NewInt(20)},
+		SUDTs:                map[types.Hash]AssetLimits{testSUDT.SUDT.TypeScript.Hash(): {MaxOwnDeposit: big.NewInt(5)}},
+	}
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 64)
+	for _, tt := range []struct {
+		name     string
+		policy   AcceptancePolicy
+		duration uint64
+		assets   []gpchannel.Asset
+		own      []*big.Int
+		err      string // The expected error, if any.
+	}{
+		{"default", DefaultAcceptancePolicy(), defaultChallengeDuration, []gpchannel.Asset{testCKB}, []*big.Int{big.NewInt(1000)}, ""},
+		{"default, short challenge", DefaultAcceptancePolicy(), defaultChallengeDuration - 1, []gpchannel.Asset{testCKB}, []*big.Int{big.NewInt(1)}, "challenge duration"},
+		{"default, sudt", DefaultAcceptancePolicy(), defaultChallengeDuration, []gpchannel.Asset{testSUDT}, []*big.Int{big.NewInt(1)}, "not allowed"},
+		{"default, ckbytes out of range", DefaultAcceptancePolicy(), defaultChallengeDuration, []gpchannel.Asset{testCKB}, []*big.Int{tooLarge}, "out of range"},
+		{"no ckbytes", AcceptancePolicy{}, 0, []gpchannel.Asset{testCKB}, []*big.Int{big.NewInt(1)}, "ckbytes not allowed"},
+		{"limits", limited, 100, []gpchannel.Asset{testCKB, testSUDT}, []*big.Int{big.NewInt(10), big.NewInt(5)}, ""},
+		{"limits, no deposit", limited, 100, []gpchannel.Asset{testSUDT}, []*big.Int{big.NewInt(0)}, ""},
+		{"below minimum", limited, 100, []gpchannel.Asset{testCKB}, []*big.Int{big.NewInt(9)}, "below minimum"},
+		{"above maximum", limited, 100, []gpchannel.Asset{testCKB}, []*big.Int{big.NewInt(21)}, "above maximum"},
+		{"sudt above maximum", limited, 100, []gpchannel.Asset{testCKB, testSUDT}, []*big.Int{big.NewInt(10), big.NewInt(6)}, "asset 1: own deposit 6 above maximum"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.check(newTestProposal(tt.duration, tt.assets, tt.own...), 1)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckFunds(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	acc := wallet.NewAccountFromPrivateKey(key)
+	lock := address.AsParticipant(acc.Address()).PaymentScript
+
+	// The CKBytes must cover the deposit, the funding cell and a cell per
+	// deposited SUDT.
+	for _, tt := range []struct {
+		name       string
+		ckbytes    uint64 // Our CKBytes on-chain.
+		sudt       int64  // Our SUDTs on-chain.
+		ckbDeposit int64
+		sudtDep    int64
+		err        string // The expected error, if any.
+	}{
+		{"exact", 100 + testFundingCapacity, 0, 100, 0, ""},
+		{"funding capacity", 100 + testFundingCapacity - 1, 0, 100, 0, "insufficient ckbytes"},
+		{"no deposit", testFundingCapacity, 0, 0, 0, ""},
+		{"sudt", testFundingCapacity + testSUDTCapacity, 5, 0, 5, ""},
+		{"sudt capacity", testFundingCapacity + testSUDTCapacity - 1, 5, 0, 5, "insufficient ckbytes"},
+		{"sudt amount", testFundingCapacity + testSUDTCapacity, 4, 0, 5, "insufficient sudt"},
+		{"both", 100 + testFundingCapacity + testSUDTCapacity, 5, 100, 5, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			node := rpcmock.New()
+			// The SUDT cell holds no CKBytes beyond the test balance.
+			node.AddCell(types.CellOutput{Capacity: tt.ckbytes, Lock: lock}, nil)
+			sudtData, err := EncodeSUDTAmount(big.NewInt(tt.sudt))
+			require.NoError(t, err)
+			node.AddCell(types.CellOutput{Capacity: 0, Lock: lock, Type: &testSUDT.SUDT.TypeScript}, sudtData)
+
+			p := &PaymentClient{
+				balance:         big.NewInt(0),
+				walletAccs:      map[gpwallet.BackendID]gpwallet.Account{channel.CKBBackendID: acc},
+				rpcClient:       node,
+				fundingLock:     lock,
+				fundingCapacity: testFundingCapacity,
+			}
+			require.NoError(t, p.RegisterSUDT(testSUDT, SUDTMeta{Symbol: "SUDT", Decimals: 8}))
+			err = p.checkFunds([]gpchannel.Asset{testCKB, testSUDT}, []*big.Int{big.NewInt(tt.ckbDeposit), big.NewInt(tt.sudtDep)})
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/crypto/blake2b"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/transaction"
+	sdksigner "github.com/nervosnetwork/ckb-sdk-go/v2/transaction/signer"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"polycry.pt/poly-go/sync"
+)
+
+const (
+	// signatureSize is the size of a recoverable secp256k1 signature.
+	signatureSize = 65
+	// ethMessagePrefix is the prefix of a message signed as an Ethereum
+	// personal message, followed by the 32 bytes of the message.
+	ethMessagePrefix = "\x19Ethereum Signed Message:\n32"
+)
+
+// lockSigner signs the inputs of an omnilock or a multisig payment script,
+// which the signer of the backend does not unlock.
+type lockSigner struct {
+	lock     LockType
+	cellDep  types.CellDep
+	keys     []*secp256k1.PrivateKey // The signing keys.
+	multisig []byte                  // The encoded multisig of LockMultisig.
+}
+
+// sign signs the inputs of the payment script with the given indices. It
+// adds the cell dep of the lock to the transaction if it is missing, so it
+// must sign before the inputs of other locks.
+func (s *lockSigner) sign(tx *types.Transaction, inputs []uint32) error {
+	if len(inputs) == 0 {
+		return fmt.Errorf("no inputs to sign")
+	}
+	s.addCellDep(tx)
+	for len(tx.Witnesses) < len(tx.Inputs) {
+		tx.Witnesses = append(tx.Witnesses, []byte{})
+	}
+	witness, err := witnessArgs(tx.Witnesses[inputs[0]])
+	if err != nil {
+		return fmt.Errorf("witness of input %d: %w", inputs[0], err)
+	}
+
+	switch s.lock {
+	case LockOmnilockEthereum:
+		// The omnilock signs the message with a zeroed lock as an Ethereum
+		// personal message.
+		witness.Lock = make([]byte, len(omnilockWitnessLock(make([]byte, signatureSize))))
+		msg := sigHashAll(tx, inputs, witness.Serialize())
+		witness.Lock = omnilockWitnessLock(signRecoverable(s.keys[0], ethcrypto.Keccak256([]byte(ethMessagePrefix), msg)))
+	case LockMultisig:
+		// The multisig signs the message with the multisig and zeroed
+		// signatures in the lock.
+		witness.Lock = append(append([]byte(nil), s.multisig...), make([]byte, signatureSize*len(s.keys))...)
+		msg := sigHashAll(tx, inputs, witness.Serialize())
+		for i, k := range s.keys {
+			copy(witness.Lock[len(s.multisig)+i*signatureSize:], signRecoverable(k, msg))
+		}
+	default:
+		return fmt.Errorf("unsupported lock type %d", s.lock)
+	}
+	tx.Witnesses[inputs[0]] = witness.Serialize()
+	return nil
+}
+
+// addCellDep adds the cell dep of the lock to the transaction if it is
+// missing.
+func (s *lockSigner) addCellDep(tx *types.Transaction) {
+	for _, dep := range tx.CellDeps {
+		if dep.DepType == s.cellDep.DepType && dep.OutPoint != nil && *dep.OutPoint == *s.cellDep.OutPoint {
+			return
+		}
+	}
+	dep := s.cellDep
+	tx.CellDeps = append(tx.CellDeps, &dep)
+}
+
+// witnessArgs decodes the witness args, which are empty in a new witness.
+func witnessArgs(witness []byte) (*types.WitnessArgs, error) {
+	if len(witness) == 0 {
+		return &types.WitnessArgs{}, nil
+	}
+	return types.DeserializeWitnessArgs(witness)
+}
+
+// sigHashAll returns the message that a lock signs for the inputs with the
+// given indices: the hash of the transaction, the first witness of the
+// inputs with the lock prepared for signing, the other witnesses of the
+// inputs and the witnesses without input, each prefixed by its length.
+func sigHashAll(tx *types.Transaction, inputs []uint32, first []byte) []byte {
+	h := tx.ComputeHash()
+	msg := append([]byte(nil), h[:]...)
+	appendWitness := func(w []byte) {
+		msg = binary.LittleEndian.AppendUint64(msg, uint64(len(w)))
+		msg = append(msg, w...)
+	}
+	appendWitness(first)
+	for _, i := range inputs[1:] {
+		appendWitness(tx.Witnesses[i])
+	}
+	for i := len(tx.Inputs); i < len(tx.Witnesses); i++ {
+		appendWitness(tx.Witnesses[i])
+	}
+	return blake2b.Blake256(msg)
+}
+
+// signRecoverable signs the hash and returns the signature in the format of
+// CKB: r, s and the recovery id.
+func signRecoverable(key *secp256k1.PrivateKey, hash []byte) []byte {
+	// The compact signature starts with 27 plus the recovery id.
+	compact := ecdsa.SignCompact(key, hash, false)
+	return append(compact[1:], compact[0]-27)
+}
+
+// omnilockWitnessLock returns the OmniLockWitnessLock molecule table with the
+// signature and without identity and preimage.
+func omnilockWitnessLock(sig []byte) []byte {
+	const header = 4 + 3*4 // The total size and the offsets of the 3 fields.
+	size := uint32(header + 4 + len(sig))
+	b := binary.LittleEndian.AppendUint32(nil, size)
+	b = binary.LittleEndian.AppendUint32(b, header)
+	b = binary.LittleEndian.AppendUint32(b, size) // No identity.
+	b = binary.LittleEndian.AppendUint32(b, size) // No preimage.
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(sig)))
+	return append(b, sig...)
+}
+
+// lockSigners are the signers of the payment scripts of the clients by the
+// hash of the script. The transaction signer of the SDK, with which the
+// backend signs, is shared per network, so the clients register the code
+// hashes of their payment scripts with it once.
+var lockSigners = struct {
+	sync.Mutex
+	registered map[types.Network]map[types.Hash]bool
+	signers    map[types.Hash]*lockSigner
+}{
+	registered: make(map[types.Network]map[types.Hash]bool),
+	signers:    make(map[types.Hash]*lockSigner),
+}
+
+// registerLockSigner registers the signer of the payment script on the
+// network.
+func registerLockSigner(network types.Network, script *types.Script, s *lockSigner) {
+	lockSigners.Lock()
+	defer lockSigners.Unlock()
+	lockSigners.signers[script.Hash()] = s
+	if lockSigners.registered[network] == nil {
+		lockSigners.registered[network] = make(map[types.Hash]bool)
+	}
+	if !lockSigners.registered[network][script.CodeHash] {
+		sdksigner.GetTransactionSignerInstance(network).RegisterLockSigner(script.CodeHash, paymentScriptSigner{})
+		lockSigners.registered[network][script.CodeHash] = true
+	}
+}
+
+// paymentScriptSigner is the lock signer of the SDK for the code hashes of
+// the registered payment scripts. It signs with the keys of the registered
+// signer instead of the key of the context.
+type paymentScriptSigner struct{}
+
+func (paymentScriptSigner) SignTransaction(tx *types.Transaction, group *transaction.ScriptGroup, _ *transaction.Context) (bool, error) {
+	lockSigners.Lock()
+	s, ok := lockSigners.signers[group.Script.Hash()]
+	lockSigners.Unlock()
+	if !ok {
+		return false, nil
+	}
+	if err := s.sign(tx, group.InputIndices); err != nil {
+		return false, err
+	}
+	return true, nil
+}
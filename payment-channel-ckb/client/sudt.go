@@ -0,0 +1,186 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	gpchannel "perun.network/go-perun/channel"
+	"perun.network/perun-ckb-backend/channel/asset"
+)
+
+// sudtAmountLen is the length of the amount at the start of the data of a
+// SUDT cell, a little-endian uint128.
+const sudtAmountLen = 16
+
+// MaxSUDTAmount is the largest SUDT amount, 2^128 - 1.
+var MaxSUDTAmount = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+// SUDTMeta is the metadata of a SUDT. Amounts are handled in base units;
+// Decimals is the number of decimal places of one token.
+type SUDTMeta struct {
+	Symbol   string
+	Decimals uint8
+}
+
+// DecodeSUDTAmount decodes the amount of a SUDT cell from the cell data. The
+// data may hold more bytes after the amount.
+func DecodeSUDTAmount(data []byte) (*big.Int, error) {
+	if len(data) < sudtAmountLen {
+		return nil, fmt.Errorf("sudt cell data too short: %d bytes, expected at least %d", len(data), sudtAmountLen)
+	}
+	// big.Int expects big-endian bytes.
+	be := make([]byte, sudtAmountLen)
+	for i := range be {
+		be[i] = data[sudtAmountLen-1-i]
+	}
+	return new(big.Int).SetBytes(be), nil
+}
+
+// EncodeSUDTAmount encodes the amount as the data of a SUDT cell.
+func EncodeSUDTAmount(amount *big.Int) ([]byte, error) {
+	if err := checkSUDTAmount(amount); err != nil {
+		return nil, err
+	}
+	be := amount.FillBytes(make([]byte, sudtAmountLen))
+	data := make([]byte, sudtAmountLen)
+	for i := range data {
+		data[i] = be[sudtAmountLen-1-i]
+	}
+	return data, nil
+}
+
+// checkSUDTAmount checks that the amount fits into a uint128.
+func checkSUDTAmount(amount *big.Int) error {
+	if amount.Sign() < 0 || amount.Cmp(MaxSUDTAmount) > 0 {
+		return fmt.Errorf("sudt amount %v out of range [0, 2^128-1]", amount)
+	}
+	return nil
+}
+
+// ParseAmount parses a decimal amount of tokens, e.g., "1.5", into base
+// units.
+func (m SUDTMeta) ParseAmount(s string) (*big.Int, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	if len(frac) > int(m.Decimals) {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", s, m.Decimals)
+	}
+	digits := whole + frac + strings.Repeat("0", int(m.Decimals)-len(frac))
+	amount, ok := new(big.Int).SetString(digits, 10)
+	if !ok || whole == "" || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	if err := checkSUDTAmount(amount); err != nil {
+		return nil, err
+	}
+	return amount, nil
+}
+
+// FormatAmount formats an amount in base units as tokens with the symbol,
+// e.g., "1.50000000 SUDT".
+func (m SUDTMeta) FormatAmount(amount *big.Int) string {
+	digits := new(big.Int).Abs(amount).String()
+	if n := int(m.Decimals) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+	split := len(digits) - int(m.Decimals)
+	s := sign + digits[:split]
+	if m.Decimals > 0 {
+		s += "." + digits[split:]
+	}
+	return s + " " + m.Symbol
+}
+
+// sudtToken is a SUDT registered with a client.
+type sudtToken struct {
+	hash    types.Hash // The hash of the type script of the SUDT.
+	meta    SUDTMeta
+	balance *big.Int
+}
+
+// RegisterSUDT registers the metadata of a SUDT asset. The client tracks the
+// balances of the registered SUDTs and formats their amounts with the
+// metadata.
+func (p *PaymentClient) RegisterSUDT(a *asset.Asset, meta SUDTMeta) error {
+	if a.IsCKBytes || a.SUDT == nil {
+		return fmt.Errorf("asset is not a sudt")
+	}
+	h := a.SUDT.TypeScript.Hash()
+
+	p.balanceMutex.Lock()
+	defer p.balanceMutex.Unlock()
+	for _, t := range p.sudts {
+		if t.hash == h {
+			t.meta = meta
+			return nil
+		}
+	}
+	p.sudts = append(p.sudts, &sudtToken{hash: h, meta: meta, balance: big.NewInt(0)})
+	return nil
+}
+
+// sudtMeta returns the metadata of the SUDT with the given type script hash.
+func (p *PaymentClient) sudtMeta(h types.Hash) (SUDTMeta, bool) {
+	p.balanceMutex.Lock()
+	defer p.balanceMutex.Unlock()
+	for _, t := range p.sudts {
+		if t.hash == h {
+			return t.meta, true
+		}
+	}
+	return SUDTMeta{}, false
+}
+
+// GetSUDTBalance returns the on-chain balance of a registered SUDT in base
+// units, as of the last balance poll.
+func (p *PaymentClient) GetSUDTBalance(a *asset.Asset) (*big.Int, error) {
+	if a.IsCKBytes || a.SUDT == nil {
+		return nil, fmt.Errorf("asset is not a sudt")
+	}
+	h := a.SUDT.TypeScript.Hash()
+
+	p.balanceMutex.Lock()
+	defer p.balanceMutex.Unlock()
+	for _, t := range p.sudts {
+		if t.hash == h {
+			return new(big.Int).Set(t.balance), nil
+		}
+	}
+	return nil, fmt.Errorf("sudt %v not registered", h)
+}
+
+// FormatAmount formats an amount of the asset: CKBytes given in Shannon, and
+// SUDT amounts given in base units with the registered metadata.
+func (p *PaymentClient) FormatAmount(a gpchannel.Asset, amount *big.Int) string {
+	ckbAsset, ok := a.(*asset.Asset)
+	switch {
+	case !ok:
+		return amount.String()
+	case ckbAsset.IsCKBytes:
+		return ShannonToCKByte(amount).Text('f', 2) + " CKByte"
+	}
+	meta, ok := p.sudtMeta(ckbAsset.SUDT.TypeScript.Hash())
+	if !ok {
+		meta = SUDTMeta{Symbol: "SUDT"}
+	}
+	return meta.FormatAmount(amount)
+}
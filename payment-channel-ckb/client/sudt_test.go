@@ -0,0 +1,67 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"perun.network/perun-examples/payment-channel-ckb/client"
+)
+
+func TestSUDTAmount(t *testing.T) {
+	// The amount is a little-endian uint128; the data may hold more bytes.
+	data := make([]byte, 20)
+	data[0], data[8], data[15] = 1, 2, 0x80
+	amount, err := client.DecodeSUDTAmount(data)
+	require.NoError(t, err)
+	expected := new(big.Int).Lsh(big.NewInt(0x80), 120)
+	expected.Add(expected, new(big.Int).Lsh(big.NewInt(2), 64))
+	expected.Add(expected, big.NewInt(1))
+	require.Equal(t, expected, amount)
+
+	enc, err := client.EncodeSUDTAmount(client.MaxSUDTAmount)
+	require.NoError(t, err)
+	dec, err := client.DecodeSUDTAmount(enc)
+	require.NoError(t, err)
+	require.Equal(t, client.MaxSUDTAmount, dec)
+
+	_, err = client.DecodeSUDTAmount(make([]byte, 8))
+	require.ErrorContains(t, err, "sudt cell data too short")
+	_, err = client.EncodeSUDTAmount(new(big.Int).Add(client.MaxSUDTAmount, big.NewInt(1)))
+	require.ErrorContains(t, err, "out of range")
+}
+
+func TestSUDTMeta(t *testing.T) {
+	m := client.SUDTMeta{Symbol: "TKN", Decimals: 8}
+	amount, err := m.ParseAmount("1.5")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(150_000_000), amount)
+	require.Equal(t, "1.50000000 TKN", m.FormatAmount(amount))
+	require.Equal(t, "0.00000042 TKN", m.FormatAmount(big.NewInt(42)))
+
+	// Amounts beyond 64 bits are exact.
+	amount, err = m.ParseAmount("3402823669209384634633746074317.68211455")
+	require.NoError(t, err)
+	require.Equal(t, client.MaxSUDTAmount, amount)
+	require.Equal(t, "3402823669209384634633746074317.68211455 TKN", m.FormatAmount(amount))
+
+	for _, s := range []string{"", ".5", "-1", "1.000000001", "1e3", "3402823669209384634633746074317.68211456"} {
+		_, err := m.ParseAmount(s)
+		require.Error(t, err, s)
+	}
+	require.Equal(t, "7 NOD", client.SUDTMeta{Symbol: "NOD"}.FormatAmount(big.NewInt(7)))
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	gpchannel "perun.network/go-perun/channel"
+	"perun.network/go-perun/client"
+	gpwallet "perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+)
+
+// ParentRef refers to the ledger channel between a participant of a virtual
+// channel and the hub, and to the index of the participant in it.
+type ParentRef struct {
+	ID  gpchannel.ID
+	Idx gpchannel.Index
+}
+
+// OpenVirtualChannel opens a virtual channel with the specified peer with the
+// given funding, in Shannon for CKBytes and in base units for SUDTs. The
+// virtual channel is funded
+// from our ledger channel parent with the hub and from the ledger channel of
+// the peer with the same hub, referenced by peerParent. The hub locks the
+// funds in both ledger channels, so no on-chain transaction is needed.
+func (p *PaymentClient) OpenVirtualChannel(
+	peer map[gpwallet.BackendID]wire.Address,
+	peerID string,
+	parent *PaymentChannel,
+	peerParent ParentRef,
+	funding map[gpchannel.Asset]Funding,
+) *PaymentChannel {
+	// The proposer has index 0 in the virtual channel, the peer index 1.
+	participants := []map[gpwallet.BackendID]wire.Address{p.WireAddress(), peer}
+	p.dialer.Register(peer, peerID)
+
+	initAlloc, assets := newInitAlloc(funding)
+
+	// The index maps map the participants of the virtual channel to the
+	// participants of the parent channels. In each parent channel, the hub
+	// stands in for the participant of the other parent channel.
+	ourParent := parent.ParentRef()
+	indexMaps := [][]gpchannel.Index{
+		{ourParent.Idx, 1 - ourParent.Idx},
+		{1 - peerParent.Idx, peerParent.Idx},
+	}
+
+	challengeDuration := uint64(defaultChallengeDuration) // On-chain challenge duration in seconds.
+	proposal, err := client.NewVirtualChannelProposal(
+		challengeDuration,
+		p.WalletAddress(),
+		initAlloc,
+		participants,
+		[]gpchannel.ID{ourParent.ID, peerParent.ID},
+		indexMaps,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Send the proposal. The client funds the virtual channel by proposing an
+	// update of the parent channel to the hub.
+	ch, err := p.PerunClient.ProposeChannel(context.TODO(), proposal)
+	if err != nil {
+		panic(err)
+	}
+
+	// The watcher of a virtual channel disputes the parent channel if needed.
+	p.startWatching(ch)
+
+	return newPaymentChannel(ch, assets)
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/crypto/blake2b"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"github.com/perun-network/perun-libp2p-wire/p2p"
+	"github.com/stretchr/testify/require"
+	gpchannel "perun.network/go-perun/channel"
+	gpwallet "perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+	"perun.network/perun-ckb-backend/backend"
+	"perun.network/perun-ckb-backend/channel/asset"
+	"perun.network/perun-ckb-backend/wallet"
+	"perun.network/perun-ckb-backend/wallet/address"
+	"perun.network/perun-examples/payment-channel-ckb/client"
+	"perun.network/perun-examples/payment-channel-ckb/deployment"
+	"perun.network/perun-examples/payment-channel-ckb/rpcmock"
+)
+
+const (
+	ckbyte         = 100_000_000 // Shannon per CKByte.
+	initialBalance = 1000        // The CKBytes of each account.
+)
+
+func TestVirtualChannel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	node := rpcmock.New()
+	node.SetAutoMine(true)
+	bus := wire.NewLocalBus()
+
+	keys := make([]*secp256k1.PrivateKey, 3)
+	for i := range keys {
+		var err error
+		keys[i], err = secp256k1.GeneratePrivateKey()
+		require.NoError(t, err)
+	}
+	d := newTestDeployment(node, defaultLock(keys[0]))
+	alice := newTestClient(t, "Alice", node, d, keys[0], bus, rng)
+	bob := newTestClient(t, "Bob", node, d, keys[1], bus, rng)
+	ingrid := client.NewHub(newTestClient(t, "Ingrid", node, d, keys[2], bus, rng))
+	ckb := &asset.Asset{IsCKBytes: true}
+
+	// Alice and Bob open a ledger channel with the hub each.
+	hubFunding := client.SymmetricFunding(map[gpchannel.Asset]*big.Int{ckb: big.NewInt(100 * ckbyte)})
+	chAliceHub := alice.OpenChannel(ingrid.WireAddress(), ingrid.PeerID(), hubFunding)
+	chBobHub := bob.OpenChannel(ingrid.WireAddress(), ingrid.PeerID(), hubFunding)
+	var bobParent client.ParentRef
+	require.Eventually(t, func() bool {
+		var err error
+		bobParent, err = ingrid.Parent(bob.WireAddress())
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// Alice opens a virtual channel with Bob and they exchange payments.
+	vchAlice := alice.OpenVirtualChannel(bob.WireAddress(), bob.PeerID(), chAliceHub, bobParent,
+		client.SymmetricFunding(map[gpchannel.Asset]*big.Int{ckb: big.NewInt(20 * ckbyte)}))
+	vchBob := bob.AcceptedChannel()
+	vchAlice.SendPayment(map[gpchannel.Asset]*big.Int{ckb: big.NewInt(10 * ckbyte)})
+	vchBob.SendPayment(map[gpchannel.Asset]*big.Int{ckb: big.NewInt(5 * ckbyte)})
+	requireBalances(t, vchBob.State(), ckb, 15, 25)
+
+	// The hub settles the virtual channel into the ledger channels.
+	done := make(chan struct{})
+	go func() {
+		vchAlice.Settle()
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return vchBob.State().IsFinal }, 10*time.Second, 100*time.Millisecond)
+	vchBob.Settle()
+	<-done
+	requireBalances(t, chAliceHub.State(), ckb, 95, 105)
+	requireBalances(t, chBobHub.State(), ckb, 105, 95)
+
+	// Settling the ledger channels pays out the balances on-chain.
+	chAliceHub.Settle()
+	chBobHub.Settle()
+	requireOnChainBalance(t, alice, initialBalance-5)
+	requireOnChainBalance(t, bob, initialBalance+5)
+}
+
+// requireBalances requires the CKByte balances of the participants of the
+// channel state.
+func requireBalances(t *testing.T, s *gpchannel.State, ckb gpchannel.Asset, bals ...int64) {
+	t.Helper()
+	for i, bal := range bals {
+		require.Zero(t, s.Allocation.Balance(gpchannel.Index(i), ckb).Cmp(big.NewInt(bal*ckbyte)), "balance of participant %d", i)
+	}
+}
+
+// requireOnChainBalance requires that the client holds the given CKBytes
+// less the transaction fees, which are below a CKByte.
+func requireOnChainBalance(t *testing.T, c *client.PaymentClient, ckbytes int64) {
+	t.Helper()
+	c.PollBalances()
+	bal := c.Balances().CKBytes.Int64()
+	require.LessOrEqual(t, bal, ckbytes*ckbyte)
+	require.Greater(t, bal, (ckbytes-1)*ckbyte)
+}
+
+// defaultLock returns the secp256k1_blake160_sighash_all lock of the key.
+func defaultLock(key *secp256k1.PrivateKey) *types.Script {
+	return address.AsParticipant(wallet.NewAccountFromPrivateKey(key).Address()).PaymentScript
+}
+
+// newTestDeployment deploys the channel scripts on the node. The node does
+// not execute scripts, so their code is arbitrary.
+func newTestDeployment(node *rpcmock.Client, defaultLock *types.Script) backend.Deployment {
+	owner := &types.Script{CodeHash: types.Hash{}, HashType: types.HashTypeData, Args: []byte{}}
+	deploy := func(name string) (types.CellDep, types.Hash) {
+		code := []byte(name)
+		op := node.AddCell(types.CellOutput{Capacity: initialBalance * ckbyte, Lock: owner}, code)
+		return types.CellDep{OutPoint: &op, DepType: types.DepTypeCode}, types.BytesToHash(blake2b.Blake256(code))
+	}
+	d := backend.Deployment{
+		Network: types.NetworkTest,
+		DefaultLockScript: types.Script{
+			CodeHash: defaultLock.CodeHash,
+			HashType: defaultLock.HashType,
+			Args:     make([]byte, 32),
+		},
+		SUDTDeps: map[types.Hash]types.CellDep{},
+		SUDTs:    map[types.Hash]types.Script{},
+	}
+	d.DefaultLockScriptDep, _ = deploy("secp256k1_blake160_sighash_all")
+	d.PCTSDep, d.PCTSCodeHash = deploy("pcts")
+	d.PCLSDep, d.PCLSCodeHash = deploy("pcls")
+	d.PFLSDep, d.PFLSCodeHash = deploy("pfls")
+	d.VCTSDep, d.VCTSCodeHash = deploy("vcts")
+	d.VCLSDep, d.VCLSCodeHash = deploy("vcls")
+	d.PCTSHashType, d.PCLSHashType, d.PFLSHashType = types.HashTypeData1, types.HashTypeData1, types.HashTypeData1
+	d.VCTSHashType, d.VCLSHashType = types.HashTypeData1, types.HashTypeData1
+	d.PFLSMinCapacity = deployment.FundingCellCapacity(d)
+	return d
+}
+
+// newTestClient returns a client of the key that communicates over the bus
+// and holds the initial balance on the node.
+func newTestClient(t *testing.T, name string, node *rpcmock.Client, d backend.Deployment, key *secp256k1.PrivateKey, bus wire.Bus, rng *rand.Rand) *client.PaymentClient {
+	t.Helper()
+	acc := wallet.NewAccountFromPrivateKey(key)
+	w := wallet.NewEphemeralWallet()
+	require.NoError(t, w.AddAccount(acc))
+	node.AddCell(types.CellOutput{Capacity: initialBalance * ckbyte, Lock: defaultLock(key)}, nil)
+
+	wireAcc := p2p.NewRandomAccount(rng)
+	c, err := client.NewPaymentClientWithRPC(name, d.Network, d, node, acc, client.LockConfig{Type: client.LockDefault}, *key, w, wireAcc.Address(), bus, localDialer{})
+	require.NoError(t, err)
+	t.Cleanup(c.Shutdown)
+	return c
+}
+
+// localDialer is the dialer of clients on a local bus, which needs no peer
+// IDs.
+type localDialer struct{}
+
+func (localDialer) Register(map[gpwallet.BackendID]wire.Address, string) {}
@@ -0,0 +1,249 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command account manages the CKB accounts of the examples: it generates and
+// imports keys, prints the addresses of an account and its balances, and
+// writes the account files the examples read.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"os"
+	"path"
+	"time"
+
+	"github.com/nervosnetwork/ckb-sdk-go/v2/indexer"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/rpc"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"perun.network/perun-examples/payment-channel-ckb/account"
+	"perun.network/perun-examples/payment-channel-ckb/client"
+	"perun.network/perun-examples/payment-channel-ckb/deployment"
+)
+
+const (
+	queryTimeout = 10 * time.Second
+	// Metadata of the SUDT issued on the devnet.
+	sudtSymbol   = "SUDT"
+	sudtDecimals = 8
+)
+
+var (
+	dir          = flag.String("dir", "./devnet/accounts", "accounts directory")
+	network      = flag.String("network", string(deployment.Devnet), "network: devnet, testnet or mainnet")
+	rpcURL       = flag.String("rpc", "http://localhost:8114", "RPC URL of the CKB node")
+	omnilockHash = flag.String("omnilock", "", "code hash of the omnilock, defaults to the omnilock of the testnet or mainnet")
+	force        = flag.Bool("force", false, "overwrite existing accounts")
+)
+
+const usage = `Usage: account [flags] <command> [args]
+
+Commands:
+  new <name>             generate an account
+  import <name> <key>    import a hex encoded key or a key file
+  list                   list the accounts with their addresses
+  show <name>            show the addresses of an account
+  balance <name>         show the capacity and SUDT balance of an account
+  sudt-owner <name>      make the account the owner of the devnet SUDT
+
+Flags:
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), flag.Args()[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(cmd string, args []string) error {
+	n, err := deployment.ParseNetwork(*network)
+	if err != nil {
+		return err
+	}
+	if cmd == "list" {
+		return list(n)
+	}
+
+	wantArgs := map[string]int{"new": 1, "import": 2, "show": 1, "balance": 1, "sudt-owner": 1}
+	want, ok := wantArgs[cmd]
+	if !ok {
+		return fmt.Errorf("unknown command %q, run with -h for help", cmd)
+	}
+	if len(args) != want {
+		return fmt.Errorf("%s expects %d arguments, run with -h for help", cmd, want)
+	}
+
+	var acc *account.Account
+	switch cmd {
+	case "new":
+		acc, err = account.Generate(args[0])
+	case "import":
+		acc, err = account.Import(args[0], args[1])
+	default:
+		acc, err = account.Load(*dir, args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "new", "import":
+		if err := acc.SaveKey(*dir, *force); err != nil {
+			return err
+		}
+		if err := acc.SaveInfo(*dir); err != nil {
+			return err
+		}
+		return show(acc, n)
+	case "show":
+		return show(acc, n)
+	case "balance":
+		return balance(acc, n)
+	default: // sudt-owner
+		if err := acc.SaveSUDTOwner(*dir); err != nil {
+			return err
+		}
+		fmt.Printf("%s owns the devnet SUDT, redeploy the contracts to issue it\n", acc.Name)
+		return nil
+	}
+}
+
+// list prints the accounts with the address of their default lock.
+func list(n deployment.Network) error {
+	names, err := account.List(*dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		acc, err := account.Load(*dir, name)
+		if err != nil {
+			return err
+		}
+		addr, err := acc.Address(n.AddressNetwork())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-12s %s\n", name, addr)
+	}
+	return nil
+}
+
+// show prints the addresses of the account on the network.
+func show(acc *account.Account, n deployment.Network) error {
+	addr, err := acc.Address(n.AddressNetwork())
+	if err != nil {
+		return err
+	}
+	lock := acc.LockScript()
+	fmt.Printf("account:      %s\n", acc.Name)
+	fmt.Printf("network:      %s\n", n)
+	fmt.Printf("address:      %s\n", addr)
+	fmt.Printf("lock_arg:     0x%x\n", lock.Args)
+	fmt.Printf("lock_hash:    %s\n", lock.Hash())
+	fmt.Printf("eth_address:  %s\n", acc.EthAddress())
+
+	var codeHash types.Hash
+	if *omnilockHash != "" {
+		codeHash = types.HexToHash(*omnilockHash)
+	} else if codeHash, err = account.OmnilockCodeHash(n); err != nil {
+		fmt.Printf("omnilock:     %v\n", err)
+		return nil
+	}
+	for _, auth := range []struct {
+		name string
+		flag account.AuthFlag
+	}{{"secp256k1", account.AuthSecp256k1}, {"ethereum", account.AuthEthereum}} {
+		addr, err := acc.OmnilockAddress(codeHash, auth.flag, n.AddressNetwork())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("omnilock (%s): %s\n", auth.name, addr)
+	}
+	return nil
+}
+
+// balance prints the capacity of the account and its balance of the SUDT of
+// the deployment, if it is deployed.
+func balance(acc *account.Account, n deployment.Network) error {
+	rpcClient, err := rpc.Dial(*rpcURL)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", *rpcURL, err)
+	}
+	defer rpcClient.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	searchKey := &indexer.SearchKey{
+		Script:           acc.LockScript(),
+		ScriptType:       types.ScriptTypeLock,
+		ScriptSearchMode: types.ScriptSearchModeExact,
+		WithData:         true,
+	}
+	cells, err := rpcClient.GetCells(ctx, searchKey, indexer.SearchOrderDesc, math.MaxUint32, "")
+	if err != nil {
+		return fmt.Errorf("querying cells: %w", err)
+	}
+
+	sudt := sudtScript(n)
+	capacity, sudtBal := new(big.Int), new(big.Int)
+	for _, cell := range cells.Objects {
+		capacity.Add(capacity, new(big.Int).SetUint64(cell.Output.Capacity))
+		if sudt == nil || cell.Output.Type == nil || cell.Output.Type.Hash() != sudt.Hash() {
+			continue
+		}
+		if amount, err := client.DecodeSUDTAmount(cell.OutputData); err == nil {
+			sudtBal.Add(sudtBal, amount)
+		}
+	}
+
+	fmt.Printf("%s: %s CKByte in %d cells\n", acc.Name, client.ShannonToCKByte(capacity).Text('f', 8), len(cells.Objects))
+	if sudt != nil {
+		fmt.Printf("%s: %s\n", acc.Name, client.SUDTMeta{Symbol: sudtSymbol, Decimals: sudtDecimals}.FormatAmount(sudtBal))
+	}
+	return nil
+}
+
+// sudtScript returns the type script of the SUDT of the deployment, or nil
+// if the deployment cannot be loaded.
+func sudtScript(n deployment.Network) *types.Script {
+	owner, err := os.ReadFile(path.Join(*dir, account.SUDTOwnerFile))
+	if err != nil {
+		return nil
+	}
+	_, sudtInfo, err := deployment.Load(deployment.Config{
+		Network:          n,
+		MigrationsDir:    "./devnet/contracts/migrations",
+		VCMigrationsDir:  "./devnet/contracts/migrations_vc",
+		SystemScriptsDir: "./devnet/system_scripts",
+		SUDTOwnerLockArg: string(owner),
+	})
+	if err != nil {
+		log.Printf("SUDT balance unavailable: %v", err)
+		return nil
+	}
+	return sudtInfo.Script
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import "strings"
+
+// ansiColors maps the color tags of the client's formatted balances, e.g.,
+// "[green]", to ANSI escape sequences.
+var ansiColors = map[string]string{
+	"[-]":      "\x1b[0m",
+	"[white]":  "\x1b[0m",
+	"[red]":    "\x1b[31m",
+	"[green]":  "\x1b[32m",
+	"[yellow]": "\x1b[33m",
+	"[blue]":   "\x1b[34m",
+	"[gray]":   "\x1b[90m",
+	"[::b]":    "\x1b[1m",
+}
+
+// renderColors replaces the color tags in s by ANSI escape sequences.
+// Brackets that are not a known tag are kept.
+func renderColors(s string) string {
+	var b strings.Builder
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '[')
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+		j := strings.IndexByte(s, ']')
+		if j < 0 {
+			b.WriteString(s)
+			break
+		}
+		if code, ok := ansiColors[s[:j+1]]; ok {
+			b.WriteString(code)
+		} else {
+			b.WriteString(s[:j+1])
+		}
+		s = s[j+1:]
+	}
+	return b.String()
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	gpchannel "perun.network/go-perun/channel"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+	"perun.network/perun-examples/payment-channel-ckb/client"
+)
+
+// commandSpec describes a command and its arguments.
+type commandSpec struct {
+	name    string
+	args    string
+	minArgs int
+	maxArgs int
+}
+
+var commandSpecs = []commandSpec{
+	{"use", "<account>", 1, 1},
+	{"connect", "<name> <peer-id>", 2, 2},
+	{"open", "<peer> <asset> <own> [<peer-deposit>]", 3, 4},
+	{"pay", "<channel> <asset> <amount>", 3, 3},
+	{"settle", "<channel>", 1, 1},
+	{"refresh", "", 0, 0},
+	{"quit", "", 0, 0},
+}
+
+// usage returns the usage of all commands.
+func usage() string {
+	usages := make([]string, len(commandSpecs))
+	for i, c := range commandSpecs {
+		usages[i] = strings.TrimSpace(c.name + " " + c.args)
+	}
+	return "Commands: " + strings.Join(usages, " | ")
+}
+
+// command is a parsed command.
+type command struct {
+	name string
+	args []string
+}
+
+// parseCommand parses a command line. An empty line refreshes the dashboard.
+func parseCommand(line string) (command, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return command{name: "refresh"}, nil
+	}
+	name, args := strings.ToLower(fields[0]), fields[1:]
+	if name == "exit" {
+		name = "quit"
+	}
+	for _, c := range commandSpecs {
+		if c.name != name {
+			continue
+		}
+		if len(args) < c.minArgs || len(args) > c.maxArgs {
+			return command{}, fmt.Errorf("usage: %s %s", c.name, c.args)
+		}
+		return command{name: name, args: args}, nil
+	}
+	return command{}, fmt.Errorf("unknown command %q", fields[0])
+}
+
+// exec executes the command. Commands that wait for the peer or the chain
+// run in the background and log their result.
+func (d *Dashboard) exec(cmd command) {
+	var err error
+	switch cmd.name {
+	case "refresh":
+		d.redraw()
+	case "use":
+		err = d.use(cmd.args[0])
+	case "connect":
+		err = d.connect(cmd.args[0], cmd.args[1])
+	case "open":
+		err = d.open(cmd.args)
+	case "pay":
+		err = d.pay(cmd.args)
+	case "settle":
+		err = d.settle(cmd.args[0])
+	}
+	if err != nil {
+		d.logf("[red]%v[white]", err)
+	}
+}
+
+// use selects the account that opens channels.
+func (d *Dashboard) use(name string) error {
+	for i, acc := range d.accounts {
+		if strings.EqualFold(acc.Name, name) {
+			d.mtx.Lock()
+			d.active = i
+			d.mtx.Unlock()
+			d.redraw()
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown account %q", name)
+}
+
+// connect adds the remote peer with the given libp2p peer ID, which another
+// dashboard shows for its accounts.
+func (d *Dashboard) connect(name, id string) error {
+	addr, err := client.PeerWireAddress(id)
+	if err != nil {
+		return err
+	}
+	if _, _, err := d.lookupPeer(name); err == nil {
+		return fmt.Errorf("peer %q already exists", name)
+	}
+	d.mtx.Lock()
+	d.peers = append(d.peers, peer{name: name, id: id, addr: addr})
+	d.mtx.Unlock()
+	d.logf("Added peer %s", name)
+	return nil
+}
+
+// lookupPeer returns the address and the peer ID of the local account or
+// remote peer with the given name.
+func (d *Dashboard) lookupPeer(name string) (map[wallet.BackendID]wire.Address, string, error) {
+	for _, acc := range d.accounts {
+		if strings.EqualFold(acc.Name, name) {
+			return acc.Client.WireAddress(), acc.Client.PeerID(), nil
+		}
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for _, p := range d.peers {
+		if strings.EqualFold(p.name, name) {
+			return p.addr, p.id, nil
+		}
+	}
+	return nil, "", fmt.Errorf("unknown peer %q, add it with connect", name)
+}
+
+// open opens a channel of the active account with a peer.
+func (d *Dashboard) open(args []string) error {
+	d.mtx.Lock()
+	acc := d.accounts[d.active]
+	d.mtx.Unlock()
+	if strings.EqualFold(acc.Name, args[0]) {
+		return fmt.Errorf("cannot open a channel with yourself")
+	}
+	addr, id, err := d.lookupPeer(args[0])
+	if err != nil {
+		return err
+	}
+	a, err := d.asset(args[1])
+	if err != nil {
+		return err
+	}
+	own, err := a.Meta.ParseAmount(args[2])
+	if err != nil {
+		return err
+	}
+	peerDeposit := big.NewInt(0)
+	if len(args) == 4 {
+		if peerDeposit, err = a.Meta.ParseAmount(args[3]); err != nil {
+			return err
+		}
+	}
+
+	name := d.peerName(addr)
+	d.async(fmt.Sprintf("%s opening channel with %s", acc.Name, name), func() {
+		ch := acc.Client.OpenChannel(addr, id, map[gpchannel.Asset]client.Funding{
+			a.Asset: {Own: own, Peer: peerDeposit},
+		})
+		d.addChannel(acc, ch)
+	})
+	return nil
+}
+
+// pay sends a payment in a channel to the peer.
+func (d *Dashboard) pay(args []string) error {
+	e, err := d.channel(args[0])
+	if err != nil {
+		return err
+	}
+	a, err := d.asset(args[1])
+	if err != nil {
+		return err
+	}
+	amount, err := a.Meta.ParseAmount(args[2])
+	if err != nil {
+		return err
+	}
+	d.async(fmt.Sprintf("%s paying %s to %s", e.owner.Name, args[2]+" "+a.Meta.Symbol, e.peer), func() {
+		e.ch.SendPayment(map[gpchannel.Asset]*big.Int{a.Asset: amount})
+	})
+	return nil
+}
+
+// settle settles a channel.
+func (d *Dashboard) settle(n string) error {
+	e, err := d.channel(n)
+	if err != nil {
+		return err
+	}
+	d.async(fmt.Sprintf("%s settling channel #%s", e.owner.Name, n), func() {
+		e.ch.Settle()
+		d.mtx.Lock()
+		e.settled = true
+		d.mtx.Unlock()
+	})
+	return nil
+}
+
+// channel returns the open channel with the given number.
+func (d *Dashboard) channel(n string) (*channelEntry, error) {
+	i, err := strconv.Atoi(strings.TrimPrefix(n, "#"))
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if err != nil || i < 1 || i > len(d.channels) {
+		return nil, fmt.Errorf("unknown channel %q", n)
+	}
+	e := d.channels[i-1]
+	if e.settled {
+		return nil, fmt.Errorf("channel #%d is settled", i)
+	}
+	return e, nil
+}
+
+// asset returns the asset with the given name.
+func (d *Dashboard) asset(name string) (Asset, error) {
+	names := make([]string, len(d.assets))
+	for i, a := range d.assets {
+		if strings.EqualFold(a.Name, name) {
+			return a, nil
+		}
+		names[i] = a.Name
+	}
+	return Asset{}, fmt.Errorf("unknown asset %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// async runs f in the background and logs its result. The client reports
+// errors by panicking, which async recovers from.
+func (d *Dashboard) async(desc string, f func()) {
+	d.logf("%s...", desc)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				d.logf("[red]%s failed: %v[white]", desc, r)
+			}
+		}()
+		f()
+		d.logf("[green]%s: done[white]", desc)
+	}()
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommand(t *testing.T) {
+	cmd, err := parseCommand("  open Bob ckb 100 20 ")
+	require.NoError(t, err)
+	require.Equal(t, command{name: "open", args: []string{"Bob", "ckb", "100", "20"}}, cmd)
+
+	cmd, err = parseCommand("")
+	require.NoError(t, err)
+	require.Equal(t, "refresh", cmd.name)
+
+	cmd, err = parseCommand("EXIT")
+	require.NoError(t, err)
+	require.Equal(t, "quit", cmd.name)
+
+	_, err = parseCommand("pay 1 ckb")
+	require.ErrorContains(t, err, "usage: pay")
+	_, err = parseCommand("withdraw")
+	require.ErrorContains(t, err, "unknown command")
+}
+
+func TestRenderColors(t *testing.T) {
+	require.Equal(t, "\x1b[32m1.00 CKByte\t\x1b[33m2 SUDT\x1b[0m [x]",
+		renderColors("[green]1.00 CKByte\t[yellow]2 SUDT[white] [x]"))
+	require.Equal(t, "open [", renderColors("open ["))
+}
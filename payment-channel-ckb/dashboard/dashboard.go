@@ -0,0 +1,246 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dashboard implements a terminal dashboard for the payment client.
+// It lists the local accounts with their balances and the open channels, and
+// executes commands to open channels, send payments and settle.
+package dashboard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"perun.network/go-perun/channel"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
+	"perun.network/perun-ckb-backend/channel/asset"
+	"perun.network/perun-examples/payment-channel-ckb/client"
+)
+
+// maxLogLines is the number of log lines shown in the dashboard.
+const maxLogLines = 8
+
+// Account is a local account shown in the dashboard.
+type Account struct {
+	Name   string
+	Client *client.PaymentClient
+}
+
+// Asset is an asset that is referenced by its name in commands.
+type Asset struct {
+	Name  string
+	Asset *asset.Asset
+	Meta  client.SUDTMeta // Used to parse the amounts of commands.
+}
+
+// CKBytes returns the CKByte asset, named "ckb", with amounts in CKByte.
+func CKBytes(a *asset.Asset) Asset {
+	return Asset{Name: "ckb", Asset: a, Meta: client.SUDTMeta{Symbol: "CKByte", Decimals: 8}}
+}
+
+// SUDT returns the SUDT asset, named by its symbol in lower case.
+func SUDT(a *asset.Asset, meta client.SUDTMeta) Asset {
+	return Asset{Name: strings.ToLower(meta.Symbol), Asset: a, Meta: meta}
+}
+
+// peer is a remote peer, e.g., the account of another dashboard.
+type peer struct {
+	name string
+	id   string
+	addr map[wallet.BackendID]wire.Address
+}
+
+// channelEntry is a channel of a local account.
+type channelEntry struct {
+	owner   *Account
+	ch      *client.PaymentChannel
+	peer    string
+	settled bool
+}
+
+// Dashboard is a terminal dashboard of local accounts.
+type Dashboard struct {
+	out      io.Writer
+	accounts []*Account
+	assets   []Asset
+
+	mtx      sync.Mutex
+	active   int // Index of the account that opens channels.
+	peers    []peer
+	channels []*channelEntry
+	log      []string
+}
+
+// New returns a dashboard of the accounts that writes to out. The commands
+// accept the given assets.
+func New(out io.Writer, accounts []Account, assets []Asset) *Dashboard {
+	d := &Dashboard{out: out, assets: assets}
+	for i := range accounts {
+		d.accounts = append(d.accounts, &accounts[i])
+	}
+	return d
+}
+
+// Run shows the dashboard and executes the commands read from in until the
+// quit command or the end of the input. The balances of the accounts are
+// updated every interval.
+func (d *Dashboard) Run(in io.Reader, interval time.Duration) error {
+	for _, acc := range d.accounts {
+		acc.Client.OnBalanceChange(func(client.Balances) { d.redraw() })
+		if err := acc.Client.WatchBalances(interval, ""); err != nil {
+			return fmt.Errorf("watching balances of %s: %w", acc.Name, err)
+		}
+		defer acc.Client.StopWatchingBalances()
+		go d.acceptChannels(acc)
+	}
+	d.redraw()
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		cmd, err := parseCommand(scanner.Text())
+		if err != nil {
+			d.logf("[red]%v[white]", err)
+			continue
+		}
+		if cmd.name == "quit" {
+			return nil
+		}
+		d.exec(cmd)
+	}
+	return scanner.Err()
+}
+
+// acceptChannels adds the channels accepted by the account. The Perun
+// client accepts proposals according to the acceptance policy of the
+// account.
+func (d *Dashboard) acceptChannels(acc *Account) {
+	for {
+		ch := acc.Client.AcceptedChannel()
+		n := d.addChannel(acc, ch)
+		d.logf("%s accepted channel #%d", acc.Name, n)
+	}
+}
+
+// addChannel adds the channel of the account and returns its number.
+func (d *Dashboard) addChannel(acc *Account, ch *client.PaymentChannel) int {
+	name := d.peerName(ch.Peer())
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.channels = append(d.channels, &channelEntry{owner: acc, ch: ch, peer: name})
+	return len(d.channels)
+}
+
+// peerName returns the name of the local account or remote peer with the
+// given address.
+func (d *Dashboard) peerName(addr map[wallet.BackendID]wire.Address) string {
+	key := wire.Keys(addr)
+	for _, acc := range d.accounts {
+		if wire.Keys(acc.Client.WireAddress()) == key {
+			return acc.Name
+		}
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for _, p := range d.peers {
+		if wire.Keys(p.addr) == key {
+			return p.name
+		}
+	}
+	return "unknown peer"
+}
+
+// logf adds a line to the log and redraws the dashboard.
+func (d *Dashboard) logf(format string, args ...interface{}) {
+	d.mtx.Lock()
+	d.log = append(d.log, time.Now().Format("15:04:05 ")+fmt.Sprintf(format, args...))
+	if len(d.log) > maxLogLines {
+		d.log = d.log[len(d.log)-maxLogLines:]
+	}
+	d.mtx.Unlock()
+	d.redraw()
+}
+
+// redraw clears the terminal and draws the dashboard.
+func (d *Dashboard) redraw() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J[::b]Perun CKB Payment Dashboard[-]\n\n")
+
+	b.WriteString("[::b]Accounts[-]\n")
+	for i, acc := range d.accounts {
+		marker := " "
+		if i == d.active {
+			marker = "*"
+		}
+		fmt.Fprintf(&b, " %s %-8s %s\n     %s\n", marker, acc.Name,
+			acc.Client.FormatBalances(acc.Client.Balances()), "[gray]peer id "+acc.Client.PeerID()+"[-]")
+	}
+
+	if len(d.peers) > 0 {
+		b.WriteString("\n[::b]Peers[-]\n")
+		for _, p := range d.peers {
+			fmt.Fprintf(&b, "   %-8s [gray]%s[-]\n", p.name, p.id)
+		}
+	}
+
+	b.WriteString("\n[::b]Channels[-]\n")
+	if len(d.channels) == 0 {
+		b.WriteString("   none\n")
+	}
+	for i, e := range d.channels {
+		b.WriteString(formatChannel(i+1, e))
+	}
+
+	b.WriteString("\n[::b]Log[-]\n")
+	for _, l := range d.log {
+		b.WriteString("   " + l + "\n")
+	}
+	b.WriteString("\n" + usage() + "\n> ")
+
+	fmt.Fprint(d.out, renderColors(b.String()))
+}
+
+// formatChannel formats the channel with the given number and the balances
+// of both participants.
+func formatChannel(n int, e *channelEntry) string {
+	state := e.ch.State()
+	status := fmt.Sprintf("version %d", state.Version)
+	switch {
+	case e.settled:
+		status = "[gray]settled[-]"
+	case state.IsFinal:
+		status += ", [yellow]final[-]"
+	}
+	id := state.ID
+	s := fmt.Sprintf(" #%-2d %s <-> %s  [gray]%x[-]  %s\n", n, e.owner.Name, e.peer, id[:4], status)
+
+	names := [2]string{}
+	names[e.ch.Idx()] = e.owner.Name
+	names[1-e.ch.Idx()] = e.peer
+	for _, a := range e.ch.Assets() {
+		s += "     "
+		for idx, name := range names {
+			bal := state.Allocation.Balance(channel.Index(idx), a)
+			s += fmt.Sprintf("%s: [green]%s[-]  ", name, e.owner.Client.FormatAmount(a, bal))
+		}
+		s += "\n"
+	}
+	return s
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"perun.network/perun-ckb-backend/backend"
+	"perun.network/perun-examples/shared/ckbcapacity"
+)
+
+// PFLSMinCapacity is the minimum capacity in Shannon of a funding cell. The
+// capacity that the cells of a channel occupy is computed from the scripts
+// of the deployment, and MakeDeployment raises the minimum to it if needed.
+const PFLSMinCapacity = 4100000032
+
+// PayoutLocks returns the locks of the cells that hold an asset after a
+// channel is settled. The default lock of the deployment has args of the
+// size of a script hash, which covers the payment scripts of the supported
+// lock types: the 20 bytes blake160 hash of a key or a multisig and the 22
+// bytes of an omnilock.
+func PayoutLocks(d backend.Deployment) []*types.Script {
+	return []*types.Script{&d.DefaultLockScript}
+}
+
+// FundingCellCapacity returns the minimum capacity in Shannon of a cell that
+// holds the CKBytes of a participant, both funding a channel and paid out on
+// settlement. It is at least the PFLS minimum capacity of the deployment.
+func FundingCellCapacity(d backend.Deployment) uint64 {
+	return ckbcapacity.CellCapacity(uint64(d.PFLSMinCapacity), fundingLock(d), nil, 0, PayoutLocks(d)...)
+}
+
+// SUDTCellCapacity returns the minimum capacity in Shannon of a cell that
+// holds the SUDT of a participant, both funding a channel and paid out on
+// settlement. Use it as the max capacity of the SUDT asset.
+func SUDTCellCapacity(d backend.Deployment, sudt *types.Script) uint64 {
+	return ckbcapacity.CellCapacity(0, fundingLock(d), sudt, ckbcapacity.SUDTAmountSize, PayoutLocks(d)...)
+}
+
+// fundingLock returns the lock of the funding cells of a channel.
+func fundingLock(d backend.Deployment) *types.Script {
+	return ckbcapacity.FundingLock(d.PFLSCodeHash, d.PFLSHashType)
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment_test
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"github.com/stretchr/testify/require"
+	"perun.network/perun-ckb-backend/backend"
+	"perun.network/perun-examples/payment-channel-ckb/account"
+	"perun.network/perun-examples/payment-channel-ckb/deployment"
+	"perun.network/perun-examples/shared/ckbcapacity"
+)
+
+func TestCellCapacity(t *testing.T) {
+	d := backend.Deployment{
+		PFLSHashType:      types.HashTypeData1,
+		PFLSMinCapacity:   deployment.PFLSMinCapacity,
+		DefaultLockScript: types.Script{HashType: types.HashTypeType, Args: make([]byte, 32)},
+	}
+	// 8 bytes capacity and 65 bytes funding or payout lock, which exceeds
+	// the minimum.
+	require.Equal(t, uint64(73*ckbcapacity.ShannonsPerByte), deployment.FundingCellCapacity(d))
+	// Plus 65 bytes SUDT type script and 16 bytes amount.
+	sudt := &types.Script{HashType: types.HashTypeData1, Args: make([]byte, 32)}
+	require.Equal(t, uint64(154*ckbcapacity.ShannonsPerByte), deployment.SUDTCellCapacity(d, sudt))
+}
+
+func TestPayoutLockCapacity(t *testing.T) {
+	keys := make([]*secp256k1.PrivateKey, 3)
+	pubs := make([]*secp256k1.PublicKey, len(keys))
+	for i := range keys {
+		var err error
+		keys[i], err = secp256k1.GeneratePrivateKey()
+		require.NoError(t, err)
+		pubs[i] = keys[i].PubKey()
+	}
+	multisig, err := account.MultisigScript(pubs, 2, 1)
+	require.NoError(t, err)
+	codeHash := types.HexToHash("0x01")
+
+	d := backend.Deployment{
+		PFLSHashType:      types.HashTypeData1,
+		DefaultLockScript: types.Script{HashType: types.HashTypeType, Args: make([]byte, 32)},
+	}
+	sudt := &types.Script{HashType: types.HashTypeData1, Args: make([]byte, 32)}
+	// The capacity of the deployment covers the payment scripts of all lock
+	// types, including the largest, the omnilock.
+	for name, lock := range map[string]*types.Script{
+		"default":           (&account.Account{Key: keys[0]}).LockScript(),
+		"omnilock ethereum": account.OmnilockScript(pubs[0], codeHash, account.AuthEthereum),
+		"omnilock":          account.OmnilockScript(pubs[0], codeHash, account.AuthSecp256k1),
+		"multisig":          multisig,
+	} {
+		require.LessOrEqual(t, ckbcapacity.OccupiedCapacity(lock, nil, 0), deployment.FundingCellCapacity(d), name)
+		require.LessOrEqual(t, ckbcapacity.OccupiedCapacity(lock, sudt, 16), deployment.SUDTCellCapacity(d, sudt), name)
+	}
+}
@@ -4,108 +4,107 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
-	"log"
 	"os"
 	"path"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
 	"perun.network/perun-ckb-backend/backend"
 )
 
-const PFLSMinCapacity = 4100000032
-
 type SUDTInfo struct {
 	Script  *types.Script
 	CellDep *types.CellDep
 }
 
+// CellRecipe is a cell deployed by a migration.
+type CellRecipe struct {
+	Name             string      `json:"name"`
+	TxHash           string      `json:"tx_hash"`
+	Index            uint32      `json:"index"`
+	OccupiedCapacity int64       `json:"occupied_capacity"`
+	DataHash         string      `json:"data_hash"`
+	TypeId           interface{} `json:"type_id"`
+}
+
+// CellDep returns the cell dep of the deployed code.
+func (r CellRecipe) CellDep() types.CellDep {
+	return types.CellDep{
+		OutPoint: &types.OutPoint{
+			TxHash: types.HexToHash(r.TxHash),
+			Index:  r.Index,
+		},
+		DepType: types.DepTypeCode,
+	}
+}
+
+// Migration is a migration file written by `ckb-cli deploy`.
 type Migration struct {
-	CellRecipes []struct {
-		Name             string      `json:"name"`
-		TxHash           string      `json:"tx_hash"`
-		Index            uint32      `json:"index"`
-		OccupiedCapacity int64       `json:"occupied_capacity"`
-		DataHash         string      `json:"data_hash"`
-		TypeId           interface{} `json:"type_id"`
-	} `json:"cell_recipes"`
+	CellRecipes     []CellRecipe  `json:"cell_recipes"`
 	DepGroupRecipes []interface{} `json:"dep_group_recipes"`
 }
 
-func (m Migration) MakeDeployment(systemScripts SystemScripts, sudtOwnerLockArg string, vcm Migration) (backend.Deployment, SUDTInfo, error) {
+// Recipe returns the cell recipe with the given name.
+func (m Migration) Recipe(name string) (CellRecipe, error) {
+	names := make([]string, len(m.CellRecipes))
+	for i, r := range m.CellRecipes {
+		if r.Name == name {
+			return r, nil
+		}
+		names[i] = r.Name
+	}
+	return CellRecipe{}, fmt.Errorf("cell recipe %q not found, the migration contains [%s]", name, strings.Join(names, ", "))
+}
+
+// recipes returns the cell recipes with the given names.
+func (m Migration) recipes(names ...string) ([]CellRecipe, error) {
+	rs := make([]CellRecipe, len(names))
+	for i, name := range names {
+		r, err := m.Recipe(name)
+		if err != nil {
+			return nil, err
+		}
+		rs[i] = r
+	}
+	return rs, nil
+}
+
+// MakeDeployment creates the deployment of the channel scripts of m and the
+// virtual channel scripts of vcm on the given network.
+func (m Migration) MakeDeployment(systemScripts SystemScripts, sudtOwnerLockArg string, vcm Migration, network types.Network) (backend.Deployment, SUDTInfo, error) {
 	sudtInfo, err := m.GetSUDT()
 	if err != nil {
 		return backend.Deployment{}, SUDTInfo{}, err
 	}
-	pcts := m.CellRecipes[1]
-	if pcts.Name != "pcts" {
-		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("second cell recipe must be pcts")
-	}
-	pcls := m.CellRecipes[2]
-	if pcls.Name != "pcls" {
-		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("third cell recipe must be pcls")
-	}
-	pfls := m.CellRecipes[3]
-	if pfls.Name != "pfls" {
-		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("fourth cell recipe must be pfls")
+	rs, err := m.recipes("pcts", "pcls", "pfls")
+	if err != nil {
+		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("channel migration: %w", err)
 	}
+	pcts, pcls, pfls := rs[0], rs[1], rs[2]
 
 	// Virtual channel scripts.
-	vcts := vcm.CellRecipes[0]
-	if vcts.Name != "vcts" {
-		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("fifth cell recipe must be vcts")
-	}
-	vcls := vcm.CellRecipes[1]
-	if vcls.Name != "vcls" {
-		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("sixth cell recipe must be vcls")
+	rs, err = vcm.recipes("vcts", "vcls")
+	if err != nil {
+		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("virtual channel migration: %w", err)
 	}
+	vcts, vcls := rs[0], rs[1]
+
 	// NOTE: The SUDT lock-arg always contains a newline character at the end.
-	hexString := strings.ReplaceAll(sudtOwnerLockArg[2:], "\n", "")
-	hexString = strings.ReplaceAll(hexString, "\r", "")
-	hexString = strings.ReplaceAll(hexString, " ", "")
+	hexString := strings.TrimPrefix(strings.TrimSpace(sudtOwnerLockArg), "0x")
 	sudtInfo.Script.Args, err = hex.DecodeString(hexString)
 	if err != nil {
 		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("invalid sudt owner lock arg: %v", err)
 	}
 
-	return backend.Deployment{
-		Network: types.NetworkTest,
-		PCTSDep: types.CellDep{
-			OutPoint: &types.OutPoint{
-				TxHash: types.HexToHash(pcts.TxHash),
-				Index:  pcts.Index,
-			},
-			DepType: types.DepTypeCode,
-		},
-		PCLSDep: types.CellDep{
-			OutPoint: &types.OutPoint{
-				TxHash: types.HexToHash(pcls.TxHash),
-				Index:  pcls.Index,
-			},
-			DepType: types.DepTypeCode,
-		},
-		VCTSDep: types.CellDep{
-			OutPoint: &types.OutPoint{
-				TxHash: types.HexToHash(vcts.TxHash),
-				Index:  vcts.Index,
-			},
-			DepType: types.DepTypeCode,
-		},
-		VCLSDep: types.CellDep{
-			OutPoint: &types.OutPoint{
-				TxHash: types.HexToHash(vcls.TxHash),
-				Index:  vcls.Index,
-			},
-			DepType: types.DepTypeCode,
-		},
-		PFLSDep: types.CellDep{
-			OutPoint: &types.OutPoint{
-				TxHash: types.HexToHash(pfls.TxHash),
-				Index:  pfls.Index,
-			},
-			DepType: types.DepTypeCode,
-		},
+	d := backend.Deployment{
+		Network:         network,
+		PCTSDep:         pcts.CellDep(),
+		PCLSDep:         pcls.CellDep(),
+		VCTSDep:         vcts.CellDep(),
+		VCLSDep:         vcls.CellDep(),
+		PFLSDep:         pfls.CellDep(),
 		PCTSCodeHash:    types.HexToHash(pcts.DataHash),
 		PCTSHashType:    types.HashTypeData1,
 		PCLSCodeHash:    types.HexToHash(pcls.DataHash),
@@ -129,13 +128,18 @@ func (m Migration) MakeDeployment(systemScripts SystemScripts, sudtOwnerLockArg
 		SUDTs: map[types.Hash]types.Script{
 			sudtInfo.Script.Hash(): *sudtInfo.Script,
 		},
-	}, *sudtInfo, nil
+	}
+	// The funding cells hold at least the capacity that the payout cells
+	// occupy.
+	d.PFLSMinCapacity = FundingCellCapacity(d)
+	return d, *sudtInfo, nil
 }
 
+// GetSUDT returns the SUDT deployed by the migration.
 func (m Migration) GetSUDT() (*SUDTInfo, error) {
-	sudt := m.CellRecipes[0]
-	if sudt.Name != "sudt" {
-		return nil, fmt.Errorf("first cell recipe must be sudt")
+	sudt, err := m.Recipe("sudt")
+	if err != nil {
+		return nil, err
 	}
 
 	sudtScript := types.Script{
@@ -143,86 +147,111 @@ func (m Migration) GetSUDT() (*SUDTInfo, error) {
 		HashType: types.HashTypeData1,
 		Args:     []byte{},
 	}
-	sudtCellDep := types.CellDep{
-		OutPoint: &types.OutPoint{
-			TxHash: types.HexToHash(sudt.TxHash),
-			Index:  sudt.Index,
-		},
-		DepType: types.DepTypeCode,
-	}
+	sudtCellDep := sudt.CellDep()
 	return &SUDTInfo{
 		Script:  &sudtScript,
 		CellDep: &sudtCellDep,
 	}, nil
 }
 
-func GetDeployment(migrationDir, migrationDirVC, systemScriptsDir, sudtOwnerLockArg string) (backend.Deployment, SUDTInfo, error) {
-	dir, err := os.ReadDir(migrationDir)
+// Config configures the loading of a deployment.
+type Config struct {
+	Network Network
+	// MigrationsDir and VCMigrationsDir contain the migrations of the channel
+	// scripts and of the virtual channel scripts in a subdirectory per
+	// network, see Network.MigrationSubdir.
+	MigrationsDir    string
+	VCMigrationsDir  string
+	SystemScriptsDir string
+	// Migration and VCMigration pin the migration files by name. If empty,
+	// the latest migration is used.
+	Migration        string
+	VCMigration      string
+	SUDTOwnerLockArg string
+}
+
+// Load loads the deployment of the configured network.
+func Load(cfg Config) (backend.Deployment, SUDTInfo, error) {
+	subdir, err := cfg.Network.MigrationSubdir()
 	if err != nil {
 		return backend.Deployment{}, SUDTInfo{}, err
 	}
-	if len(dir) != 1 {
-		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("migration dir must contain exactly one file")
-	}
-
-	vc_dir, err := os.ReadDir(migrationDirVC)
+	migration, err := ReadMigration(path.Join(cfg.MigrationsDir, subdir), cfg.Migration)
 	if err != nil {
 		return backend.Deployment{}, SUDTInfo{}, err
 	}
-	if len(vc_dir) != 1 {
-		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("migration dir must contain exactly one file")
-	}
-
-	migrationName := dir[0].Name()
-	migrationFile, err := os.Open(path.Join(migrationDir, migrationName))
-	defer func() {
-		if err := migrationFile.Close(); err != nil {
-			log.Fatalf("failed to close migration file: %v\n", err)
-		}
-	}()
+	vcMigration, err := ReadMigration(path.Join(cfg.VCMigrationsDir, subdir), cfg.VCMigration)
 	if err != nil {
 		return backend.Deployment{}, SUDTInfo{}, err
 	}
+	ss, err := GetSystemScripts(cfg.SystemScriptsDir)
+	if err != nil {
+		return backend.Deployment{}, SUDTInfo{}, fmt.Errorf("reading system scripts of %s: %w", cfg.SystemScriptsDir, err)
+	}
+	return migration.MakeDeployment(ss, cfg.SUDTOwnerLockArg, vcMigration, cfg.Network.AddressNetwork())
+}
 
-	vcMigrationName := vc_dir[0].Name()
-	vcMigrationFile, err := os.Open(path.Join(migrationDirVC, vcMigrationName))
-	defer func() {
-		if err := vcMigrationFile.Close(); err != nil {
-			log.Fatalf("failed to close vc migration file: %v\n", err)
-		}
-	}()
+// GetDeployment loads the deployment of the latest migrations in the given
+// directories on a development network.
+func GetDeployment(migrationDir, migrationDirVC, systemScriptsDir, sudtOwnerLockArg string) (backend.Deployment, SUDTInfo, error) {
+	migration, err := ReadMigration(migrationDir, "")
 	if err != nil {
 		return backend.Deployment{}, SUDTInfo{}, err
 	}
-
-	// Read and unmarshall migration file
-	migrationData, err := io.ReadAll(migrationFile)
+	vcMigration, err := ReadMigration(migrationDirVC, "")
 	if err != nil {
 		return backend.Deployment{}, SUDTInfo{}, err
 	}
-	var migration Migration
-	err = json.Unmarshal(migrationData, &migration)
+	ss, err := GetSystemScripts(systemScriptsDir)
 	if err != nil {
 		return backend.Deployment{}, SUDTInfo{}, err
 	}
+	return migration.MakeDeployment(ss, sudtOwnerLockArg, vcMigration, types.NetworkTest)
+}
 
-	// Read and unmarshall vc migration file
-	vcMigrationData, err := io.ReadAll(vcMigrationFile)
+// ReadMigration reads the migration with the given name from dir. If name is
+// empty, it reads the latest migration. `ckb-cli deploy` names migrations
+// by their timestamp, so the latest migration is the last one by name.
+func ReadMigration(dir, name string) (Migration, error) {
+	names, err := migrationNames(dir)
 	if err != nil {
-		return backend.Deployment{}, SUDTInfo{}, err
+		return Migration{}, err
 	}
-	var vcMigration Migration
-	err = json.Unmarshal(vcMigrationData, &vcMigration)
+	if name == "" {
+		name = names[len(names)-1]
+	} else if !strings.HasSuffix(name, ".json") {
+		name += ".json"
+	}
+	if !slices.Contains(names, name) {
+		return Migration{}, fmt.Errorf("migration %q not found in %s, available: [%s]", name, dir, strings.Join(names, ", "))
+	}
+
+	data, err := os.ReadFile(path.Join(dir, name))
 	if err != nil {
-		return backend.Deployment{}, SUDTInfo{}, err
+		return Migration{}, err
 	}
+	var m Migration
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Migration{}, fmt.Errorf("parsing migration %s: %w", path.Join(dir, name), err)
+	}
+	return m, nil
+}
 
-	// Read system scripts
-	ss, err := GetSystemScripts(systemScriptsDir)
+// migrationNames returns the names of the migration files in dir, sorted.
+func migrationNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return backend.Deployment{}, SUDTInfo{}, err
+		return nil, fmt.Errorf("reading migrations: %w; deploy the contracts first, e.g., with `make dev` in devnet", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no migrations in %s; deploy the contracts first, e.g., with `make dev` in devnet", dir)
 	}
-	fmt.Printf("Migration: %v\n", migration)
-	fmt.Printf("VC Migration: %v\n", vcMigration)
-	return migration.MakeDeployment(ss, sudtOwnerLockArg, vcMigration)
+	sort.Strings(names)
+	return names, nil
 }
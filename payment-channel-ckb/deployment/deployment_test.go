@@ -0,0 +1,63 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"perun.network/perun-examples/payment-channel-ckb/deployment"
+)
+
+func TestReadMigration(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration := func(name, recipe string) {
+		data := `{"cell_recipes":[{"name":"` + recipe + `","tx_hash":"0x01","index":1,"data_hash":"0x02"}],"dep_group_recipes":[]}`
+		require.NoError(t, os.WriteFile(path.Join(dir, name), []byte(data), 0o600))
+	}
+	writeMigration("2024-01-01-000000.json", "old")
+	writeMigration("2025-01-01-000000.json", "new")
+
+	m, err := deployment.ReadMigration(dir, "")
+	require.NoError(t, err)
+	_, err = m.Recipe("new")
+	require.NoError(t, err, "latest migration")
+
+	m, err = deployment.ReadMigration(dir, "2024-01-01-000000")
+	require.NoError(t, err)
+	r, err := m.Recipe("old")
+	require.NoError(t, err, "pinned migration")
+	require.EqualValues(t, 1, r.Index)
+
+	_, err = m.Recipe("pcts")
+	require.ErrorContains(t, err, "[old]")
+	_, err = deployment.ReadMigration(dir, "2023-01-01-000000")
+	require.ErrorContains(t, err, "2024-01-01-000000.json, 2025-01-01-000000.json")
+	_, err = deployment.ReadMigration(t.TempDir(), "")
+	require.ErrorContains(t, err, "no migrations")
+}
+
+func TestParseNetwork(t *testing.T) {
+	n, err := deployment.ParseNetwork("testnet")
+	require.NoError(t, err)
+	subdir, err := n.MigrationSubdir()
+	require.NoError(t, err)
+	require.Equal(t, "testnet", subdir)
+
+	_, err = deployment.ParseNetwork("staging")
+	require.Error(t, err)
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"fmt"
+
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+)
+
+// Network is a CKB network the contracts are deployed on.
+type Network string
+
+const (
+	Devnet  Network = "devnet"
+	Testnet Network = "testnet"
+	Mainnet Network = "mainnet"
+)
+
+// ParseNetwork parses the name of a network.
+func ParseNetwork(name string) (Network, error) {
+	switch n := Network(name); n {
+	case Devnet, Testnet, Mainnet:
+		return n, nil
+	}
+	return "", fmt.Errorf("unknown network %q, expected one of %s, %s or %s", name, Devnet, Testnet, Mainnet)
+}
+
+// AddressNetwork returns the network used for encoding addresses. Devnets use
+// testnet addresses.
+func (n Network) AddressNetwork() types.Network {
+	if n == Mainnet {
+		return types.NetworkMain
+	}
+	return types.NetworkTest
+}
+
+// MigrationSubdir returns the subdirectory in which `ckb-cli deploy` stores
+// the migrations of the network.
+func (n Network) MigrationSubdir() (string, error) {
+	switch n {
+	case Devnet:
+		return "dev", nil
+	case Testnet, Mainnet:
+		return string(n), nil
+	}
+	return "", fmt.Errorf("unknown network %q", string(n))
+}
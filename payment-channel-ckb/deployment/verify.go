@@ -0,0 +1,81 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nervosnetwork/ckb-sdk-go/v2/rpc"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"perun.network/perun-ckb-backend/backend"
+)
+
+const cellStatusLive = "live"
+
+// Verify checks that the cell deps of the deployment are live on the chain
+// the client is connected to and that the code cells contain the expected
+// scripts.
+func Verify(ctx context.Context, client rpc.Client, d backend.Deployment) error {
+	codeDeps := []struct {
+		name     string
+		dep      types.CellDep
+		codeHash types.Hash
+	}{
+		{"pcts", d.PCTSDep, d.PCTSCodeHash},
+		{"pcls", d.PCLSDep, d.PCLSCodeHash},
+		{"pfls", d.PFLSDep, d.PFLSCodeHash},
+		{"vcts", d.VCTSDep, d.VCTSCodeHash},
+		{"vcls", d.VCLSDep, d.VCLSCodeHash},
+	}
+	for h, script := range d.SUDTs {
+		codeDeps = append(codeDeps, struct {
+			name     string
+			dep      types.CellDep
+			codeHash types.Hash
+		}{"sudt", d.SUDTDeps[h], script.CodeHash})
+	}
+
+	for _, c := range codeDeps {
+		cell, err := liveCell(ctx, client, c.name, c.dep)
+		if err != nil {
+			return err
+		}
+		if cell.Cell.Data == nil || cell.Cell.Data.Hash != c.codeHash {
+			return fmt.Errorf("cell dep %s (%s) does not contain the script with code hash %s; the migration does not match the deployed contracts, pin the matching migration", c.name, outPointString(c.dep.OutPoint), c.codeHash)
+		}
+	}
+	_, err := liveCell(ctx, client, "default lock", d.DefaultLockScriptDep)
+	return err
+}
+
+// liveCell fetches the cell of the given dep and checks that it is live.
+func liveCell(ctx context.Context, client rpc.Client, name string, dep types.CellDep) (*types.CellWithStatus, error) {
+	cell, err := client.GetLiveCell(ctx, dep.OutPoint, true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetching cell dep %s (%s): %w", name, outPointString(dep.OutPoint), err)
+	}
+	if cell.Status != cellStatusLive || cell.Cell == nil {
+		return nil, fmt.Errorf("cell dep %s (%s) is %s; check that the node runs on the selected network and that the contracts are deployed, e.g., with `make dev` in devnet", name, outPointString(dep.OutPoint), cell.Status)
+	}
+	return cell, nil
+}
+
+func outPointString(op *types.OutPoint) string {
+	if op == nil {
+		return "missing out point"
+	}
+	return fmt.Sprintf("%s:%d", op.TxHash, op.Index)
+}
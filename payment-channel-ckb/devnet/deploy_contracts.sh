@@ -97,9 +97,11 @@ timestamp=$(date '+%Y-%m-%d-%H%M%S')
 rm ./$DEPLOYMENT_INFO.json
 rm ./$DEPLOYMENT_INFO_VC.json
 
-SUDT_TX_HASH=$(cat ./contracts/migrations/dev/*.json | jq .cell_recipes[0].tx_hash)
-SUDT_TX_INDEX=$(cat ./contracts/migrations/dev/*.json | jq .cell_recipes[0].index)
-SUDT_DATA_HASH=$(cat ./contracts/migrations/dev/*.json | jq .cell_recipes[0].data_hash)
+# Use the latest migration and look up the SUDT recipe by name.
+LATEST_MIGRATION=$(ls ./contracts/migrations/dev/*.json | sort | tail -n 1)
+SUDT_TX_HASH=$(jq '.cell_recipes[] | select(.name == "sudt") | .tx_hash' $LATEST_MIGRATION)
+SUDT_TX_INDEX=$(jq '.cell_recipes[] | select(.name == "sudt") | .index' $LATEST_MIGRATION)
+SUDT_DATA_HASH=$(jq '.cell_recipes[] | select(.name == "sudt") | .data_hash' $LATEST_MIGRATION)
 echo "Fetching genesis cell done."
 # TODO: This only works as long as the tx index is 0-9.
 jq ".items.sudt.script_id.code_hash = $SUDT_DATA_HASH | .items.sudt.cell_dep.out_point.tx_hash = $SUDT_TX_HASH | .items.sudt.cell_dep.out_point.index = \"0x$SUDT_TX_INDEX\"" ./sudt-celldep-template.json > $SYSTEM_SCRIPTS_DIR/sudt-celldep.json
\ No newline at end of file
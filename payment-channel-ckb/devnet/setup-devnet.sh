@@ -4,8 +4,9 @@ set -eu
 [ -n "${DEBUG:-}" ] && set -x || true
 
 # This script sets up the devnet for CKB.
-# Part of the setup are a miner, two accounts Alice and Bob, as well as the
-# registration of two accounts governing the genesis cells.
+# Part of the setup are a miner, two accounts Alice and Bob, an account for the
+# hub Ingrid, the co-signer of Bob's multisig lock, as well as the registration
+# of two accounts governing the genesis cells.
 
 ACCOUNTS_DIR="accounts"
 PERUN_CONTRACTS_DIR="contracts"
@@ -69,6 +70,9 @@ MINER_LOCK_ARG=$(cat $ACCOUNTS_DIR/miner.txt | awk '/lock_arg/ {print $2}')
 
 create_account "alice"
 create_account "bob"
+create_account "ingrid"
+# The co-signer of Bob's multisig lock, see -bob-lock multisig.
+create_account "bob-cosigner"
 
 ckb init --chain dev --ba-arg $MINER_LOCK_ARG --ba-message "0x" --force
 
@@ -15,84 +15,121 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
+	"math/big"
 	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	sdkaddress "github.com/nervosnetwork/ckb-sdk-go/v2/address"
 	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
 	"github.com/perun-network/perun-libp2p-wire/p2p"
 	"perun.network/go-perun/channel"
+	"perun.network/go-perun/wallet"
+	"perun.network/go-perun/wire"
 	ckbchannel "perun.network/perun-ckb-backend/channel"
 	"perun.network/perun-ckb-backend/channel/asset"
+	"perun.network/perun-ckb-backend/channel/test"
+	"perun.network/perun-examples/payment-channel-ckb/account"
 	"perun.network/perun-examples/payment-channel-ckb/client"
+	"perun.network/perun-examples/payment-channel-ckb/dashboard"
+	"perun.network/perun-examples/payment-channel-ckb/deployment"
 )
 
 const (
 	rpcNodeURL = "http://localhost:8114"
-	Network    = types.NetworkTest // Network to use for the CKB client
+	// The interval in which the balance watcher polls the balances.
+	balanceInterval = time.Second
+	// The log file of the dashboard.
+	dashboardLog = "dashboard.log"
 )
 
+var (
+	network     = flag.String("network", string(deployment.Devnet), "network the contracts are deployed on: devnet, testnet or mainnet")
+	migration   = flag.String("migration", "", "migration of the channel scripts, defaults to the latest")
+	vcMigration = flag.String("vc-migration", "", "migration of the virtual channel scripts, defaults to the latest")
+	dashboardUI = flag.Bool("dashboard", false, "run the terminal dashboard instead of the demo")
+	accounts    = flag.String("accounts", "alice", "comma-separated accounts of the dashboard: alice, bob or ingrid")
+	bobLock     = flag.String("bob-lock", "default", "payment script of Bob: default, omnilock or multisig")
+	bobCosigner = flag.String("bob-cosigner", "./devnet/accounts/bob-cosigner.pk", "key that co-signs Bob's multisig lock")
+	omnilockDep = flag.String("omnilock-dep", "", "out point <tx hash>:<index> of the omnilock code cell, required by -bob-lock omnilock")
+)
+
+// accountNames are the names of the accounts of the setup by index.
+var accountNames = []string{"Alice", "Bob", "Ingrid"}
+
 func main() {
 	//Setup devnet environment
-	setup := NewSetup()
-
-	log.Println("Setting up payment channel clients")
-	aliceWireAcc := p2p.NewRandomAccount(rand.New(rand.NewSource(time.Now().UnixNano())))
-	aliceNet, err := p2p.NewP2PBus(ckbchannel.CKBBackendID, aliceWireAcc)
+	flag.Parse()
+	n, err := deployment.ParseNetwork(*network)
 	if err != nil {
-		log.Fatalf("creating p2p net: %v", err)
+		log.Fatal(err)
 	}
-	aliceBus := aliceNet.Bus
-	aliceListener := aliceNet.Listener
-	go aliceBus.Listen(aliceListener)
-
-	alice, err := client.NewPaymentClient(
-		"Alice",
-		Network,
-		setup.Deployment,
-		rpcNodeURL,
-		setup.WalletAccs[0],
-		*setup.AccKeys[0],
-		setup.Wallets[0],
-		aliceWireAcc.Address(),
-		aliceNet,
-	)
-	if err != nil {
-		log.Fatalf("error creating alice's client: %v", err)
+	setup := NewSetup(deployment.Config{
+		Network:     n,
+		Migration:   *migration,
+		VCMigration: *vcMigration,
+	})
+	if setup.Locks[1], err = parseLock(*bobLock, n, setup); err != nil {
+		log.Fatal(err)
 	}
-
-	bobWireAcc := p2p.NewRandomAccount(rand.New(rand.NewSource(time.Now().UnixNano())))
-	bobNet, err := p2p.NewP2PBus(ckbchannel.CKBBackendID, bobWireAcc)
-	if err != nil {
-		log.Fatalf("creating p2p net: %v", err)
+	if *dashboardUI {
+		runDashboard(setup, strings.Split(*accounts, ","))
+		return
 	}
-	bobBus := bobNet.Bus
-	bobListener := bobNet.Listener
-	go bobBus.Listen(bobListener)
 
-	bob, err := client.NewPaymentClient(
-		"Bob",
-		Network,
-		setup.Deployment,
-		rpcNodeURL,
-		setup.WalletAccs[1],
-		*setup.AccKeys[1],
-		setup.Wallets[1],
-		bobWireAcc.Address(),
-		bobNet,
-	)
-	if err != nil {
-		log.Fatalf("error creating bob's client: %v", err)
+	log.Println("Setting up payment channel clients")
+	alice := setupClient("Alice", setup, 0)
+	bob := setupClient("Bob", setup, 1)
+	if setup.Locks[1].Type != client.LockDefault {
+		// Bob funds channels from the cells of his payment script, which the
+		// devnet setup does not fund.
+		addr := sdkaddress.Address{Script: bob.PaymentScript(), Network: setup.Deployment.Network}
+		encoded, err := addr.EncodeFullBech32m()
+		if err != nil {
+			log.Fatalf("error encoding bob's address: %v", err)
+		}
+		log.Println("Bob funds channels from", encoded)
+	}
+	ingrid := client.NewHub(setupClient("Ingrid", setup, 2))
+	for _, c := range []*client.PaymentClient{alice, bob} {
+		if err := c.RegisterSUDT(setup.SudtAsset, setup.SudtMeta); err != nil {
+			log.Fatalf("error registering sudt: %v", err)
+		}
 	}
 
 	fmt.Println("Alice Balance:", alice.GetBalances())
 	fmt.Println("Bob Balance:", bob.GetBalances())
 
+	// Log the balance changes of Alice, e.g., when she locks funds into a
+	// channel. The devnet node does not serve subscriptions, so the watcher
+	// polls.
+	alice.OnBalanceChange(func(b client.Balances) {
+		log.Println("Alice's balance changed:", alice.FormatBalances(b))
+	})
+	if err := alice.WatchBalances(balanceInterval, ""); err != nil {
+		log.Fatalf("error watching alice's balance: %v", err)
+	}
+
+	// Bob accepts channels in which he deposits at most 50 CKBytes.
+	bob.SetAcceptancePolicy(client.AcceptancePolicy{
+		MinChallengeDuration: 10,
+		CKBytes:              &client.AssetLimits{MaxOwnDeposit: client.CKByteToShannon(big.NewFloat(50.0))},
+	})
+
 	//Open Channel between Alice and Bob
 	log.Println("Opening channel and depositing funds")
-	chAlice := alice.OpenChannel(bob.WireAddress(), bob.PeerID(), map[channel.Asset]float64{
-		setup.CKBAsset: 100.0,
+	chAlice := alice.OpenChannel(bob.WireAddress(), bob.PeerID(), map[channel.Asset]client.Funding{
+		setup.CKBAsset: {
+			Own:  client.CKByteToShannon(big.NewFloat(100.0)),
+			Peer: client.CKByteToShannon(big.NewFloat(20.0)),
+		},
 	})
 
 	log.Println("Alice sent proposal")
@@ -101,23 +138,23 @@ func main() {
 	log.Println("Bob accepted proposal")
 
 	assets := []asset.Asset{*setup.CKBAsset}
-	printBalances(chAlice, assets)
+	printBalances(alice, chAlice, assets)
 
 	log.Println("Sending payments....")
 
 	//Alice sends payment
-	chAlice.SendPayment(map[channel.Asset]float64{
-		setup.CKBAsset: 10.0,
+	chAlice.SendPayment(map[channel.Asset]*big.Int{
+		setup.CKBAsset: client.CKByteToShannon(big.NewFloat(10.0)),
 	})
 	log.Println("Alice sent Bob a payment")
-	printBalances(chAlice, assets)
+	printBalances(alice, chAlice, assets)
 
 	//Bob sends payment
-	chBob.SendPayment(map[channel.Asset]float64{
-		setup.CKBAsset: 5.0,
+	chBob.SendPayment(map[channel.Asset]*big.Int{
+		setup.CKBAsset: client.CKByteToShannon(big.NewFloat(5.0)),
 	})
 	log.Println("Bob sent Alice a payment")
-	printBalances(chAlice, assets)
+	printBalances(alice, chAlice, assets)
 
 	log.Println("Payments completed")
 
@@ -125,9 +162,187 @@ func main() {
 	log.Println("Settle channels")
 	chAlice.Settle()
 
+	// Alice and Bob each open a ledger channel with the hub Ingrid and then a
+	// virtual channel with each other through the hub.
+	log.Println("Opening ledger channels with the hub")
+	hubFunding := client.SymmetricFunding(map[channel.Asset]*big.Int{
+		setup.CKBAsset: client.CKByteToShannon(big.NewFloat(100.0)),
+	})
+	chAliceHub := alice.OpenChannel(ingrid.WireAddress(), ingrid.PeerID(), hubFunding)
+	chBobHub := bob.OpenChannel(ingrid.WireAddress(), ingrid.PeerID(), hubFunding)
+
+	log.Println("Opening virtual channel")
+	bobParent, err := waitForParent(ingrid, bob.WireAddress())
+	if err != nil {
+		log.Fatalf("error looking up bob's channel with the hub: %v", err)
+	}
+	vchAlice := alice.OpenVirtualChannel(bob.WireAddress(), bob.PeerID(), chAliceHub, bobParent, client.SymmetricFunding(map[channel.Asset]*big.Int{
+		setup.CKBAsset: client.CKByteToShannon(big.NewFloat(20.0)),
+	}))
+	vchBob := bob.AcceptedChannel()
+	printBalances(alice, vchAlice, assets)
+
+	log.Println("Sending payments in the virtual channel....")
+	vchAlice.SendPayment(map[channel.Asset]*big.Int{
+		setup.CKBAsset: client.CKByteToShannon(big.NewFloat(10.0)),
+	})
+	vchBob.SendPayment(map[channel.Asset]*big.Int{
+		setup.CKBAsset: client.CKByteToShannon(big.NewFloat(5.0)),
+	})
+	printBalances(alice, vchAlice, assets)
+
+	// The hub settles the virtual channel into the ledger channels when it
+	// receives the settlement of both participants.
+	log.Println("Settling virtual channel")
+	done := make(chan struct{})
+	go func() {
+		vchAlice.Settle() // Finalize and settle.
+		close(done)
+	}()
+	for !vchBob.State().IsFinal {
+		time.Sleep(100 * time.Millisecond)
+	}
+	vchBob.Settle() // Settle.
+	<-done
+
+	log.Println("Settling ledger channels with the hub")
+	chAliceHub.Settle()
+	chBobHub.Settle()
+
 	//Cleanup
 	alice.Shutdown()
 	bob.Shutdown()
+	ingrid.Shutdown()
 	log.Println("Clients shutdown, exiting method")
 
 }
+
+// runDashboard runs the terminal dashboard of the given accounts of the setup.
+func runDashboard(setup *Setup, names []string) {
+	// The dashboard takes over the terminal, so we log to a file.
+	logFile, err := os.OpenFile(dashboardLog, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		log.Fatalf("error opening log file: %v", err)
+	}
+	defer logFile.Close()
+	log.SetOutput(logFile)
+
+	var accs []dashboard.Account
+	for _, name := range names {
+		i := slices.IndexFunc(accountNames, func(n string) bool { return strings.EqualFold(n, strings.TrimSpace(name)) })
+		if i < 0 {
+			log.Fatalf("unknown account %q", name)
+		}
+		c := setupClient(accountNames[i], setup, i)
+		if err := c.RegisterSUDT(setup.SudtAsset, setup.SudtMeta); err != nil {
+			log.Fatalf("error registering sudt: %v", err)
+		}
+		// Accept channels in CKBytes and in the SUDT of the setup.
+		policy := client.DefaultAcceptancePolicy()
+		policy.SUDTs = map[types.Hash]client.AssetLimits{setup.SudtAsset.SUDT.TypeScript.Hash(): {}}
+		c.SetAcceptancePolicy(policy)
+		defer c.Shutdown()
+		accs = append(accs, dashboard.Account{Name: accountNames[i], Client: c})
+	}
+
+	d := dashboard.New(os.Stdout, accs, []dashboard.Asset{
+		dashboard.CKBytes(setup.CKBAsset),
+		dashboard.SUDT(setup.SudtAsset, setup.SudtMeta),
+	})
+	if err := d.Run(os.Stdin, balanceInterval); err != nil {
+		log.Printf("dashboard error: %v", err)
+	}
+}
+
+// setupClient sets up the payment client of the account with index i of the
+// setup.
+func setupClient(name string, setup *Setup, i int) *client.PaymentClient {
+	wireAcc := p2p.NewRandomAccount(rand.New(rand.NewSource(time.Now().UnixNano())))
+	net, err := p2p.NewP2PBus(ckbchannel.CKBBackendID, wireAcc)
+	if err != nil {
+		log.Fatalf("creating p2p net: %v", err)
+	}
+	go net.Bus.Listen(net.Listener)
+
+	c, err := client.NewPaymentClient(
+		name,
+		setup.Deployment.Network,
+		setup.Deployment,
+		rpcNodeURL,
+		setup.WalletAccs[i],
+		setup.Locks[i],
+		*setup.AccKeys[i],
+		setup.Wallets[i],
+		wireAcc.Address(),
+		net,
+	)
+	if err != nil {
+		log.Fatalf("error creating %s's client: %v", name, err)
+	}
+	return c
+}
+
+// parseLock returns the lock config of Bob. The multisig lock requires the
+// signatures of Bob and of his co-signer, whose key Bob's client holds.
+func parseLock(kind string, n deployment.Network, setup *Setup) (client.LockConfig, error) {
+	switch kind {
+	case "default":
+		return client.LockConfig{Type: client.LockDefault}, nil
+	case "omnilock":
+		codeHash, err := account.OmnilockCodeHash(n)
+		if err != nil {
+			return client.LockConfig{}, err
+		}
+		dep, err := parseCodeDep(*omnilockDep)
+		if err != nil {
+			return client.LockConfig{}, fmt.Errorf("omnilock dep: %w", err)
+		}
+		return client.LockConfig{Type: client.LockOmnilockEthereum, OmnilockCodeHash: codeHash, CellDep: dep}, nil
+	case "multisig":
+		cosigner, err := test.GetKey(*bobCosigner)
+		if err != nil {
+			return client.LockConfig{}, fmt.Errorf("getting bob's co-signer key: %w", err)
+		}
+		return client.LockConfig{
+			Type:    client.LockMultisig,
+			CellDep: setup.MultisigDep,
+			Multisig: client.MultisigConfig{
+				PubKeys:   []*secp256k1.PublicKey{setup.AccKeys[1].PubKey(), cosigner.PubKey()},
+				Threshold: 2,
+				CoSigners: []*secp256k1.PrivateKey{cosigner},
+			},
+		}, nil
+	}
+	return client.LockConfig{}, fmt.Errorf("unknown lock %q, want default, omnilock or multisig", kind)
+}
+
+// parseCodeDep parses the out point <tx hash>:<index> of a code cell.
+func parseCodeDep(s string) (types.CellDep, error) {
+	hash, index, ok := strings.Cut(s, ":")
+	if !ok {
+		return types.CellDep{}, fmt.Errorf("want <tx hash>:<index>, got %q", s)
+	}
+	i, err := strconv.ParseUint(index, 10, 32)
+	if err != nil {
+		return types.CellDep{}, fmt.Errorf("index: %w", err)
+	}
+	return types.CellDep{
+		OutPoint: &types.OutPoint{TxHash: types.HexToHash(hash), Index: uint32(i)},
+		DepType:  types.DepTypeCode,
+	}, nil
+}
+
+// waitForParent waits until the hub recorded the ledger channel of the user.
+// In a deployment, the peer of a virtual channel sends the reference to its
+// parent channel to the proposer.
+func waitForParent(hub *client.Hub, user map[wallet.BackendID]wire.Address) (client.ParentRef, error) {
+	var err error
+	for i := 0; i < 50; i++ {
+		var ref client.ParentRef
+		if ref, err = hub.Parent(user); err == nil {
+			return ref, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return client.ParentRef{}, err
+}
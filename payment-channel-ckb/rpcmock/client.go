@@ -0,0 +1,503 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rpcmock implements an in-process CKB node with an indexer for
+// testing the payment client without a devnet.
+package rpcmock
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/crypto/blake2b"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/indexer"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/rpc"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+)
+
+const (
+	// GenesisTimestamp is the timestamp of the genesis block in milliseconds.
+	GenesisTimestamp = 1_700_000_000_000
+	// BlockInterval is the time between two blocks.
+	BlockInterval = 8 * time.Second
+
+	shannonPerByte = 100_000_000
+
+	cellStatusLive    = "live"
+	cellStatusDead    = "dead"
+	cellStatusUnknown = "unknown"
+)
+
+// Client is an in-process CKB node with an indexer. It implements the subset
+// of rpc.Client that the payment client, the funder and the adjudicator use:
+// querying headers, cells and transactions, and sending transactions.
+// Any other method of rpc.Client fails with a not implemented error.
+//
+// The node starts with a genesis block and the cells added with AddCell.
+// Sent transactions are validated against the live cells and the capacity
+// rules, but their scripts are not executed. They are committed in the next
+// block, which is produced by Mine, or immediately if auto mining is enabled.
+type Client struct {
+	rpc.Client // Fails every call, see notImplemented.
+
+	mtx      sync.Mutex
+	cells    []*cell // All cells in the order of their creation.
+	outputs  map[types.OutPoint]*cell
+	pool     []*types.Transaction // Accepted transactions of the next block.
+	spent    map[types.OutPoint]bool
+	txs      map[types.Hash]*txRecord
+	headers  []*types.Header
+	autoMine bool
+}
+
+// cell is a cell created in the genesis block or by a transaction.
+type cell struct {
+	outPoint types.OutPoint
+	output   types.CellOutput
+	data     []byte
+	block    uint64
+	txIndex  uint
+	consumed bool
+}
+
+// txRecord is a sent transaction and its status.
+type txRecord struct {
+	tx        *types.Transaction
+	status    types.TransactionStatus
+	blockHash *types.Hash
+}
+
+// New returns a node with only the genesis block.
+func New() *Client {
+	c := &Client{
+		Client:  notImplemented(),
+		outputs: make(map[types.OutPoint]*cell),
+		spent:   make(map[types.OutPoint]bool),
+		txs:     make(map[types.Hash]*txRecord),
+	}
+	c.appendHeader(GenesisTimestamp)
+	return c
+}
+
+// notImplemented returns an RPC client that fails every call with an error
+// naming the called method.
+func notImplemented() rpc.Client {
+	c, err := gethrpc.DialHTTPWithClient("http://rpcmock", &http.Client{Transport: notImplementedTransport{}})
+	if err != nil {
+		panic(err) // Only fails for an invalid URL.
+	}
+	return rpc.NewClient(c)
+}
+
+// notImplementedTransport answers each JSON-RPC request with an error.
+type notImplementedTransport struct{}
+
+func (notImplementedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	defer req.Body.Close()
+	var msg struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&msg); err != nil {
+		return nil, fmt.Errorf("rpcmock: batch calls not implemented")
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      msg.ID,
+		"error": map[string]interface{}{
+			"code":    -32601, // Method not found.
+			"message": fmt.Sprintf("rpcmock: method %s not implemented", msg.Method),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// SetAutoMine sets whether every accepted transaction is committed in a new
+// block immediately.
+func (c *Client) SetAutoMine(autoMine bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.autoMine = autoMine
+}
+
+// AddCell adds a live cell to the tip block and returns its out point. The
+// out points are deterministic: the n-th added cell always has the same out
+// point.
+func (c *Client) AddCell(output types.CellOutput, data []byte) types.OutPoint {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	op := types.OutPoint{TxHash: deterministicHash("cell", uint64(len(c.cells))), Index: 0}
+	c.addCell(op, output, data, 0)
+	return op
+}
+
+// addCell adds a cell to the tip block. The mutex must be held.
+func (c *Client) addCell(op types.OutPoint, output types.CellOutput, data []byte, txIndex uint) {
+	ce := &cell{
+		outPoint: op,
+		output:   output,
+		data:     append([]byte(nil), data...),
+		block:    c.tip().Number,
+		txIndex:  txIndex,
+	}
+	c.cells = append(c.cells, ce)
+	c.outputs[op] = ce
+}
+
+// Mine commits the accepted transactions in a new block and returns its
+// header.
+func (c *Client) Mine() *types.Header {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.mine(BlockInterval)
+}
+
+// AdvanceTime produces empty blocks until the timestamp of the tip advanced by
+// at least d, e.g., to let the challenge duration of a channel pass.
+func (c *Client) AdvanceTime(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	end := c.tip().Timestamp + uint64(d.Milliseconds())
+	for c.tip().Timestamp < end {
+		c.mine(BlockInterval)
+	}
+}
+
+// mine commits the pool in a new block produced after the interval. The
+// mutex must be held.
+func (c *Client) mine(interval time.Duration) *types.Header {
+	h := c.appendHeader(c.tip().Timestamp + uint64(interval.Milliseconds()))
+	for i, tx := range c.pool {
+		hash := tx.ComputeHash()
+		for _, in := range tx.Inputs {
+			c.outputs[*in.PreviousOutput].consumed = true
+		}
+		// The cellbase has index 0 in a block.
+		for j, out := range tx.Outputs {
+			c.addCell(types.OutPoint{TxHash: hash, Index: uint32(j)}, *out, tx.OutputsData[j], uint(i+1))
+		}
+		rec := c.txs[hash]
+		rec.status = types.TransactionStatusCommitted
+		rec.blockHash = &h.Hash
+	}
+	c.pool = nil
+	c.spent = make(map[types.OutPoint]bool)
+	return h
+}
+
+// appendHeader appends a header with the given timestamp. The mutex must be
+// held.
+func (c *Client) appendHeader(timestamp uint64) *types.Header {
+	h := &types.Header{
+		Number:    uint64(len(c.headers)),
+		Timestamp: timestamp,
+	}
+	h.Hash = deterministicHash("block", h.Number)
+	if h.Number > 0 {
+		h.ParentHash = c.tip().Hash
+	}
+	c.headers = append(c.headers, h)
+	return h
+}
+
+// tip returns the tip header. The mutex must be held.
+func (c *Client) tip() *types.Header {
+	return c.headers[len(c.headers)-1]
+}
+
+// GetTipBlockNumber returns the number of the tip block.
+func (c *Client) GetTipBlockNumber(context.Context) (uint64, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.tip().Number, nil
+}
+
+// GetTipHeader returns the header of the tip block.
+func (c *Client) GetTipHeader(context.Context) (*types.Header, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	h := *c.tip()
+	return &h, nil
+}
+
+// GetHeader returns the header of the block with the given hash.
+func (c *Client) GetHeader(_ context.Context, hash types.Hash) (*types.Header, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for _, h := range c.headers {
+		if h.Hash == hash {
+			hc := *h
+			return &hc, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown block %v", hash)
+}
+
+// GetHeaderByNumber returns the header of the block with the given number.
+func (c *Client) GetHeaderByNumber(_ context.Context, number uint64) (*types.Header, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if number >= uint64(len(c.headers)) {
+		return nil, fmt.Errorf("block %d not found, tip is %d", number, c.tip().Number)
+	}
+	h := *c.headers[number]
+	return &h, nil
+}
+
+// GetLiveCell returns the cell with the given out point and whether it is
+// live. Cells of accepted but uncommitted transactions are unknown.
+func (c *Client) GetLiveCell(_ context.Context, outPoint *types.OutPoint, withData bool, _ *bool) (*types.CellWithStatus, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	ce, ok := c.outputs[*outPoint]
+	switch {
+	case !ok:
+		return &types.CellWithStatus{Status: cellStatusUnknown}, nil
+	case ce.consumed:
+		return &types.CellWithStatus{Status: cellStatusDead}, nil
+	}
+	output := ce.output
+	info := &types.CellInfo{Output: &output}
+	if withData {
+		info.Data = &types.CellData{
+			Content: append([]byte(nil), ce.data...),
+			Hash:    types.BytesToHash(blake2b.Blake256(ce.data)),
+		}
+	}
+	return &types.CellWithStatus{Cell: info, Status: cellStatusLive}, nil
+}
+
+// GetTransaction returns a sent transaction and its status.
+func (c *Client) GetTransaction(_ context.Context, hash types.Hash, _ *bool) (*types.TransactionWithStatus, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	rec, ok := c.txs[hash]
+	if !ok {
+		return &types.TransactionWithStatus{TxStatus: &types.TxStatus{Status: types.TransactionStatusUnknown}}, nil
+	}
+	return &types.TransactionWithStatus{
+		Transaction: rec.tx,
+		TxStatus:    &types.TxStatus{Status: rec.status, BlockHash: rec.blockHash},
+	}, nil
+}
+
+// SendTransaction validates the transaction and adds it to the next block.
+// It must spend live cells that are not spent by another accepted
+// transaction, its cell deps must be live, and its outputs must be covered by
+// its inputs and hold at least their occupied capacity.
+func (c *Client) SendTransaction(_ context.Context, tx *types.Transaction) (*types.Hash, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	hash := tx.ComputeHash()
+	if _, ok := c.txs[hash]; ok {
+		return nil, fmt.Errorf("duplicated transaction %v", hash)
+	}
+	if err := c.validate(tx); err != nil {
+		return nil, fmt.Errorf("transaction %v rejected: %w", hash, err)
+	}
+
+	for _, in := range tx.Inputs {
+		c.spent[*in.PreviousOutput] = true
+	}
+	c.pool = append(c.pool, tx)
+	c.txs[hash] = &txRecord{tx: tx, status: types.TransactionStatusPending}
+	if c.autoMine {
+		c.mine(BlockInterval)
+	}
+	return &hash, nil
+}
+
+// validate validates the transaction against the live cells. The mutex must
+// be held.
+func (c *Client) validate(tx *types.Transaction) error {
+	if len(tx.Inputs) == 0 {
+		return fmt.Errorf("no inputs")
+	}
+	if len(tx.Outputs) != len(tx.OutputsData) {
+		return fmt.Errorf("%d outputs but %d outputs data", len(tx.Outputs), len(tx.OutputsData))
+	}
+
+	var inCapacity, outCapacity uint64
+	for i, in := range tx.Inputs {
+		ce, err := c.liveCell(in.PreviousOutput)
+		if err != nil {
+			return fmt.Errorf("input %d: %w", i, err)
+		}
+		if c.spent[*in.PreviousOutput] {
+			return fmt.Errorf("input %d: double spend of %s", i, outPointString(in.PreviousOutput))
+		}
+		inCapacity += ce.output.Capacity
+	}
+	for i, dep := range tx.CellDeps {
+		if _, err := c.liveCell(dep.OutPoint); err != nil {
+			return fmt.Errorf("cell dep %d: %w", i, err)
+		}
+	}
+	for i, out := range tx.Outputs {
+		if occupied := occupiedCapacity(out, tx.OutputsData[i]); out.Capacity < occupied {
+			return fmt.Errorf("output %d: capacity %d below occupied capacity %d", i, out.Capacity, occupied)
+		}
+		outCapacity += out.Capacity
+	}
+	if outCapacity > inCapacity {
+		return fmt.Errorf("outputs capacity %d exceeds inputs capacity %d", outCapacity, inCapacity)
+	}
+	return nil
+}
+
+// liveCell returns the committed live cell with the given out point. The
+// mutex must be held.
+func (c *Client) liveCell(op *types.OutPoint) (*cell, error) {
+	if op == nil {
+		return nil, fmt.Errorf("missing out point")
+	}
+	ce, ok := c.outputs[*op]
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("unknown cell %s", outPointString(op))
+	case ce.consumed:
+		return nil, fmt.Errorf("dead cell %s", outPointString(op))
+	}
+	return ce, nil
+}
+
+// GetCells returns the live cells whose lock or type script matches the
+// search key. The cursor is the position of the last returned cell.
+func (c *Client) GetCells(_ context.Context, searchKey *indexer.SearchKey, order indexer.SearchOrder, limit uint64, afterCursor string) (*indexer.LiveCells, error) {
+	after := -1
+	if afterCursor != "" {
+		var err error
+		if after, err = strconv.Atoi(afterCursor); err != nil {
+			return nil, fmt.Errorf("invalid cursor %q", afterCursor)
+		}
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	n := len(c.cells)
+	result := &indexer.LiveCells{LastCursor: afterCursor}
+	for k := 0; k < n && uint64(len(result.Objects)) < limit; k++ {
+		pos := k
+		if order == indexer.SearchOrderDesc {
+			pos = n - 1 - k
+		}
+		if after >= 0 && !pastCursor(order, pos, after) {
+			continue
+		}
+		ce := c.cells[pos]
+		if ce.consumed || !matches(searchKey, &ce.output) {
+			continue
+		}
+		op := ce.outPoint
+		output := ce.output
+		lc := &indexer.LiveCell{
+			BlockNumber: ce.block,
+			OutPoint:    &op,
+			Output:      &output,
+			TxIndex:     ce.txIndex,
+		}
+		if searchKey.WithData {
+			lc.OutputData = append([]byte(nil), ce.data...)
+		}
+		result.Objects = append(result.Objects, lc)
+		result.LastCursor = strconv.Itoa(pos)
+	}
+	return result, nil
+}
+
+// pastCursor returns whether the position comes after the cursor in the
+// order.
+func pastCursor(order indexer.SearchOrder, pos, cursor int) bool {
+	if order == indexer.SearchOrderDesc {
+		return pos < cursor
+	}
+	return pos > cursor
+}
+
+// matches returns whether the output matches the search key.
+func matches(key *indexer.SearchKey, output *types.CellOutput) bool {
+	script, other := output.Lock, output.Type
+	if key.ScriptType == types.ScriptTypeType {
+		script, other = output.Type, output.Lock
+	}
+	if !scriptMatches(key.Script, script, key.ScriptSearchMode == types.ScriptSearchModeExact) {
+		return false
+	}
+	if key.Filter != nil && key.Filter.Script != nil {
+		return scriptMatches(key.Filter.Script, other, false)
+	}
+	return true
+}
+
+// scriptMatches returns whether the script matches the searched script. The
+// arguments of the searched script are a prefix unless exact is set.
+func scriptMatches(searched, script *types.Script, exact bool) bool {
+	if script == nil || searched == nil {
+		return script == searched
+	}
+	if script.CodeHash != searched.CodeHash || script.HashType != searched.HashType {
+		return false
+	}
+	if exact {
+		return bytes.Equal(script.Args, searched.Args)
+	}
+	return bytes.HasPrefix(script.Args, searched.Args)
+}
+
+// Close does nothing.
+func (c *Client) Close() {}
+
+// occupiedCapacity returns the capacity in Shannon that the output occupies:
+// one CKByte per byte of the capacity field, the scripts and the data.
+func occupiedCapacity(output *types.CellOutput, data []byte) uint64 {
+	size := 8 + scriptSize(output.Lock) + scriptSize(output.Type) + len(data)
+	return uint64(size) * shannonPerByte
+}
+
+func scriptSize(s *types.Script) int {
+	if s == nil {
+		return 0
+	}
+	return len(s.CodeHash) + 1 + len(s.Args)
+}
+
+// deterministicHash returns the hash of the domain and the number.
+func deterministicHash(domain string, n uint64) types.Hash {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], n)
+	return types.Hash(sha256.Sum256(append([]byte("rpcmock "+domain), buf[:]...)))
+}
+
+func outPointString(op *types.OutPoint) string {
+	return fmt.Sprintf("%s:%d", op.TxHash, op.Index)
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcmock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nervosnetwork/ckb-sdk-go/v2/indexer"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"github.com/stretchr/testify/require"
+	"perun.network/perun-examples/payment-channel-ckb/rpcmock"
+)
+
+const ckbyte = 100_000_000 // Shannon per CKByte.
+
+func lockScript(arg byte) *types.Script {
+	return &types.Script{CodeHash: types.HexToHash("0x01"), HashType: types.HashTypeType, Args: []byte{arg}}
+}
+
+func lockKey(lock *types.Script) *indexer.SearchKey {
+	return &indexer.SearchKey{Script: lock, ScriptType: types.ScriptTypeLock, ScriptSearchMode: types.ScriptSearchModeExact, WithData: true}
+}
+
+func TestGetCells(t *testing.T) {
+	ctx := context.Background()
+	alice, bob := lockScript(1), lockScript(2)
+	node := rpcmock.New()
+	op1 := node.AddCell(types.CellOutput{Capacity: 100 * ckbyte, Lock: alice}, nil)
+	op2 := node.AddCell(types.CellOutput{Capacity: 50 * ckbyte, Lock: alice}, []byte{1})
+	node.AddCell(types.CellOutput{Capacity: 70 * ckbyte, Lock: bob}, nil)
+	require.Equal(t, op1, rpcmock.New().AddCell(types.CellOutput{Capacity: ckbyte, Lock: bob}, nil), "deterministic out points")
+
+	// Page through the cells of Alice.
+	cells, err := node.GetCells(ctx, lockKey(alice), indexer.SearchOrderAsc, 1, "")
+	require.NoError(t, err)
+	require.Len(t, cells.Objects, 1)
+	require.Equal(t, op1, *cells.Objects[0].OutPoint)
+	cells, err = node.GetCells(ctx, lockKey(alice), indexer.SearchOrderAsc, 10, cells.LastCursor)
+	require.NoError(t, err)
+	require.Len(t, cells.Objects, 1)
+	require.Equal(t, op2, *cells.Objects[0].OutPoint)
+	require.Equal(t, []byte{1}, cells.Objects[0].OutputData)
+
+	cells, err = node.GetCells(ctx, lockKey(alice), indexer.SearchOrderDesc, 10, "")
+	require.NoError(t, err)
+	require.Len(t, cells.Objects, 2)
+	require.Equal(t, op2, *cells.Objects[0].OutPoint)
+}
+
+func TestSendTransaction(t *testing.T) {
+	ctx := context.Background()
+	alice, bob := lockScript(1), lockScript(2)
+	node := rpcmock.New()
+	in := node.AddCell(types.CellOutput{Capacity: 100 * ckbyte, Lock: alice}, nil)
+
+	transfer := func(in types.OutPoint, amount uint64) *types.Transaction {
+		return &types.Transaction{
+			Inputs:      []*types.CellInput{{PreviousOutput: &in}},
+			Outputs:     []*types.CellOutput{{Capacity: amount, Lock: bob}},
+			OutputsData: [][]byte{{}},
+		}
+	}
+	hash, err := node.SendTransaction(ctx, transfer(in, 99*ckbyte))
+	require.NoError(t, err)
+	tx, err := node.GetTransaction(ctx, *hash, nil)
+	require.NoError(t, err)
+	require.Equal(t, types.TransactionStatusPending, tx.TxStatus.Status)
+	_, err = node.SendTransaction(ctx, transfer(in, 98*ckbyte))
+	require.ErrorContains(t, err, "double spend")
+
+	header := node.Mine()
+	require.EqualValues(t, 1, header.Number)
+	tx, err = node.GetTransaction(ctx, *hash, nil)
+	require.NoError(t, err)
+	require.Equal(t, types.TransactionStatusCommitted, tx.TxStatus.Status)
+	require.Equal(t, header.Hash, *tx.TxStatus.BlockHash)
+
+	cell, err := node.GetLiveCell(ctx, &in, false, nil)
+	require.NoError(t, err)
+	require.Equal(t, "dead", cell.Status)
+	out := types.OutPoint{TxHash: *hash, Index: 0}
+	cell, err = node.GetLiveCell(ctx, &out, true, nil)
+	require.NoError(t, err)
+	require.Equal(t, "live", cell.Status)
+	require.EqualValues(t, 99*ckbyte, cell.Cell.Output.Capacity)
+
+	// The outputs must be covered and hold their occupied capacity.
+	_, err = node.SendTransaction(ctx, transfer(out, 100*ckbyte))
+	require.ErrorContains(t, err, "exceeds inputs capacity")
+	_, err = node.SendTransaction(ctx, transfer(out, ckbyte))
+	require.ErrorContains(t, err, "below occupied capacity")
+}
+
+func TestAutoMineAndTime(t *testing.T) {
+	ctx := context.Background()
+	node := rpcmock.New()
+	node.SetAutoMine(true)
+	in := node.AddCell(types.CellOutput{Capacity: 100 * ckbyte, Lock: lockScript(1)}, nil)
+	_, err := node.SendTransaction(ctx, &types.Transaction{
+		Inputs:      []*types.CellInput{{PreviousOutput: &in}},
+		Outputs:     []*types.CellOutput{{Capacity: 100 * ckbyte, Lock: lockScript(2)}},
+		OutputsData: [][]byte{{}},
+	})
+	require.NoError(t, err)
+	n, err := node.GetTipBlockNumber(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	node.AdvanceTime(time.Minute)
+	tip, err := node.GetTipHeader(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, tip.Timestamp, uint64(rpcmock.GenesisTimestamp+rpcmock.BlockInterval.Milliseconds()+time.Minute.Milliseconds()))
+}
+
+func TestNotImplemented(t *testing.T) {
+	_, err := rpcmock.New().GetBlockByNumber(context.Background(), 0)
+	require.ErrorContains(t, err, "rpcmock: method get_block_by_number not implemented")
+}
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"math/big"
 	"os"
+	"time"
 
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/rpc"
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
 	"perun.network/perun-ckb-backend/backend"
 	"perun.network/perun-ckb-backend/channel/asset"
 	"perun.network/perun-ckb-backend/channel/test"
@@ -18,22 +22,30 @@ import (
 )
 
 const (
-	sudtMaxCapacity = 200_00_000_000 // 200 ckb
+	// Metadata of the SUDT issued on the devnet.
+	sudtSymbol   = "SUDT"
+	sudtDecimals = 8
 )
 
 // Setup contains all the necessary information for CKB payment channel setup.
 type Setup struct {
 	Deployment backend.Deployment
 	SUDTInfo   deployment.SUDTInfo
-	Wallets    []*ckbwallet.EphemeralWallet
+	Wallets    []*ckbwallet.EphemeralWallet // The wallets of Alice, Bob and the hub Ingrid.
 	WalletAccs []*ckbwallet.Account
 	CKBAsset   *asset.Asset
 	SudtAsset  *asset.Asset
+	SudtMeta   client.SUDTMeta
 	AccKeys    []*secp256k1.PrivateKey
+	Locks      []client.LockConfig // The payment scripts of the accounts.
+	// MultisigDep is the cell dep of the secp256k1_blake160_multisig_all
+	// lock of the system scripts.
+	MultisigDep types.CellDep
 }
 
-// NewSetup creates a new Setup instance with the provided parameters.
-func NewSetup() *Setup {
+// NewSetup creates a new Setup instance with the deployment selected by cfg.
+// The directories of the deployment are those of the devnet setup.
+func NewSetup(cfg deployment.Config) *Setup {
 	log.Println("Initializing CKB payment channel setup")
 
 	sudtOwnerLockArg, err := parseSUDTOwnerLockArg("./devnet/accounts/sudt-owner-lock-hash.txt")
@@ -41,15 +53,27 @@ func NewSetup() *Setup {
 		log.Fatalf("error getting SUDT owner lock arg: %v", err)
 	}
 
-	d, sudtInfo, err := deployment.GetDeployment("./devnet/contracts/migrations/dev/", "./devnet/contracts/migrations_vc/dev/", "./devnet/system_scripts", sudtOwnerLockArg)
+	cfg.MigrationsDir = "./devnet/contracts/migrations"
+	cfg.VCMigrationsDir = "./devnet/contracts/migrations_vc"
+	cfg.SystemScriptsDir = "./devnet/system_scripts"
+	cfg.SUDTOwnerLockArg = sudtOwnerLockArg
+	d, sudtInfo, err := deployment.Load(cfg)
 	if err != nil {
 		log.Fatalf("error getting deployment: %v", err)
 	}
+	if err := verifyDeployment(d); err != nil {
+		log.Fatalf("error verifying deployment on %s: %v", cfg.Network, err)
+	}
+	systemScripts, err := deployment.GetSystemScripts(cfg.SystemScriptsDir)
+	if err != nil {
+		log.Fatalf("error getting system scripts: %v", err)
+	}
 
 	//Setup wallets
 	log.Println("Creating wallets")
 	wAlice := wallet.NewEphemeralWallet()
 	wBob := wallet.NewEphemeralWallet()
+	wIngrid := wallet.NewEphemeralWallet()
 
 	keyAlice, err := test.GetKey("./devnet/accounts/alice.pk")
 	if err != nil {
@@ -59,9 +83,14 @@ func NewSetup() *Setup {
 	if err != nil {
 		log.Fatalf("error getting bob's private key: %v", err)
 	}
+	keyIngrid, err := test.GetKey("./devnet/accounts/ingrid.pk")
+	if err != nil {
+		log.Fatalf("error getting ingrid's private key: %v", err)
+	}
 
 	aliceAccount := wallet.NewAccountFromPrivateKey(keyAlice)
 	bobAccount := wallet.NewAccountFromPrivateKey(keyBob)
+	ingridAccount := wallet.NewAccountFromPrivateKey(keyIngrid)
 
 	err = wAlice.AddAccount(aliceAccount)
 	if err != nil {
@@ -71,24 +100,35 @@ func NewSetup() *Setup {
 	if err != nil {
 		log.Fatalf("error adding bob's account: %v", err)
 	}
+	err = wIngrid.AddAccount(ingridAccount)
+	if err != nil {
+		log.Fatalf("error adding ingrid's account: %v", err)
+	}
 
 	ckbAsset := &asset.Asset{
 		IsCKBytes: true,
 		SUDT:      nil,
 	}
 
+	// The SUDT cells of a channel hold the capacity they occupy on top of
+	// the SUDT amount.
+	sudtCapacity := deployment.SUDTCellCapacity(d, sudtInfo.Script)
+	log.Printf("SUDT cells occupy %d Shannon", sudtCapacity)
 	sudtAsset := &asset.Asset{
 		IsCKBytes: false,
-		SUDT:      asset.NewSUDT(*sudtInfo.Script, uint64(sudtMaxCapacity)),
+		SUDT:      asset.NewSUDT(*sudtInfo.Script, sudtCapacity),
 	}
 	return &Setup{
-		Deployment: d,
-		SUDTInfo:   sudtInfo,
-		Wallets:    []*ckbwallet.EphemeralWallet{wAlice, wBob},
-		WalletAccs: []*ckbwallet.Account{aliceAccount, bobAccount},
-		CKBAsset:   ckbAsset,
-		SudtAsset:  sudtAsset,
-		AccKeys:    []*secp256k1.PrivateKey{keyAlice, keyBob},
+		Deployment:  d,
+		SUDTInfo:    sudtInfo,
+		Wallets:     []*ckbwallet.EphemeralWallet{wAlice, wBob, wIngrid},
+		WalletAccs:  []*ckbwallet.Account{aliceAccount, bobAccount, ingridAccount},
+		CKBAsset:    ckbAsset,
+		SudtAsset:   sudtAsset,
+		SudtMeta:    client.SUDTMeta{Symbol: sudtSymbol, Decimals: sudtDecimals},
+		AccKeys:     []*secp256k1.PrivateKey{keyAlice, keyBob, keyIngrid},
+		Locks:       make([]client.LockConfig, 3),
+		MultisigDep: systemScripts.Secp256k1Blake160MultisigAll.CellDep,
 	}
 }
 
@@ -104,63 +144,40 @@ func parseSUDTOwnerLockArg(pathToSUDTOwnerLockArg string) (string, error) {
 	return sudtOwnerLockArg, nil
 }
 
-func printBalances(ch *client.PaymentChannel, assets []asset.Asset) {
+func printBalances(c *client.PaymentClient, ch *client.PaymentChannel, assets []asset.Asset) {
 	chAlloc := ch.State().Allocation
 
-	// Constants for formatting CKBytes
-	const ckbyteConversionFactor = 100_000_000 // 1 CKByte = 100,000,000 smallest units
-	const sudtConversionFactor = 1             // SUDT is already in the smallest unit
-
 	// Log general information
 	log.Println("=== Allocation Balances ===")
 	for _, asset := range assets {
-		if asset.IsCKBytes {
-
-			// Get Alice's balance (participant 0)
-			aliceBalance := chAlloc.Balance(0, &asset)
-			aliceBalanceCKBytes := new(big.Float).Quo(new(big.Float).SetInt(aliceBalance), big.NewFloat(ckbyteConversionFactor))
+		// Get Alice's balance (participant 0)
+		aliceBalance := chAlloc.Balance(0, &asset)
 
-			// Get Bob's balance (participant 1)
-			bobBalance := chAlloc.Balance(1, &asset)
-			bobBalanceCKBytes := new(big.Float).Quo(new(big.Float).SetInt(bobBalance), big.NewFloat(ckbyteConversionFactor))
+		// Get Bob's balance (participant 1)
+		bobBalance := chAlloc.Balance(1, &asset)
 
-			// Print Alice's balance
-			log.Printf("Alice's allocation: %s CKBytes", aliceBalanceCKBytes.Text('f', 2))
+		// Print Alice's balance
+		log.Printf("Alice's allocation: %s", c.FormatAmount(&asset, aliceBalance))
 
-			// Print Bob's balance
-			log.Printf("Bob's allocation: %s CKBytes", bobBalanceCKBytes.Text('f', 2))
+		// Print Bob's balance
+		log.Printf("Bob's allocation: %s", c.FormatAmount(&asset, bobBalance))
 
-			// Calculate the total balance
-			totalBalance := new(big.Int).Add(aliceBalance, bobBalance)
-			totalBalanceCKBytes := new(big.Float).Quo(new(big.Float).SetInt(totalBalance), big.NewFloat(ckbyteConversionFactor))
+		// Calculate and print the total channel balance
+		totalBalance := new(big.Int).Add(aliceBalance, bobBalance)
+		log.Printf("Total channel balance: %s", c.FormatAmount(&asset, totalBalance))
 
-			// Print the total channel balance
-			log.Printf("Total channel balance: %s CKBytes", totalBalanceCKBytes.Text('f', 2))
-
-			log.Println("===========================")
-		} else {
-			// Get Alice's balance (participant 0)
-			aliceBalance := chAlloc.Balance(0, &asset)
-			aliceBalanceSUDTs := new(big.Float).Quo(new(big.Float).SetInt(aliceBalance), big.NewFloat(sudtConversionFactor))
-
-			// Get Bob's balance (participant 1)
-			bobBalance := chAlloc.Balance(1, &asset)
-			bobBalanceSUDTs := new(big.Float).Quo(new(big.Float).SetInt(bobBalance), big.NewFloat(sudtConversionFactor))
-
-			// Print Alice's balance
-			log.Printf("Alice's allocation: %s SUDT", aliceBalanceSUDTs.Text('f', 2))
-
-			// Print Bob's balance
-			log.Printf("Bob's allocation: %s SUDT", bobBalanceSUDTs.Text('f', 2))
-
-			// Calculate the total balance
-			totalBalance := new(big.Int).Add(aliceBalance, bobBalance)
-			totalBalanceSUDTs := new(big.Float).Quo(new(big.Float).SetInt(totalBalance), big.NewFloat(sudtConversionFactor))
-
-			// Print the total channel balance
-			log.Printf("Total channel balance: %s SUDT", totalBalanceSUDTs.Text('f', 2))
+		log.Println("===========================")
+	}
+}
 
-			log.Println("===========================")
-		}
+// verifyDeployment checks the cell deps of the deployment on the node.
+func verifyDeployment(d backend.Deployment) error {
+	rpcClient, err := rpc.Dial(rpcNodeURL)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", rpcNodeURL, err)
 	}
+	defer rpcClient.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return deployment.Verify(ctx, rpcClient, d)
 }
@@ -30,19 +30,20 @@ const (
 	chainID  = 1337
 
 	// Private keys.
-	keyDeployer = "79ea8f62d97bc0591a4224c1725fca6b00de5b2cea286fe2e0bb35c5e76be46e"
-	keyAlice    = "1af2e950272dd403de7a5760d41c6e44d92b6d02797e51810795ff03cc2cda4f"
-	keyBob      = "f63d7d8e930bccd74e93cf5662fde2c28fd8be95edb70c73f1bdd863d07f412e"
+	keyAlice = "1af2e950272dd403de7a5760d41c6e44d92b6d02797e51810795ff03cc2cda4f"
+	keyBob   = "f63d7d8e930bccd74e93cf5662fde2c28fd8be95edb70c73f1bdd863d07f412e"
 )
 
 // main runs a demo of the payment client. It assumes that a blockchain node is
-// available at `chainURL` and that the accounts corresponding to the specified
-// secret keys are provided with sufficient funds.
+// available at `chainURL`, that the contracts were deployed with `cmd/deploy`
+// and that the accounts corresponding to the specified secret keys are
+// provided with sufficient funds.
 func main() {
-	// Deploy contracts.
-	log.Println("Deploying contracts.")
-	adjudicator, assetHolder := deployContracts(chainURL, chainID, keyDeployer)
-	asset := *ethwallet.AsWalletAddr(assetHolder)
+	// Load the deployed contracts.
+	log.Println("Loading contracts.")
+	contracts := loadContracts(chainURL, chainID)
+	adjudicator := contracts.Adjudicator
+	asset := *ethwallet.AsWalletAddr(contracts.AssetHolder)
 
 	// Setup bus.
 	aliceWireAcc := p2p.NewRandomAccount(rand.New(rand.NewSource(time.Now().UnixNano())))
@@ -19,11 +19,9 @@ import (
 	"log"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
-	ethchannel "github.com/perun-network/perun-eth-backend/channel"
 	ethwallet "github.com/perun-network/perun-eth-backend/wallet"
 	swallet "github.com/perun-network/perun-eth-backend/wallet/simple"
 	"perun.network/go-perun/wallet"
@@ -32,34 +30,28 @@ import (
 	p2p "perun.network/go-perun/wire/net/libp2p"
 	perunio "perun.network/go-perun/wire/perunio/serializer"
 	"perun.network/perun-examples/payment-channel/client"
+	"perun.network/perun-examples/payment-channel/deployment"
+	"perun.network/perun-examples/shared/registry"
 )
 
-// deployContracts deploys the Perun smart contracts on the specified ledger.
-func deployContracts(nodeURL string, chainID uint64, privateKey string) (adj, ah common.Address) {
-	k, err := crypto.HexToECDSA(privateKey)
+// loadContracts loads the contracts of the specified ledger from the
+// deployment registry and verifies that they are deployed.
+func loadContracts(nodeURL string, chainID uint64) deployment.Contracts {
+	r, err := registry.Load(registry.DefaultFile)
 	if err != nil {
 		panic(err)
 	}
-	w := swallet.NewWallet(k)
-	cb, err := client.CreateContractBackend(nodeURL, chainID, w)
+	ethClient, err := ethclient.Dial(nodeURL)
 	if err != nil {
 		panic(err)
 	}
-	acc := accounts.Account{Address: crypto.PubkeyToAddress(k.PublicKey)}
+	defer ethClient.Close()
 
-	// Deploy adjudicator.
-	adj, err = ethchannel.DeployAdjudicator(context.TODO(), cb, acc)
+	c, err := deployment.Lookup(context.TODO(), ethClient, new(big.Int).SetUint64(chainID), r)
 	if err != nil {
-		panic(err)
+		log.Fatalf("Loading contracts: %v. Deploy them with: go run ./cmd/deploy", err)
 	}
-
-	// Deploy asset holder.
-	ah, err = ethchannel.DeployETHAssetholder(context.TODO(), cb, adj, acc)
-	if err != nil {
-		panic(err)
-	}
-
-	return adj, ah
+	return c
 }
 
 // setupPaymentClient sets up a new client with the given parameters.
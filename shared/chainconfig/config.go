@@ -0,0 +1,259 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chainconfig loads the configuration of the Ethereum chains of a
+// client from a YAML or JSON file and validates it. The package does not
+// depend on the client, so that all examples can use the same format.
+package chainconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"gopkg.in/yaml.v3"
+)
+
+// Defaults of the optional settings. The gas limits suffice for ETH deposits
+// and are the limits the Ethereum demos used before they were configurable.
+// ERC20 deposits need a higher deposit limit, which must be configured.
+const (
+	DefaultFinalityDepth       = 1
+	DefaultDepositGasLimit     = 50000
+	DefaultAdjudicatorGasLimit = 1000000
+)
+
+// maxGasLimit is the block gas limit. A transaction with a higher gas limit
+// is never mined.
+const maxGasLimit = 30000000
+
+// Config is the content of a chain configuration file.
+type Config struct {
+	Chains []Chain `json:"chains" yaml:"chains"`
+}
+
+// Chain is the configuration of a chain. The contract addresses are hex
+// encoded. They are optional, so that they can be taken from a deployment
+// instead.
+type Chain struct {
+	Name          string `json:"name" yaml:"name"`       // The unique name of the chain in the file.
+	ChainID       uint64 `json:"chainID" yaml:"chainID"` // The chain ID the node must report.
+	RPCURL        string `json:"rpcURL" yaml:"rpcURL"`   // The URL of the node: ws, wss, http or https.
+	Token         string `json:"token,omitempty" yaml:"token,omitempty"`
+	Adjudicator   string `json:"adjudicator,omitempty" yaml:"adjudicator,omitempty"`
+	AssetHolder   string `json:"assetHolder,omitempty" yaml:"assetHolder,omitempty"`
+	Gas           Gas    `json:"gas,omitempty" yaml:"gas,omitempty"`
+	FinalityDepth uint64 `json:"finalityDepth,omitempty" yaml:"finalityDepth,omitempty"` // The number of blocks that confirm a transaction.
+}
+
+// Gas holds the gas limits of the transactions of a client.
+type Gas struct {
+	DepositLimit     uint64 `json:"depositLimit,omitempty" yaml:"depositLimit,omitempty"`
+	AdjudicatorLimit uint64 `json:"adjudicatorLimit,omitempty" yaml:"adjudicatorLimit,omitempty"`
+}
+
+// Load reads the configuration from the file at path, sets the defaults of
+// missing optional settings and validates it. The format is given by the
+// file extension: .yaml, .yml or .json. Unknown fields are rejected.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&cfg)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&cfg)
+	default:
+		return nil, fmt.Errorf("%s: unsupported format %q, expected .yaml, .yml or .json", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: parsing: %w", path, err)
+	}
+
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// SetDefaults sets the missing optional settings to their defaults.
+func (c *Config) SetDefaults() {
+	for i := range c.Chains {
+		ch := &c.Chains[i]
+		if ch.FinalityDepth == 0 {
+			ch.FinalityDepth = DefaultFinalityDepth
+		}
+		if ch.Gas.DepositLimit == 0 {
+			ch.Gas.DepositLimit = DefaultDepositGasLimit
+		}
+		if ch.Gas.AdjudicatorLimit == 0 {
+			ch.Gas.AdjudicatorLimit = DefaultAdjudicatorGasLimit
+		}
+	}
+}
+
+// Validate checks the configuration. It returns all problems at once, each
+// prefixed with the field it concerns.
+func (c *Config) Validate() error {
+	if len(c.Chains) == 0 {
+		return errors.New("chains: no chains configured")
+	}
+
+	var errs []error
+	names := make(map[string]bool)
+	for i, ch := range c.Chains {
+		field := func(name string) string {
+			return fmt.Sprintf("chains[%d] (%s).%s", i, ch.Name, name)
+		}
+		fail := func(name, format string, args ...any) {
+			errs = append(errs, fmt.Errorf("%s: %s", field(name), fmt.Sprintf(format, args...)))
+		}
+
+		switch {
+		case ch.Name == "":
+			fail("name", "missing")
+		case names[ch.Name]:
+			fail("name", "duplicate name %q", ch.Name)
+		}
+		names[ch.Name] = true
+
+		if ch.ChainID == 0 {
+			fail("chainID", "missing")
+		}
+		if err := validateURL(ch.RPCURL); err != nil {
+			fail("rpcURL", "%v", err)
+		}
+		for _, a := range [][2]string{{"token", ch.Token}, {"adjudicator", ch.Adjudicator}, {"assetHolder", ch.AssetHolder}} {
+			if a[1] == "" {
+				continue
+			}
+			if !common.IsHexAddress(a[1]) {
+				fail(a[0], "invalid address %q", a[1])
+			} else if common.HexToAddress(a[1]) == (common.Address{}) {
+				fail(a[0], "zero address")
+			}
+		}
+		if ch.FinalityDepth == 0 {
+			fail("finalityDepth", "must be at least 1")
+		}
+		if ch.Gas.DepositLimit > maxGasLimit {
+			fail("gas.depositLimit", "%d exceeds the block gas limit %d", ch.Gas.DepositLimit, maxGasLimit)
+		}
+		if ch.Gas.AdjudicatorLimit > maxGasLimit {
+			fail("gas.adjudicatorLimit", "%d exceeds the block gas limit %d", ch.Gas.AdjudicatorLimit, maxGasLimit)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateURL checks that the RPC URL has a supported scheme and a host.
+func validateURL(rawURL string) error {
+	if rawURL == "" {
+		return errors.New("missing")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "ws", "wss", "http", "https":
+	default:
+		return fmt.Errorf("unsupported scheme %q in %q, expected ws, wss, http or https", u.Scheme, rawURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("missing host in %q", rawURL)
+	}
+	return nil
+}
+
+// Chain returns the chain with the given name.
+func (c *Config) Chain(name string) (Chain, error) {
+	for _, ch := range c.Chains {
+		if ch.Name == name {
+			return ch, nil
+		}
+	}
+	return Chain{}, fmt.Errorf("chain %q not configured", name)
+}
+
+// ID returns the chain ID.
+func (c Chain) ID() *big.Int {
+	return new(big.Int).SetUint64(c.ChainID)
+}
+
+// TokenAddress returns the address of the token, or the zero address if it
+// is not configured.
+func (c Chain) TokenAddress() common.Address {
+	return common.HexToAddress(c.Token)
+}
+
+// AdjudicatorAddress returns the address of the adjudicator, or the zero
+// address if it is not configured.
+func (c Chain) AdjudicatorAddress() common.Address {
+	return common.HexToAddress(c.Adjudicator)
+}
+
+// AssetHolderAddress returns the address of the asset holder, or the zero
+// address if it is not configured.
+func (c Chain) AssetHolderAddress() common.Address {
+	return common.HexToAddress(c.AssetHolder)
+}
+
+// Dial connects to the node of the chain and checks that the node reports
+// the configured chain ID.
+func (c Chain) Dial(ctx context.Context) (*ethclient.Client, error) {
+	client, err := ethclient.DialContext(ctx, c.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("chain %s: connecting to %s: %w", c.Name, c.RPCURL, err)
+	}
+	if err := CheckChainID(ctx, client, c.ID()); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("chain %s: node at %s: %w", c.Name, c.RPCURL, err)
+	}
+	return client, nil
+}
+
+// ChainIDReader reads the chain ID of a node. ethclient.Client implements it.
+type ChainIDReader interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// CheckChainID checks that the node reports the expected chain ID.
+func CheckChainID(ctx context.Context, node ChainIDReader, expected *big.Int) error {
+	id, err := node.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("reading chain ID: %w", err)
+	}
+	if id.Cmp(expected) != 0 {
+		return fmt.Errorf("reports chain ID %v, but %v is configured", id, expected)
+	}
+	return nil
+}
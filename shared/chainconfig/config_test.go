@@ -0,0 +1,127 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainconfig
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	yamlPath := writeFile(t, "chains.yaml", `
+chains:
+  - name: a
+    chainID: 1337
+    rpcURL: ws://127.0.0.1:8545
+    adjudicator: 0x56FD289cEe714a5E471c418436EFA63E780D7a87
+    gas:
+      depositLimit: 200000
+  - name: b
+    chainID: 1338
+    rpcURL: https://node.example
+    finalityDepth: 12
+`)
+	jsonPath := writeFile(t, "chains.json", `{"chains": [
+	{"name": "a", "chainID": 1337, "rpcURL": "ws://127.0.0.1:8545", "adjudicator": "0x56FD289cEe714a5E471c418436EFA63E780D7a87", "gas": {"depositLimit": 200000}},
+	{"name": "b", "chainID": 1338, "rpcURL": "https://node.example", "finalityDepth": 12}
+]}`)
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		cfg, err := Load(path)
+		require.NoError(t, err)
+		require.Len(t, cfg.Chains, 2)
+
+		a, err := cfg.Chain("a")
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1337), a.ID())
+		require.Equal(t, common.HexToAddress("0x56FD289cEe714a5E471c418436EFA63E780D7a87"), a.AdjudicatorAddress())
+		require.Equal(t, common.Address{}, a.TokenAddress())
+		require.Equal(t, Gas{DepositLimit: 200000, AdjudicatorLimit: DefaultAdjudicatorGasLimit}, a.Gas)
+		require.Equal(t, uint64(DefaultFinalityDepth), a.FinalityDepth)
+
+		b, err := cfg.Chain("b")
+		require.NoError(t, err)
+		require.Equal(t, uint64(12), b.FinalityDepth)
+
+		_, err = cfg.Chain("c")
+		require.ErrorContains(t, err, `chain "c" not configured`)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	_, err := Load(writeFile(t, "chains.yaml", `
+chains:
+  - name: a
+    chainID: 1337
+    rpcURL: ws://127.0.0.1:8545
+    rpcUrl: ws://127.0.0.1:8546
+`))
+	require.ErrorContains(t, err, "field rpcUrl not found")
+
+	_, err = Load(writeFile(t, "chains.toml", ""))
+	require.ErrorContains(t, err, `unsupported format ".toml"`)
+
+	_, err = Load(writeFile(t, "chains.json", `{"chains": []}`))
+	require.ErrorContains(t, err, "chains: no chains configured")
+
+	// All problems are reported at once.
+	_, err = Load(writeFile(t, "chains.yaml", `
+chains:
+  - name: a
+    rpcURL: tcp://127.0.0.1:8545
+    token: 0x1234
+    assetHolder: 0x0000000000000000000000000000000000000000
+  - name: a
+    chainID: 1338
+    rpcURL: ws://
+    gas:
+      adjudicatorLimit: 50000000
+`))
+	for _, msg := range []string{
+		"chains[0] (a).chainID: missing",
+		`chains[0] (a).rpcURL: unsupported scheme "tcp"`,
+		`chains[0] (a).token: invalid address "0x1234"`,
+		"chains[0] (a).assetHolder: zero address",
+		`chains[1] (a).name: duplicate name "a"`,
+		`chains[1] (a).rpcURL: missing host in "ws://"`,
+		"chains[1] (a).gas.adjudicatorLimit: 50000000 exceeds the block gas limit 30000000",
+	} {
+		require.ErrorContains(t, err, msg)
+	}
+}
+
+type chainIDReader int64
+
+func (r chainIDReader) ChainID(context.Context) (*big.Int, error) {
+	return big.NewInt(int64(r)), nil
+}
+
+func TestCheckChainID(t *testing.T) {
+	require.NoError(t, CheckChainID(context.Background(), chainIDReader(1337), big.NewInt(1337)))
+	require.EqualError(t, CheckChainID(context.Background(), chainIDReader(1), big.NewInt(1337)),
+		"reports chain ID 1, but 1337 is configured")
+}
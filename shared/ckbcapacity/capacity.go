@@ -0,0 +1,77 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ckbcapacity computes the capacity that the cells of a CKB payment
+// channel occupy. It takes the locks of the payout cells as a parameter, so
+// that the examples with different lock types share the computation.
+package ckbcapacity
+
+import "github.com/nervosnetwork/ckb-sdk-go/v2/types"
+
+const (
+	// ShannonsPerByte is the capacity in Shannon that a byte of a cell
+	// occupies.
+	ShannonsPerByte = 100_000_000
+	// capacityFieldSize is the size of the capacity field of a cell.
+	capacityFieldSize = 8
+	// scriptHashSize is the size of a script hash, e.g., the hash of the
+	// channel type script in the args of the funding lock.
+	scriptHashSize = 32
+	// SUDTAmountSize is the size of the SUDT amount in the cell data.
+	SUDTAmountSize = 16
+)
+
+// OccupiedCapacity returns the minimum capacity in Shannon of a cell with the
+// given lock, optional type script and data length. Each byte of the cell,
+// including its capacity field, occupies one CKByte.
+func OccupiedCapacity(lock, typeScript *types.Script, dataLen int) uint64 {
+	size := capacityFieldSize + scriptSize(lock) + scriptSize(typeScript) + dataLen
+	return uint64(size) * ShannonsPerByte
+}
+
+// scriptSize returns the size of the script in a cell, or 0 for no script.
+func scriptSize(s *types.Script) int {
+	if s == nil {
+		return 0
+	}
+	return len(s.CodeHash) + 1 + len(s.Args)
+}
+
+// FundingLock returns the lock of the funding cells of a channel with the
+// given PFLS code hash and hash type. Its args hold the hash of the channel
+// type script, which is only known once the channel is proposed, so they are
+// zero here.
+func FundingLock(codeHash types.Hash, hashType types.ScriptHashType) *types.Script {
+	return &types.Script{
+		CodeHash: codeHash,
+		HashType: hashType,
+		Args:     make([]byte, scriptHashSize),
+	}
+}
+
+// CellCapacity returns the minimum capacity in Shannon of a cell that holds
+// an asset of a participant during the lifetime of a channel: as a funding
+// cell with the funding lock and as a payout cell with any of the payout
+// locks. The type script and data length are those of the asset, nil and 0
+// for CKBytes. The capacity is at least minCapacity.
+//
+// The args of a payout lock must be at least as long as those of the payment
+// scripts it stands for, so that the capacity covers them.
+func CellCapacity(minCapacity uint64, fundingLock, typeScript *types.Script, dataLen int, payoutLocks ...*types.Script) uint64 {
+	capacity := max(minCapacity, OccupiedCapacity(fundingLock, typeScript, dataLen))
+	for _, lock := range payoutLocks {
+		capacity = max(capacity, OccupiedCapacity(lock, typeScript, dataLen))
+	}
+	return capacity
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ckbcapacity
+
+import (
+	"testing"
+
+	"github.com/nervosnetwork/ckb-sdk-go/v2/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCellCapacity(t *testing.T) {
+	lock := &types.Script{HashType: types.HashTypeType, Args: make([]byte, 20)}
+	require.Equal(t, uint64(61*ShannonsPerByte), OccupiedCapacity(lock, nil, 0), "default lock")
+
+	funding := FundingLock(types.Hash{}, types.HashTypeData1)
+	// 8 bytes capacity and 65 bytes funding lock.
+	require.Equal(t, uint64(73*ShannonsPerByte), CellCapacity(0, funding, nil, 0, lock))
+	// Plus 65 bytes SUDT type script and 16 bytes amount.
+	sudt := &types.Script{HashType: types.HashTypeData1, Args: make([]byte, 32)}
+	require.Equal(t, uint64(154*ShannonsPerByte), CellCapacity(0, funding, sudt, SUDTAmountSize, lock))
+
+	require.Equal(t, uint64(100*ShannonsPerByte), CellCapacity(100*ShannonsPerByte, funding, nil, 0, lock), "minimum")
+
+	// The largest payout lock determines the capacity.
+	large := &types.Script{HashType: types.HashTypeType, Args: make([]byte, 64)}
+	require.Equal(t, OccupiedCapacity(large, sudt, SUDTAmountSize), CellCapacity(0, funding, sudt, SUDTAmountSize, lock, large))
+}
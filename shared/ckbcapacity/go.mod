@@ -0,0 +1,10 @@
+module perun.network/perun-examples/shared/ckbcapacity
+
+go 1.23.0
+
+require (
+	github.com/nervosnetwork/ckb-sdk-go/v2 v2.2.0
+	github.com/stretchr/testify v1.8.4
+)
+
+replace github.com/nervosnetwork/ckb-sdk-go/v2 v2.2.0 => github.com/perun-network/ckb-sdk-go/v2 v2.2.1-0.20250729062233-916bd7327fac
@@ -0,0 +1,196 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registry records the contracts deployed on each chain in a
+// registry file, so that they are deployed once and reused by later runs.
+// The examples share the package, so that all of them use the same format.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	ethchannel "github.com/perun-network/perun-eth-backend/channel"
+)
+
+// DefaultFile is the default path of the registry file.
+const DefaultFile = "deployments.json"
+
+// Contract is a deployed contract.
+type Contract struct {
+	Address  common.Address `json:"address"`
+	CodeHash common.Hash    `json:"codeHash"` // The hash of the deployed runtime code.
+	Block    uint64         `json:"block"`    // The block in which the contract was deployed.
+}
+
+// Registry holds the contracts deployed on each chain by chain ID and
+// contract name.
+type Registry struct {
+	Chains map[string]map[string]Contract `json:"chains"`
+}
+
+// New returns an empty registry.
+func New() *Registry {
+	return &Registry{Chains: make(map[string]map[string]Contract)}
+}
+
+// Load reads the registry from the file at path. If the file does not exist,
+// it returns an empty registry.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	r := New()
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("parsing registry %s: %w", path, err)
+	}
+	if r.Chains == nil {
+		r.Chains = make(map[string]map[string]Contract)
+	}
+	return r, nil
+}
+
+// Save writes the registry to the file at path. The file is replaced
+// atomically, so that an interrupted write does not corrupt it.
+func (r *Registry) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // The file is gone after the rename.
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Contract returns the named contract recorded for the chain.
+func (r *Registry) Contract(chainID *big.Int, name string) (Contract, bool) {
+	c, ok := r.Chains[chainID.String()][name]
+	return c, ok
+}
+
+// SetContract records the named contract for the chain.
+func (r *Registry) SetContract(chainID *big.Int, name string, c Contract) {
+	key := chainID.String()
+	if r.Chains[key] == nil {
+		r.Chains[key] = make(map[string]Contract)
+	}
+	r.Chains[key][name] = c
+}
+
+// Lookup returns the address of the named contract recorded for the chain and
+// verifies that its code is deployed.
+func (r *Registry) Lookup(ctx context.Context, backend bind.ContractBackend, chainID *big.Int, name string) (common.Address, error) {
+	c, ok := r.Contract(chainID, name)
+	if !ok {
+		return common.Address{}, fmt.Errorf("%s not deployed on chain %v", name, chainID)
+	}
+	if err := Verify(ctx, backend, c); err != nil {
+		return common.Address{}, fmt.Errorf("%s on chain %v: %w", name, chainID, err)
+	}
+	return c.Address, nil
+}
+
+// Verify checks that the recorded code of the contract is deployed at its
+// address. It fails if the chain was reset since the deployment.
+func Verify(ctx context.Context, backend bind.ContractBackend, c Contract) error {
+	code, err := backend.CodeAt(ctx, c.Address, nil)
+	if err != nil {
+		return fmt.Errorf("reading code at %v: %w", c.Address, err)
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no code at %v", c.Address)
+	}
+	if crypto.Keccak256Hash(code) != c.CodeHash {
+		return fmt.Errorf("code at %v does not match the registry", c.Address)
+	}
+	return nil
+}
+
+// Ensure returns the named contract if it is recorded for the chain of the
+// backend and its code is deployed, unless redeploy is set because a contract
+// it depends on was deployed anew. Otherwise, it deploys the contract and
+// records it. It reports whether the contract was deployed.
+func (r *Registry) Ensure(ctx context.Context, cb ethchannel.ContractBackend, name string, redeploy bool, deploy func() (common.Address, error)) (Contract, bool, error) {
+	chainID := cb.ChainID().Int
+	if c, ok := r.Contract(chainID, name); ok && !redeploy && Verify(ctx, cb, c) == nil {
+		return c, false, nil
+	}
+
+	head, err := cb.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return Contract{}, false, fmt.Errorf("reading head: %w", err)
+	}
+	addr, err := deploy()
+	if err != nil {
+		return Contract{}, false, fmt.Errorf("deploying %s: %w", name, err)
+	}
+	c, err := record(ctx, cb, addr, head.Number.Uint64())
+	if err != nil {
+		return Contract{}, false, fmt.Errorf("recording %s: %w", name, err)
+	}
+	r.SetContract(chainID, name, c)
+	return c, true, nil
+}
+
+// record returns the registry entry of the contract at addr, which was
+// deployed after block from.
+func record(ctx context.Context, cb ethchannel.ContractBackend, addr common.Address, from uint64) (Contract, error) {
+	code, err := cb.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return Contract{}, err
+	}
+	head, err := cb.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return Contract{}, err
+	}
+
+	// Search the first block with code at the address.
+	lo, hi := from, head.Number.Uint64()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		c, err := cb.CodeAt(ctx, addr, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return Contract{}, err
+		}
+		if len(c) > 0 {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return Contract{Address: addr, CodeHash: crypto.Keccak256Hash(code), Block: lo}, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 PolyCrypt GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// codeBackend is a contract backend that only serves code.
+type codeBackend struct {
+	bind.ContractBackend
+	code map[common.Address][]byte
+}
+
+func (b codeBackend) CodeAt(_ context.Context, addr common.Address, _ *big.Int) ([]byte, error) {
+	return b.code[addr], nil
+}
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	chainID := big.NewInt(1337)
+	backend := codeBackend{code: map[common.Address][]byte{
+		{1}: []byte("adjudicator"),
+	}}
+
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	r, err := Load(path)
+	require.NoError(t, err)
+	require.Empty(t, r.Chains)
+	_, err = r.Lookup(ctx, backend, chainID, "adjudicator")
+	require.ErrorContains(t, err, "adjudicator not deployed on chain 1337")
+
+	adj := Contract{Address: common.Address{1}, CodeHash: crypto.Keccak256Hash([]byte("adjudicator")), Block: 3}
+	r.SetContract(chainID, "adjudicator", adj)
+	require.NoError(t, r.Save(path))
+
+	r, err = Load(path)
+	require.NoError(t, err)
+	got, ok := r.Contract(chainID, "adjudicator")
+	require.True(t, ok)
+	require.Equal(t, adj, got)
+	addr, err := r.Lookup(ctx, backend, chainID, "adjudicator")
+	require.NoError(t, err)
+	require.Equal(t, adj.Address, addr)
+	_, err = r.Lookup(ctx, backend, big.NewInt(1), "adjudicator")
+	require.ErrorContains(t, err, "not deployed on chain 1")
+
+	// The chain was reset or the code differs from the recorded one.
+	backend.code[adj.Address] = []byte("other")
+	_, err = r.Lookup(ctx, backend, chainID, "adjudicator")
+	require.ErrorContains(t, err, "does not match the registry")
+	delete(backend.code, adj.Address)
+	_, err = r.Lookup(ctx, backend, chainID, "adjudicator")
+	require.ErrorContains(t, err, "no code at")
+}